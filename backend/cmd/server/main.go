@@ -1,14 +1,28 @@
 package main
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+	"github.com/voiceline/backend/internal/infrastructure/mail"
+	"github.com/voiceline/backend/internal/infrastructure/oidc"
 	"github.com/voiceline/backend/internal/infrastructure/openai"
 	"github.com/voiceline/backend/internal/infrastructure/persistence"
+	"github.com/voiceline/backend/internal/infrastructure/storage"
+	"github.com/voiceline/backend/internal/infrastructure/summarizer"
+	"github.com/voiceline/backend/internal/infrastructure/webhook"
 	httpInterface "github.com/voiceline/backend/internal/interface/http"
 )
 
@@ -18,9 +32,28 @@ func main() {
 
 	// Load configuration from environment
 	port := getEnv("PORT", "8080")
-	jwtSecret := getEnv("JWT_SECRET", "your-super-secret-jwt-key")
+	// Development mode relaxes the secret checks, so it must be chosen explicitly
+	environment := getEnv("ENVIRONMENT", "production")
+	jwtSecret := getEnv("JWT_SECRET", defaultJWTSecret)
+	jwtAlgorithm := getEnv("JWT_ALGORITHM", entities.SigningAlgorithmEdDSA)
 	openAIKey := getEnv("OPENAI_API_KEY", "")
 
+	if insecureJWTSecrets[jwtSecret] {
+		if environment != "development" {
+			log.Fatalf("JWT_SECRET must be changed from its default when ENVIRONMENT=%s", environment)
+		}
+		log.Println("WARNING: JWT_SECRET is set to its default. Only use it for development.")
+	}
+
+	// Export download links get their own key so that a leaked link key never
+	// allows forging access tokens
+	exportSigningSecret := getEnv("EXPORT_SIGNING_SECRET", "")
+	if exportSigningSecret == "" {
+		exportSigningSecret = deriveSecret(jwtSecret, "export-download-links")
+	} else if exportSigningSecret == jwtSecret {
+		log.Fatalf("EXPORT_SIGNING_SECRET must differ from JWT_SECRET")
+	}
+
 	if openAIKey == "" {
 		log.Println("WARNING: OPENAI_API_KEY is not set. Transcription will not work.")
 	}
@@ -28,6 +61,43 @@ func main() {
 	// Initialize repositories
 	userRepo := persistence.NewMemoryUserRepository()
 	transcriptionRepo := persistence.NewMemoryTranscriptionRepository()
+	webhookRepo := persistence.NewMemoryWebhookRepository()
+	webhookDeliveryRepo := persistence.NewMemoryWebhookDeliveryRepository()
+	outboxRepo := persistence.NewMemoryOutboxRepository()
+	transactor := persistence.NewMemoryTransactor()
+	idempotencyRepo := persistence.NewMemoryIdempotencyRepository()
+	usageRepo := persistence.NewMemoryUsageRepository()
+	usageQuotaRepo := persistence.NewMemoryUsageQuotaRepository()
+	rateLimitStore := persistence.NewMemoryRateLimitStore()
+	loginThrottleRepo := persistence.NewMemoryLoginThrottleRepository()
+	userTokenRepo := persistence.NewMemoryUserTokenRepository()
+	exportJobRepo := persistence.NewMemoryExportJobRepository()
+	auditLogRepo := persistence.NewMemoryAuditLogRepository()
+	apiKeyRepo := persistence.NewMemoryAPIKeyRepository()
+	signingKeyRepo := persistence.NewMemorySigningKeyRepository()
+	externalIdentityRepo := persistence.NewMemoryExternalIdentityRepository()
+	oidcLoginRepo := persistence.NewMemoryOIDCLoginRequestRepository()
+	totpFactorRepo := persistence.NewMemoryTOTPFactorRepository()
+	sessionRepo := persistence.NewMemorySessionRepository()
+	organizationRepo := persistence.NewMemoryOrganizationRepository()
+	organizationMemberRepo := persistence.NewMemoryOrganizationMemberRepository()
+	organizationInvitationRepo := persistence.NewMemoryOrganizationInvitationRepository()
+	transcriptionShareRepo := persistence.NewMemoryTranscriptionShareRepository()
+	shareLinkRepo := persistence.NewMemoryShareLinkRepository()
+	commentRepo := persistence.NewMemoryCommentRepository()
+	highlightRepo := persistence.NewMemoryHighlightRepository()
+	translationRepo := persistence.NewMemoryTranslationRepository()
+	textProcessingRepo := persistence.NewMemoryTextProcessingSettingsRepository()
+
+	audioStore, err := storage.NewLocalFileStore(getEnv("AUDIO_STORAGE_DIR", "data/audio"))
+	if err != nil {
+		log.Fatalf("Failed to initialize audio storage: %v", err)
+	}
+
+	exportStore, err := storage.NewLocalFileStore(getEnv("EXPORT_STORAGE_DIR", "data/exports"))
+	if err != nil {
+		log.Fatalf("Failed to initialize export storage: %v", err)
+	}
 
 	// Initialize OpenAI service
 	openAIService, err := openai.NewTranscriptionService(openAIKey)
@@ -40,11 +110,162 @@ func main() {
 	}
 
 	// Initialize services
-	authService := services.NewAuthService(userRepo, jwtSecret)
-	transcriptionService := services.NewTranscriptionService(transcriptionRepo, openAIService)
+	usagePolicy := services.DefaultUsagePolicy()
+	usagePolicy.PlanMonthlyMinutes[entities.PlanFree] = getEnvFloat("QUOTA_FREE_MINUTES", usagePolicy.PlanMonthlyMinutes[entities.PlanFree])
+	usagePolicy.PlanMonthlyMinutes[entities.PlanPro] = getEnvFloat("QUOTA_PRO_MINUTES", usagePolicy.PlanMonthlyMinutes[entities.PlanPro])
+	usagePolicy.CostPerMinute = getEnvFloat("TRANSCRIPTION_COST_PER_MINUTE", usagePolicy.CostPerMinute)
+	usagePolicy.TextCostPerMinute = getEnvFloat("TEXT_PROCESSING_COST_PER_MINUTE", usagePolicy.TextCostPerMinute)
+	usageService := services.NewUsageService(usageRepo, usageQuotaRepo, userRepo, usagePolicy)
+
+	loginPolicy := services.DefaultLoginProtectionPolicy()
+	loginPolicy.Account.LockoutThreshold = getEnvInt("LOGIN_LOCKOUT_THRESHOLD", loginPolicy.Account.LockoutThreshold)
+	loginPolicy.Account.LockoutDuration = getEnvDuration("LOGIN_LOCKOUT_DURATION", loginPolicy.Account.LockoutDuration)
+	loginPolicy.IP.LockoutDuration = loginPolicy.Account.LockoutDuration
+	loginProtection := services.NewLoginProtectionService(loginThrottleRepo, loginPolicy)
+
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo)
+	mfaService := services.NewMFAService(totpFactorRepo, userRepo, getEnv("MFA_ISSUER", services.DefaultMFAIssuer))
+	sessionService := services.NewSessionService(sessionRepo)
+	adminEmails := strings.Split(getEnv("ADMIN_EMAILS", ""), ",")
+	authService := services.NewAuthService(userRepo, jwtSecret).
+		WithOutbox(transactor, outboxRepo).
+		WithLoginProtection(loginProtection).
+		WithAdminEmails(adminEmails).
+		WithAPIKeys(apiKeyService).
+		WithMFA(mfaService).
+		WithSessions(sessionService)
+
+	// Sign tokens with rotating asymmetric keys unless HS256 is requested
+	var signingKeyService *services.SigningKeyService
+	if jwtAlgorithm != "HS256" {
+		signingPolicy := services.DefaultSigningKeyPolicy()
+		signingPolicy.Algorithm = jwtAlgorithm
+		signingPolicy.RotationInterval = getEnvDuration("JWT_KEY_ROTATION_INTERVAL", signingPolicy.RotationInterval)
+		signingKeyService = services.NewSigningKeyService(signingKeyRepo, signingPolicy)
+
+		if err := signingKeyService.EnsureActiveKey(context.Background()); err != nil {
+			log.Fatalf("Failed to initialize JWT signing keys: %v", err)
+		}
+		authService.WithSigningKeys(signingKeyService)
+	}
+	redactionKinds, err := entities.ParsePIIKinds(getEnv("PII_REDACTION", "email,phone,card_number,iban"))
+	if err != nil {
+		log.Fatalf("Invalid PII_REDACTION: %v", err)
+	}
+	textStages, err := entities.ParseTextStages(getEnv("TEXT_PROCESSING_STAGES", ""))
+	if err != nil {
+		log.Fatalf("Invalid TEXT_PROCESSING_STAGES: %v", err)
+	}
+	textProcessingService := services.NewTextProcessingService(textProcessingRepo).WithDefaultStages(textStages)
+	dedupScope, err := services.ParseDeduplicationScope(getEnv("TRANSCRIPTION_DEDUP_SCOPE", string(services.DeduplicationUser)))
+	if err != nil {
+		log.Fatalf("Invalid TRANSCRIPTION_DEDUP_SCOPE: %v", err)
+	}
+	transcriptionService := services.NewTranscriptionService(transcriptionRepo, openAIService).
+		WithOutbox(transactor, outboxRepo).
+		WithAudioStore(audioStore).
+		WithDeduplication(dedupScope).
+		WithUsageMetering(usageService).
+		WithTextProcessing(textProcessingService).
+		WithRedaction(redactionKinds)
+	appBaseURL := getEnv("APP_BASE_URL", "http://localhost:3000")
+	mailer := newMailer()
+	accountService := services.NewAccountService(userRepo, userTokenRepo, mailer, services.AccountLinks{
+		VerifyEmailURL:   appBaseURL + "/verify-email",
+		ResetPasswordURL: appBaseURL + "/reset-password",
+	}).WithLoginProtection(loginProtection).
+		WithAdminEmails(adminEmails).
+		WithSessions(sessionService)
+	organizationService := services.NewOrganizationService(
+		organizationRepo, organizationMemberRepo, organizationInvitationRepo, userRepo, mailer, appBaseURL+"/invitations",
+	)
+	sharingService := services.NewSharingService(transcriptionShareRepo, shareLinkRepo, transcriptionRepo, userRepo, appBaseURL+"/shared").
+		WithNotifications(mailer, appBaseURL+"/transcriptions")
+	transcriptionService.WithOrganizations(organizationService).WithSharing(sharingService)
+	commentService := services.NewCommentService(commentRepo, highlightRepo, transcriptionService)
+	summaryService := newSummaryService(transcriptionRepo, transcriptionService, usageService, openAIKey)
+	translationService := newTranslationService(translationRepo, transcriptionService, usageService, openAIKey)
+	userService := services.NewUserService(userRepo, transcriptionService).
+		WithOutbox(transactor, outboxRepo).
+		WithAccountService(accountService).
+		WithMFA(mfaService).
+		WithSessions(sessionService)
+	exportService := services.NewExportService(exportJobRepo, userRepo, transcriptionRepo, exportStore, exportSigningSecret).
+		WithAudioStore(audioStore).
+		WithTranslations(translationRepo)
+	oidcService := newOIDCService(externalIdentityRepo, oidcLoginRepo, userRepo, authService, appBaseURL)
+	adminService := services.NewAdminService(userRepo, transcriptionRepo, auditLogRepo, usageService)
+	webhookSender := webhook.NewHTTPSender(10 * time.Second)
+	webhookService := services.NewWebhookService(webhookRepo, webhookDeliveryRepo, webhookSender)
+	if getEnv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "false") == "true" {
+		webhookSender.AllowPrivateNetworks()
+		webhookService.AllowPrivateNetworks()
+	}
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, getEnvDuration("IDEMPOTENCY_TTL", services.DefaultIdempotencyTTL))
+
+	// Subscribe to domain events
+	eventBus := services.NewEventBus()
+	webhookService.Subscribe(eventBus)
+	accountService.Subscribe(eventBus)
+	sessionService.Subscribe(eventBus)
+	exportService.Subscribe(eventBus)
+	apiKeyService.Subscribe(eventBus)
+	mfaService.Subscribe(eventBus)
+	organizationService.Subscribe(eventBus)
+	sharingService.Subscribe(eventBus)
+	commentService.Subscribe(eventBus)
+	summaryService.Subscribe(eventBus)
+	textProcessingService.Subscribe(eventBus)
+	if translationService != nil {
+		translationService.Subscribe(eventBus)
+	}
+	if oidcService != nil {
+		oidcService.Subscribe(eventBus)
+	}
+
+	// Start background workers
+	outboxDispatcher := services.NewOutboxDispatcher(outboxRepo, eventBus)
+	go outboxDispatcher.Run(context.Background(), time.Second)
+	go webhookService.Run(context.Background(), 5*time.Second)
+	go idempotencyService.Run(context.Background(), time.Hour)
+	go loginProtection.Run(context.Background(), 10*time.Minute)
+	go exportService.Run(context.Background(), 5*time.Second)
+	go sessionService.Run(context.Background(), time.Hour)
+	go organizationService.Run(context.Background(), time.Hour)
+	if signingKeyService != nil {
+		go signingKeyService.Run(context.Background(), time.Hour)
+	}
+	if oidcService != nil {
+		go oidcService.Run(context.Background(), 10*time.Minute)
+	}
+
+	// Configure rate limits
+	rateLimits := httpInterface.DefaultRateLimitPolicies()
+	rateLimits.Auth = getEnvRateLimit("RATE_LIMIT_AUTH", rateLimits.Auth)
+	rateLimits.Transcriptions = getEnvRateLimit("RATE_LIMIT_TRANSCRIPTIONS", rateLimits.Transcriptions)
+	rateLimits.API = getEnvRateLimit("RATE_LIMIT_API", rateLimits.API)
 
 	// Initialize HTTP router
-	router := httpInterface.NewRouter(authService, transcriptionService)
+	router := httpInterface.NewRouter(authService, transcriptionService).
+		WithWebhookService(webhookService).
+		WithIdempotencyService(idempotencyService).
+		WithUsageService(usageService).
+		WithAccountService(accountService).
+		WithUserService(userService).
+		WithExportService(exportService).
+		WithAdminService(adminService).
+		WithAPIKeyService(apiKeyService).
+		WithSigningKeys(signingKeyService).
+		WithOIDCService(oidcService).
+		WithMFAService(mfaService).
+		WithSessionService(sessionService).
+		WithOrganizationService(organizationService).
+		WithSharingService(sharingService).
+		WithCommentService(commentService).
+		WithSummaryService(summaryService).
+		WithTranslationService(translationService).
+		WithTextProcessingService(textProcessingService).
+		WithRateLimiting(rateLimitStore, rateLimits)
 	engine := router.Setup()
 
 	// Start server
@@ -55,6 +276,127 @@ func main() {
 	}
 }
 
+const defaultJWTSecret = "your-super-secret-jwt-key"
+
+// insecureJWTSecrets are the published placeholder secrets, which must not
+// protect a real deployment
+var insecureJWTSecrets = map[string]bool{
+	defaultJWTSecret: true,
+	"your-super-secret-jwt-key-change-this-in-production": true,
+}
+
+// newMailer sends through SMTP_HOST when set and logs email otherwise
+func newMailer() services.IMailer {
+	host := getEnv("SMTP_HOST", "")
+	if host == "" {
+		log.Println("WARNING: SMTP_HOST is not set. Emails will be written to the log.")
+		return mail.NewLogMailer()
+	}
+
+	return mail.NewSMTPMailer(
+		host,
+		getEnv("SMTP_PORT", "587"),
+		getEnv("SMTP_USERNAME", ""),
+		getEnv("SMTP_PASSWORD", ""),
+		getEnv("SMTP_FROM", "Voiceline <no-reply@voiceline.app>"),
+	)
+}
+
+// newSummaryService summarizes with the summarizer named by SUMMARIZER.
+// OpenAI is used by default when an API key is configured, and its summaries
+// are metered; the local extractive summarizer is used otherwise.
+func newSummaryService(
+	transcriptionRepo repositories.TranscriptionRepository,
+	transcriptionService *services.TranscriptionService,
+	usageService *services.UsageService,
+	openAIKey string,
+) *services.SummaryService {
+	name := "local"
+	if openAIKey != "" {
+		name = "openai"
+	}
+
+	switch strings.ToLower(getEnv("SUMMARIZER", name)) {
+	case "openai":
+		openAISummarizer, err := openai.NewSummarizer(openAIKey, getEnv("OPENAI_SUMMARY_MODEL", openai.DefaultSummaryModel))
+		if err == nil {
+			return services.NewSummaryService(transcriptionRepo, transcriptionService, openAISummarizer).
+				WithUsageMetering(usageService)
+		}
+		log.Printf("WARNING: OpenAI summarizer unavailable (%v). Falling back to local summaries.", err)
+	case "local":
+	default:
+		log.Fatalf("SUMMARIZER must be openai or local")
+	}
+
+	return services.NewSummaryService(transcriptionRepo, transcriptionService, summarizer.NewLocalSummarizer())
+}
+
+// newTranslationService translates with OpenAI, or returns nil when no API
+// key is configured
+func newTranslationService(
+	translationRepo repositories.TranslationRepository,
+	transcriptionService *services.TranscriptionService,
+	usageService *services.UsageService,
+	openAIKey string,
+) *services.TranslationService {
+	translator, err := openai.NewTranslator(openAIKey, getEnv("OPENAI_TRANSLATION_MODEL", openai.DefaultTranslationModel))
+	if err != nil {
+		log.Println("WARNING: OPENAI_API_KEY is not set. Translation endpoints are disabled.")
+		return nil
+	}
+
+	return services.NewTranslationService(translationRepo, transcriptionService, translator).
+		WithUsageMetering(usageService)
+}
+
+// newOIDCService configures the identity providers named in OIDC_PROVIDERS,
+// or returns nil when there are none
+func newOIDCService(
+	identityRepo repositories.ExternalIdentityRepository,
+	loginRepo repositories.OIDCLoginRequestRepository,
+	userRepo repositories.UserRepository,
+	authService *services.AuthService,
+	appBaseURL string,
+) *services.OIDCService {
+	var names []string
+	for _, name := range strings.Split(getEnv("OIDC_PROVIDERS", ""), ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	oidcService := services.NewOIDCService(identityRepo, loginRepo, userRepo, authService)
+	for _, name := range names {
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+
+		config := oidc.Config{
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", appBaseURL+"/auth/callback/"+name),
+		}
+		if config.Issuer == "" || config.ClientID == "" {
+			log.Fatalf("%sISSUER and %sCLIENT_ID are required for identity provider %q", prefix, prefix, name)
+		}
+
+		oidcService.WithProvider(name, oidc.NewProvider(config, 10*time.Second))
+	}
+
+	return oidcService
+}
+
+// deriveSecret derives a key for purpose from secret with HMAC-SHA256
+func deriveSecret(secret, purpose string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -62,3 +404,40 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvRateLimit(key string, defaultValue entities.RateLimitPolicy) entities.RateLimitPolicy {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	policy, err := entities.ParseRateLimitPolicy(defaultValue.Name, value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return policy
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrInvalidToken         = errors.New("invalid or expired token")
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+)
+
+const (
+	DefaultEmailVerificationTTL = 48 * time.Hour
+	DefaultPasswordResetTTL     = time.Hour
+)
+
+// IMailer sends plain text email
+type IMailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// AccountLinks are the pages users open from account emails. The token is
+// appended as the "token" query parameter.
+type AccountLinks struct {
+	VerifyEmailURL   string
+	ResetPasswordURL string
+}
+
+// AccountService runs the email verification and password reset flows
+type AccountService struct {
+	userRepo        repositories.UserRepository
+	tokenRepo       repositories.UserTokenRepository
+	mailer          IMailer
+	links           AccountLinks
+	login           *LoginProtectionService
+	verificationTTL time.Duration
+	resetTTL        time.Duration
+	admins          map[string]bool
+	sessions        *SessionService
+}
+
+func NewAccountService(
+	userRepo repositories.UserRepository,
+	tokenRepo repositories.UserTokenRepository,
+	mailer IMailer,
+	links AccountLinks,
+) *AccountService {
+	return &AccountService{
+		userRepo:        userRepo,
+		tokenRepo:       tokenRepo,
+		mailer:          mailer,
+		links:           links,
+		verificationTTL: DefaultEmailVerificationTTL,
+		resetTTL:        DefaultPasswordResetTTL,
+	}
+}
+
+// WithLoginProtection lifts login lockouts when a user resets their password
+func (s *AccountService) WithLoginProtection(loginProtection *LoginProtectionService) *AccountService {
+	s.login = loginProtection
+	return s
+}
+
+// WithSessions logs users out on every device when they reset their password
+func (s *AccountService) WithSessions(sessions *SessionService) *AccountService {
+	s.sessions = sessions
+	return s
+}
+
+// WithAdminEmails grants the admin role to accounts with one of the given
+// addresses once they verify it
+func (s *AccountService) WithAdminEmails(emails []string) *AccountService {
+	s.admins = emailSet(emails)
+	return s
+}
+
+// Subscribe emails a verification link to every newly registered user
+func (s *AccountService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventUserRegistered, func(ctx context.Context, event entities.DomainEvent) error {
+		registered := event.(*entities.UserRegistered)
+		err := s.RequestEmailVerification(ctx, registered.UserID)
+		if errors.Is(err, ErrEmailAlreadyVerified) || errors.Is(err, ErrUserNotFound) {
+			return nil
+		}
+		return err
+	})
+}
+
+// RequestEmailVerification emails a new verification link to the user,
+// invalidating earlier ones
+func (s *AccountService) RequestEmailVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	secret, err := s.issueToken(ctx, user, entities.TokenPurposeEmailVerification, s.verificationTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nConfirm your email address by opening this link:\n\n%s\n\nThe link expires in %s.\n",
+		user.Name, s.link(s.links.VerifyEmailURL, secret), s.verificationTTL,
+	)
+	return s.mailer.Send(ctx, user.Email, "Confirm your Voiceline email address", body)
+}
+
+// ConfirmEmail redeems a verification token and marks the address it was
+// sent to as verified
+func (s *AccountService) ConfirmEmail(ctx context.Context, token string) (*entities.User, error) {
+	userToken, err := s.tokenRepo.Consume(ctx, entities.TokenPurposeEmailVerification, entities.HashUserToken(token), time.Now())
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userToken.UserID)
+	if err != nil || user.Email != userToken.Email {
+		return nil, ErrInvalidToken
+	}
+
+	if !user.EmailVerified {
+		user.VerifyEmail()
+		if s.admins[strings.ToLower(user.Email)] {
+			user.Role = entities.RoleAdmin
+		}
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// RequestPasswordReset emails a reset link when an account exists for email.
+// It succeeds either way so callers cannot probe for registered addresses.
+func (s *AccountService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil
+	}
+
+	secret, err := s.issueToken(ctx, user, entities.TokenPurposePasswordReset, s.resetTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nSomeone asked to reset your Voiceline password. Choose a new one here:\n\n%s\n\n"+
+			"The link expires in %s. If you did not ask for this, you can ignore this email.\n",
+		user.Name, s.link(s.links.ResetPasswordURL, secret), s.resetTTL,
+	)
+
+	if err := s.mailer.Send(ctx, user.Email, "Reset your Voiceline password", body); err != nil {
+		log.Printf("password reset mail to user %s: %v", user.ID, err)
+	}
+	return nil
+}
+
+type ResetPasswordInput struct {
+	Token    string
+	Password string
+}
+
+// ResetPassword redeems a reset token and sets the new password. Opening the
+// emailed link also proves ownership of the address, so it is marked verified.
+// Every session is revoked, since the reset may follow a stolen password.
+func (s *AccountService) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
+	userToken, err := s.tokenRepo.Consume(ctx, entities.TokenPurposePasswordReset, entities.HashUserToken(input.Token), time.Now())
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userToken.UserID)
+	if err != nil || user.Email != userToken.Email {
+		return ErrInvalidToken
+	}
+
+	if err := user.UpdatePassword(input.Password); err != nil {
+		return err
+	}
+
+	if !user.EmailVerified {
+		user.VerifyEmail()
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.DeleteByUserID(ctx, user.ID, entities.TokenPurposePasswordReset); err != nil {
+		return err
+	}
+
+	if s.sessions != nil {
+		if err := s.sessions.RevokeOtherSessions(ctx, user.ID, uuid.Nil); err != nil {
+			return err
+		}
+	}
+
+	if s.login != nil {
+		return s.login.Unlock(ctx, user.Email)
+	}
+	return nil
+}
+
+func (s *AccountService) issueToken(ctx context.Context, user *entities.User, purpose entities.UserTokenPurpose, ttl time.Duration) (string, error) {
+	if err := s.tokenRepo.DeleteByUserID(ctx, user.ID, purpose); err != nil {
+		return "", err
+	}
+
+	token, secret, err := entities.NewUserToken(user, purpose, ttl)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+func (s *AccountService) link(base, secret string) string {
+	parsed, err := url.Parse(base)
+	if err != nil {
+		return secret
+	}
+
+	query := parsed.Query()
+	query.Set("token", secret)
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrCannotModifySelf = errors.New("staff cannot change their own role or disable their own account")
+)
+
+const (
+	defaultUserPageSize = 50
+	maxUserPageSize     = 200
+	defaultAuditLimit   = 100
+)
+
+// AdminService gives staff access to other users' accounts and data. Every
+// access to someone else's data and every change is written to the audit log.
+type AdminService struct {
+	userRepo          repositories.UserRepository
+	transcriptionRepo repositories.TranscriptionRepository
+	auditRepo         repositories.AuditLogRepository
+	usageService      *UsageService
+}
+
+func NewAdminService(
+	userRepo repositories.UserRepository,
+	transcriptionRepo repositories.TranscriptionRepository,
+	auditRepo repositories.AuditLogRepository,
+	usageService *UsageService,
+) *AdminService {
+	return &AdminService{
+		userRepo:          userRepo,
+		transcriptionRepo: transcriptionRepo,
+		auditRepo:         auditRepo,
+		usageService:      usageService,
+	}
+}
+
+// Actor identifies the staff member performing an administrative action
+type Actor struct {
+	ID   uuid.UUID
+	Role string
+	IP   string
+}
+
+type SearchUsersInput struct {
+	Query    string
+	Role     string
+	Disabled *bool
+	Offset   int
+	Limit    int
+}
+
+type UserPage struct {
+	Users  []*entities.User
+	Total  int
+	Offset int
+	Limit  int
+}
+
+func (s *AdminService) SearchUsers(ctx context.Context, input SearchUsersInput) (*UserPage, error) {
+	if input.Role != "" && !entities.IsValidRole(input.Role) {
+		return nil, entities.ErrInvalidRole
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultUserPageSize
+	}
+	if limit > maxUserPageSize {
+		limit = maxUserPageSize
+	}
+
+	offset := input.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	users, total, err := s.userRepo.Search(ctx, repositories.UserSearch{
+		Query:    input.Query,
+		Role:     input.Role,
+		Disabled: input.Disabled,
+		Offset:   offset,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserPage{Users: users, Total: total, Offset: offset, Limit: limit}, nil
+}
+
+func (s *AdminService) GetUser(ctx context.Context, actor Actor, userID uuid.UUID) (*entities.User, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.audit(ctx, actor, entities.AuditUserViewed, entities.AuditTargetUser, user.ID, nil); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *AdminService) SetRole(ctx context.Context, actor Actor, userID uuid.UUID, role string) (*entities.User, error) {
+	if userID == actor.ID {
+		return nil, ErrCannotModifySelf
+	}
+
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := user.Role
+	if err := user.SetRole(role); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	err = s.audit(ctx, actor, entities.AuditUserRoleChanged, entities.AuditTargetUser, user.ID, map[string]string{
+		"from": previous,
+		"to":   role,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DisableUser blocks the account from logging in; tokens already issued
+// stop working immediately
+func (s *AdminService) DisableUser(ctx context.Context, actor Actor, userID uuid.UUID, reason string) (*entities.User, error) {
+	if userID == actor.ID {
+		return nil, ErrCannotModifySelf
+	}
+
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Disable()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.audit(ctx, actor, entities.AuditUserDisabled, entities.AuditTargetUser, user.ID, reasonDetails(reason)); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *AdminService) EnableUser(ctx context.Context, actor Actor, userID uuid.UUID) (*entities.User, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Enable()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.audit(ctx, actor, entities.AuditUserEnabled, entities.AuditTargetUser, user.ID, nil); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ResetQuota discards the user's consumption in the current billing period
+// and returns the resulting usage
+func (s *AdminService) ResetQuota(ctx context.Context, actor Actor, userID uuid.UUID, reason string) (*UsageSummary, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.usageService.ResetUserQuota(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.audit(ctx, actor, entities.AuditQuotaReset, entities.AuditTargetUser, user.ID, reasonDetails(reason)); err != nil {
+		return nil, err
+	}
+	return s.usageService.GetCurrentUsage(ctx, user.ID)
+}
+
+// GetTranscription returns any user's transcription for support purposes
+func (s *AdminService) GetTranscription(ctx context.Context, actor Actor, id uuid.UUID, reason string) (*entities.Transcription, error) {
+	transcription, err := s.transcriptionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrTranscriptionNotFound
+	}
+
+	details := reasonDetails(reason)
+	if details == nil {
+		details = map[string]string{}
+	}
+	details["owner_id"] = transcription.UserID.String()
+
+	if err := s.audit(ctx, actor, entities.AuditTranscriptionViewed, entities.AuditTargetTranscription, transcription.ID, details); err != nil {
+		return nil, err
+	}
+	return transcription, nil
+}
+
+// GetUserTranscriptions returns every transcription of a user for support
+// purposes
+func (s *AdminService) GetUserTranscriptions(ctx context.Context, actor Actor, userID uuid.UUID, reason string) ([]*entities.Transcription, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transcriptions, err := s.transcriptionRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.audit(ctx, actor, entities.AuditTranscriptionsListed, entities.AuditTargetUser, user.ID, reasonDetails(reason)); err != nil {
+		return nil, err
+	}
+	return transcriptions, nil
+}
+
+type SearchAuditLogInput struct {
+	ActorID  uuid.UUID
+	TargetID uuid.UUID
+	Limit    int
+}
+
+func (s *AdminService) SearchAuditLog(ctx context.Context, input SearchAuditLogInput) ([]*entities.AuditLogEntry, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+
+	return s.auditRepo.Search(ctx, repositories.AuditLogSearch{
+		ActorID:  input.ActorID,
+		TargetID: input.TargetID,
+		Limit:    limit,
+	})
+}
+
+func (s *AdminService) findUser(ctx context.Context, userID uuid.UUID) (*entities.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// audit records the action. Callers fail when the entry cannot be written so
+// that no data is handed out without a trace.
+func (s *AdminService) audit(ctx context.Context, actor Actor, action, targetType string, targetID uuid.UUID, details map[string]string) error {
+	entry := entities.NewAuditLogEntry(actor.ID, actor.Role, action, targetType, targetID)
+	entry.IP = actor.IP
+	for key, value := range details {
+		entry.Details[key] = value
+	}
+
+	return s.auditRepo.Create(ctx, entry)
+}
+
+func reasonDetails(reason string) map[string]string {
+	if reason == "" {
+		return nil
+	}
+	return map[string]string{"reason": reason}
+}
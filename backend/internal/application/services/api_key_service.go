@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrAPIKeyNotFound     = errors.New("api key not found")
+	ErrUnauthorizedAPIKey = errors.New("unauthorized access to api key")
+	ErrInvalidAPIKey      = errors.New("invalid, expired or revoked api key")
+)
+
+// apiKeyUsageInterval bounds how often LastUsedAt is written for a busy key
+const apiKeyUsageInterval = time.Minute
+
+type APIKeyService struct {
+	apiKeyRepo repositories.APIKeyRepository
+}
+
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+type CreateAPIKeyInput struct {
+	UserID    uuid.UUID
+	Name      string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+// CreateAPIKey returns the new key with its plaintext secret, which cannot be
+// retrieved again
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (*entities.APIKey, string, error) {
+	key, secret, err := entities.NewAPIKey(input.UserID, input.Name, input.Scopes, input.ExpiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	return key, secret, nil
+}
+
+func (s *APIKeyService) GetUserAPIKeys(ctx context.Context, userID uuid.UUID) ([]*entities.APIKey, error) {
+	return s.apiKeyRepo.FindByUserID(ctx, userID)
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.APIKey, error) {
+	key, err := s.apiKeyRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	if !key.BelongsToUser(userID) {
+		return nil, ErrUnauthorizedAPIKey
+	}
+
+	key.Revoke()
+	if err := s.apiKeyRepo.Update(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Authenticate returns the active key matching the plaintext secret and
+// records that it was used
+func (s *APIKeyService) Authenticate(ctx context.Context, secret string) (*entities.APIKey, error) {
+	key, err := s.apiKeyRepo.FindByHash(ctx, entities.HashAPIKey(secret))
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// The request is authenticated either way, so a failed write is only logged
+	if key.MarkUsed(now, apiKeyUsageInterval) {
+		if err := s.apiKeyRepo.Update(ctx, key); err != nil {
+			log.Printf("failed to record use of api key %s: %v", key.ID, err)
+		}
+	}
+
+	return key, nil
+}
+
+// Subscribe removes the API keys of deleted users
+func (s *APIKeyService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+		return s.apiKeyRepo.DeleteByUserID(ctx, event.(*entities.UserDeleted).UserID)
+	})
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -11,15 +12,35 @@ import (
 	"github.com/voiceline/backend/internal/domain/repositories"
 )
 
+const (
+	// accessTokenTTL is how long login tokens are valid
+	accessTokenTTL = 7 * 24 * time.Hour
+	// mfaChallengeTTL is how long a user has to enter their second factor
+	// after their password
+	mfaChallengeTTL = 5 * time.Minute
+	// tokenUseMFAChallenge marks tokens that only prove the password was
+	// entered and cannot be used as access tokens
+	tokenUseMFAChallenge = "mfa_challenge"
+)
+
 var (
-	ErrUserAlreadyExists  = errors.New("user already exists")
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrUserNotFound       = errors.New("user not found")
+	ErrUserAlreadyExists   = errors.New("user already exists")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrAccountDisabled     = errors.New("account is disabled")
+	ErrInvalidMFAChallenge = errors.New("two-factor challenge is invalid or has expired")
 )
 
 type AuthService struct {
 	userRepo  repositories.UserRepository
 	jwtSecret string
+	outbox    *outboxWriter
+	login     *LoginProtectionService
+	admins    map[string]bool
+	apiKeys   *APIKeyService
+	keys      *SigningKeyService
+	mfa       *MFAService
+	sessions  *SessionService
 }
 
 func NewAuthService(userRepo repositories.UserRepository, jwtSecret string) *AuthService {
@@ -29,15 +50,80 @@ func NewAuthService(userRepo repositories.UserRepository, jwtSecret string) *Aut
 	}
 }
 
+// WithOutbox records the domain events emitted by users in the outbox,
+// atomically with the state change that produced them
+func (s *AuthService) WithOutbox(transactor repositories.Transactor, outboxRepo repositories.OutboxRepository) *AuthService {
+	s.outbox = &outboxWriter{transactor: transactor, outboxRepo: outboxRepo}
+	return s
+}
+
+// WithLoginProtection throttles failed logins per account and per client IP
+func (s *AuthService) WithLoginProtection(loginProtection *LoginProtectionService) *AuthService {
+	s.login = loginProtection
+	return s
+}
+
+// WithAdminEmails grants the admin role to accounts registered through an
+// identity provider that vouches for one of the given addresses, so a fresh
+// deployment can bootstrap its first admin. Accounts registered with a
+// password are promoted by AccountService once their address is verified.
+func (s *AuthService) WithAdminEmails(emails []string) *AuthService {
+	s.admins = emailSet(emails)
+	return s
+}
+
+// emailSet normalizes a list of addresses for case-insensitive lookups
+func emailSet(emails []string) map[string]bool {
+	set := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			set[email] = true
+		}
+	}
+	return set
+}
+
+// WithSigningKeys signs tokens with the asymmetric keys of keyService instead
+// of the shared HS256 secret. HS256 tokens are no longer accepted.
+func (s *AuthService) WithSigningKeys(keyService *SigningKeyService) *AuthService {
+	s.keys = keyService
+	return s
+}
+
+// WithAPIKeys lets requests authenticate with personal API keys
+func (s *AuthService) WithAPIKeys(apiKeyService *APIKeyService) *AuthService {
+	s.apiKeys = apiKeyService
+	return s
+}
+
+// WithSessions binds access tokens to sessions that users can revoke
+func (s *AuthService) WithSessions(sessionService *SessionService) *AuthService {
+	s.sessions = sessionService
+	return s
+}
+
+// WithMFA requires a second factor at login from users who enabled it
+func (s *AuthService) WithMFA(mfaService *MFAService) *AuthService {
+	s.mfa = mfaService
+	return s
+}
+
 type RegisterInput struct {
-	Email    string
-	Password string
-	Name     string
+	Email      string
+	Password   string
+	Name       string
+	IP         string
+	UserAgent  string
+	DeviceName string
 }
 
+// AuthOutput is the result of a login. When the user has two-factor
+// authentication enabled only MFAToken is set, and it must be exchanged for
+// a token with VerifyMFA.
 type AuthOutput struct {
-	Token string
-	User  *entities.User
+	Token    string
+	User     *entities.User
+	MFAToken string
 }
 
 func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*AuthOutput, error) {
@@ -51,11 +137,18 @@ func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*AuthO
 		return nil, err
 	}
 
-	if err := s.userRepo.Create(ctx, user); err != nil {
+	err = s.outbox.save(ctx, user, func(ctx context.Context) error {
+		return s.userRepo.Create(ctx, user)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	token, err := s.generateToken(user)
+	token, err := s.generateToken(ctx, user, SessionClient{
+		DeviceName: input.DeviceName,
+		UserAgent:  input.UserAgent,
+		IP:         input.IP,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -67,21 +160,115 @@ func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*AuthO
 }
 
 type LoginInput struct {
-	Email    string
-	Password string
+	Email      string
+	Password   string
+	IP         string
+	UserAgent  string
+	DeviceName string
 }
 
 func (s *AuthService) Login(ctx context.Context, input LoginInput) (*AuthOutput, error) {
+	if s.login != nil {
+		if err := s.login.Check(ctx, input.Email, input.IP); err != nil {
+			return nil, err
+		}
+	}
+
 	user, err := s.userRepo.FindByEmail(ctx, input.Email)
 	if err != nil {
-		return nil, ErrInvalidCredentials
+		entities.VerifyDummyPassword(input.Password)
+		return nil, s.loginFailed(ctx, input)
 	}
 
 	if !user.VerifyPassword(input.Password) {
-		return nil, ErrInvalidCredentials
+		return nil, s.loginFailed(ctx, input)
+	}
+
+	// Only reveal that the account is disabled to someone who knows its password
+	if user.Disabled {
+		return nil, ErrAccountDisabled
+	}
+
+	// Failures stay on record until the second factor is verified as well,
+	// so knowing the password does not reset the throttling of code guesses
+	if s.mfa != nil && s.mfa.IsEnabled(ctx, user.ID) {
+		challenge, err := s.generateMFAChallenge(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &AuthOutput{MFAToken: challenge}, nil
 	}
 
-	token, err := s.generateToken(user)
+	return s.completeLogin(ctx, user, SessionClient{
+		DeviceName: input.DeviceName,
+		UserAgent:  input.UserAgent,
+		IP:         input.IP,
+	})
+}
+
+type VerifyMFAInput struct {
+	MFAToken   string
+	Code       string
+	IP         string
+	UserAgent  string
+	DeviceName string
+}
+
+// VerifyMFA completes a login with the challenge token returned by Login and
+// a code from the user's authenticator app or a recovery code. Wrong codes
+// count as failed logins.
+func (s *AuthService) VerifyMFA(ctx context.Context, input VerifyMFAInput) (*AuthOutput, error) {
+	if s.mfa == nil {
+		return nil, ErrInvalidMFAChallenge
+	}
+
+	userID, err := s.parseMFAChallenge(ctx, input.MFAToken)
+	if err != nil {
+		return nil, ErrInvalidMFAChallenge
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrInvalidMFAChallenge
+	}
+
+	if s.login != nil {
+		if err := s.login.Check(ctx, user.Email, input.IP); err != nil {
+			return nil, err
+		}
+	}
+
+	if user.Disabled {
+		return nil, ErrAccountDisabled
+	}
+
+	if err := s.mfa.Verify(ctx, user.ID, input.Code); err != nil {
+		if err != entities.ErrInvalidMFACode {
+			return nil, ErrInvalidMFAChallenge
+		}
+		if s.login != nil {
+			if err := s.login.RecordFailure(ctx, user.Email, input.IP); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+
+	return s.completeLogin(ctx, user, SessionClient{
+		DeviceName: input.DeviceName,
+		UserAgent:  input.UserAgent,
+		IP:         input.IP,
+	})
+}
+
+func (s *AuthService) completeLogin(ctx context.Context, user *entities.User, client SessionClient) (*AuthOutput, error) {
+	if s.login != nil {
+		if err := s.login.RecordSuccess(ctx, user.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	token, err := s.generateToken(ctx, user, client)
 	if err != nil {
 		return nil, err
 	}
@@ -92,42 +279,288 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*AuthOutput,
 	}, nil
 }
 
-func (s *AuthService) ValidateToken(tokenString string) (uuid.UUID, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid token signing method")
+// RegisterExternal creates the account of someone who signs in through an
+// external identity provider for the first time
+func (s *AuthService) RegisterExternal(ctx context.Context, claims *entities.IdentityClaims) (*entities.User, error) {
+	user, err := entities.NewExternalUser(claims.Email, claims.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// The provider vouches for the address, so it needs no verification email
+	if claims.EmailVerified {
+		user.VerifyEmail()
+		if s.admins[strings.ToLower(user.Email)] {
+			user.Role = entities.RoleAdmin
 		}
-		return []byte(s.jwtSecret), nil
+	}
+
+	err = s.outbox.save(ctx, user, func(ctx context.Context) error {
+		return s.userRepo.Create(ctx, user)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// SignIn issues an access token to a user who was authenticated by other
+// means than a password, such as an external identity provider. Users with
+// two-factor authentication enabled get a challenge for VerifyMFA instead.
+func (s *AuthService) SignIn(ctx context.Context, user *entities.User, client SessionClient) (*AuthOutput, error) {
+	if user.Disabled {
+		return nil, ErrAccountDisabled
+	}
+
+	if s.mfa != nil && s.mfa.IsEnabled(ctx, user.ID) {
+		challenge, err := s.generateMFAChallenge(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &AuthOutput{MFAToken: challenge}, nil
+	}
+
+	token, err := s.generateToken(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthOutput{
+		Token: token,
+		User:  user,
+	}, nil
+}
+
+// UnlockAccount lifts a login lockout on the account with the given email
+func (s *AuthService) UnlockAccount(ctx context.Context, email string) error {
+	if s.login == nil {
+		return nil
+	}
+	return s.login.Unlock(ctx, email)
+}
+
+func (s *AuthService) loginFailed(ctx context.Context, input LoginInput) error {
+	if s.login != nil {
+		if err := s.login.RecordFailure(ctx, input.Email, input.IP); err != nil {
+			return err
+		}
+	}
+	return ErrInvalidCredentials
+}
 
+// TokenClaims are the identity claims carried by an access token or API key
+type TokenClaims struct {
+	UserID uuid.UUID
+	Role   string
+	// Scopes limits what an API key may do; it is nil for login tokens,
+	// which may do everything the user can
+	Scopes []string
+	// SessionID is the session a login token was issued for. Tokens issued
+	// before sessions were tracked have none.
+	SessionID uuid.UUID
+}
+
+func (s *AuthService) ValidateToken(tokenString string) (uuid.UUID, error) {
+	claims, err := s.ParseToken(context.Background(), tokenString)
 	if err != nil {
 		return uuid.Nil, err
 	}
+	return claims.UserID, nil
+}
+
+// Authenticate validates the token against the current state of its account.
+// Tokens of disabled or deleted accounts are rejected, and the role is taken
+// from the account so that role changes apply without a new login. Tokens of
+// revoked sessions are rejected, and the activity of the session is recorded
+// from ip.
+func (s *AuthService) Authenticate(ctx context.Context, tokenString string, ip string) (*TokenClaims, error) {
+	claims, err := s.ParseToken(ctx, tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every access token is bound to a session while sessions are tracked, so
+	// that revoking sessions revokes all of them; API keys are authenticated
+	// by AuthenticateAPIKey instead
+	if s.sessions != nil {
+		if claims.SessionID == uuid.Nil {
+			return nil, ErrInvalidToken
+		}
+		if err := s.sessions.Validate(ctx, claims.SessionID, claims.UserID, ip); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if user.Disabled {
+		return nil, ErrAccountDisabled
+	}
+
+	claims.Role = user.Role
+	return claims, nil
+}
+
+// AuthenticateAPIKey resolves a personal API key to its owner. API keys act
+// with the scopes they were granted and never carry staff privileges.
+func (s *AuthService) AuthenticateAPIKey(ctx context.Context, secret string) (*TokenClaims, error) {
+	if s.apiKeys == nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeys.Authenticate(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, key.UserID)
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if user.Disabled {
+		return nil, ErrAccountDisabled
+	}
+
+	return &TokenClaims{UserID: user.ID, Role: entities.RoleUser, Scopes: key.Scopes}, nil
+}
+
+// ParseToken verifies the token signature and expiry and returns its claims.
+// Tokens issued before roles existed are treated as regular users.
+func (s *AuthService) ParseToken(ctx context.Context, tokenString string) (*TokenClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return s.verificationKey(ctx, token)
+	})
+
+	if err != nil {
+		return nil, err
+	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		if use, _ := claims["token_use"].(string); use != "" {
+			return nil, errors.New("invalid token")
+		}
+
 		userIDStr, ok := claims["user_id"].(string)
 		if !ok {
-			return uuid.Nil, errors.New("invalid token claims")
+			return nil, errors.New("invalid token claims")
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			return uuid.Nil, err
+			return nil, err
+		}
+
+		role, _ := claims["role"].(string)
+		if !entities.IsValidRole(role) {
+			role = entities.RoleUser
 		}
 
-		return userID, nil
+		var sessionID uuid.UUID
+		if sid, ok := claims["sid"].(string); ok {
+			if sessionID, err = uuid.Parse(sid); err != nil {
+				return nil, errors.New("invalid token claims")
+			}
+		}
+
+		return &TokenClaims{UserID: userID, Role: role, SessionID: sessionID}, nil
 	}
 
-	return uuid.Nil, errors.New("invalid token")
+	return nil, errors.New("invalid token")
 }
 
-func (s *AuthService) generateToken(user *entities.User) (string, error) {
+// verificationKey selects the key that verifies a token. With signing keys the
+// token must name a known key in its kid header and use that key's algorithm,
+// so a token cannot pick a weaker algorithm or another key type.
+func (s *AuthService) verificationKey(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	if s.keys == nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid token signing method")
+		}
+		return []byte(s.jwtSecret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, err := s.keys.VerificationKey(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, errors.New("invalid token signing method")
+	}
+
+	return key.PublicKey(), nil
+}
+
+// generateToken issues an access token, bound to a new session for the
+// client when sessions are tracked
+func (s *AuthService) generateToken(ctx context.Context, user *entities.User, client SessionClient) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"user_id": user.ID.String(),
 		"email":   user.Email,
-		"exp":     time.Now().Add(time.Hour * 24 * 7).Unix(),
+		"role":    user.Role,
+		"iat":     now.Unix(),
+		"exp":     now.Add(accessTokenTTL).Unix(),
+	}
+
+	if s.sessions != nil {
+		session, err := s.sessions.CreateSession(ctx, user.ID, client, accessTokenTTL)
+		if err != nil {
+			return "", err
+		}
+		claims["sid"] = session.ID.String()
+	}
+
+	return s.signToken(ctx, claims)
+}
+
+// generateMFAChallenge issues a short-lived token proving that the user
+// entered their password, to be exchanged for an access token with VerifyMFA
+func (s *AuthService) generateMFAChallenge(ctx context.Context, user *entities.User) (string, error) {
+	now := time.Now()
+	return s.signToken(ctx, jwt.MapClaims{
+		"user_id":   user.ID.String(),
+		"token_use": tokenUseMFAChallenge,
+		"iat":       now.Unix(),
+		"exp":       now.Add(mfaChallengeTTL).Unix(),
+	})
+}
+
+func (s *AuthService) parseMFAChallenge(ctx context.Context, tokenString string) (uuid.UUID, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return s.verificationKey(ctx, token)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if use, _ := claims["token_use"].(string); use != tokenUseMFAChallenge {
+		return uuid.Nil, ErrInvalidMFAChallenge
+	}
+
+	userID, _ := claims["user_id"].(string)
+	return uuid.Parse(userID)
+}
+
+func (s *AuthService) signToken(ctx context.Context, claims jwt.MapClaims) (string, error) {
+	if s.keys == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(s.jwtSecret))
+	}
+
+	key, err := s.keys.SigningKey(ctx)
+	if err != nil {
+		return "", err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
 }
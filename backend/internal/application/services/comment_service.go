@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrCommentNotFound   = errors.New("comment not found")
+	ErrHighlightNotFound = errors.New("highlight not found")
+	ErrCommentForbidden  = errors.New("not allowed to change this annotation")
+)
+
+// CommentService manages comments and highlights on transcriptions. Anyone
+// who can read a transcription can annotate it.
+type CommentService struct {
+	commentRepo          repositories.CommentRepository
+	highlightRepo        repositories.HighlightRepository
+	transcriptionService *TranscriptionService
+}
+
+func NewCommentService(
+	commentRepo repositories.CommentRepository,
+	highlightRepo repositories.HighlightRepository,
+	transcriptionService *TranscriptionService,
+) *CommentService {
+	return &CommentService{
+		commentRepo:          commentRepo,
+		highlightRepo:        highlightRepo,
+		transcriptionService: transcriptionService,
+	}
+}
+
+// Thread is a top-level comment with its replies, oldest first
+type Thread struct {
+	Comment *entities.Comment
+	Replies []*entities.Comment
+}
+
+// AnchorInput locates an annotation by audio time range in seconds and/or
+// text span in characters. All fields are optional.
+type AnchorInput struct {
+	StartTime *float64
+	EndTime   *float64
+	TextStart *int
+	TextEnd   *int
+}
+
+func (a AnchorInput) isEmpty() bool {
+	return a.StartTime == nil && a.EndTime == nil && a.TextStart == nil && a.TextEnd == nil
+}
+
+type AddCommentInput struct {
+	TranscriptionID uuid.UUID
+	UserID          uuid.UUID
+	// ParentID replies to an existing thread instead of starting one
+	ParentID *uuid.UUID
+	Body     string
+	Anchor   AnchorInput
+}
+
+// AddComment starts a thread on the transcription or replies to one
+func (s *CommentService) AddComment(ctx context.Context, input AddCommentInput) (*entities.Comment, error) {
+	transcription, err := s.transcriptionService.GetTranscription(ctx, input.TranscriptionID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment *entities.Comment
+	if input.ParentID != nil {
+		if !input.Anchor.isEmpty() {
+			return nil, entities.ErrReplyAnchored
+		}
+
+		parent, err := s.comment(ctx, transcription.ID, *input.ParentID)
+		if err != nil {
+			return nil, err
+		}
+
+		comment, err = entities.NewReply(parent, input.UserID, input.Body)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		anchor, err := newAnchor(transcription, input.Anchor)
+		if err != nil {
+			return nil, err
+		}
+
+		comment, err = entities.NewComment(transcription, input.UserID, input.Body, anchor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// GetThreads returns the comment threads of the transcription, oldest
+// first. A non-nil resolved only returns threads in that state.
+func (s *CommentService) GetThreads(ctx context.Context, transcriptionID, userID uuid.UUID, resolved *bool) ([]*Thread, error) {
+	if _, err := s.transcriptionService.GetTranscription(ctx, transcriptionID, userID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.FindByTranscriptionID(ctx, transcriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make([]*Thread, 0)
+	byID := make(map[uuid.UUID]*Thread)
+	for _, comment := range comments {
+		if comment.IsReply() {
+			continue
+		}
+
+		thread := &Thread{Comment: comment, Replies: make([]*entities.Comment, 0)}
+		byID[comment.ID] = thread
+		if resolved == nil || comment.IsResolved() == *resolved {
+			threads = append(threads, thread)
+		}
+	}
+
+	for _, comment := range comments {
+		if thread, exists := byID[comment.ThreadID()]; exists && comment.IsReply() {
+			thread.Replies = append(thread.Replies, comment)
+		}
+	}
+
+	return threads, nil
+}
+
+// EditComment changes the body of a comment written by the user
+func (s *CommentService) EditComment(ctx context.Context, transcriptionID, commentID, userID uuid.UUID, body string) (*entities.Comment, error) {
+	comment, err := s.accessibleComment(ctx, transcriptionID, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != userID {
+		return nil, ErrCommentForbidden
+	}
+
+	if err := comment.EditBody(body); err != nil {
+		return nil, err
+	}
+
+	if err := s.commentRepo.Update(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment removes a comment, and the replies of a thread, for its
+// author or the transcription's owner
+func (s *CommentService) DeleteComment(ctx context.Context, transcriptionID, commentID, userID uuid.UUID) error {
+	comment, err := s.accessibleComment(ctx, transcriptionID, commentID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !comment.CanDelete(userID) {
+		return ErrCommentForbidden
+	}
+
+	return s.commentRepo.Delete(ctx, comment.ID)
+}
+
+// ResolveComment marks a thread as settled
+func (s *CommentService) ResolveComment(ctx context.Context, transcriptionID, commentID, userID uuid.UUID) (*entities.Comment, error) {
+	comment, err := s.accessibleComment(ctx, transcriptionID, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := comment.Resolve(userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.commentRepo.Update(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// UnresolveComment reopens a resolved thread
+func (s *CommentService) UnresolveComment(ctx context.Context, transcriptionID, commentID, userID uuid.UUID) (*entities.Comment, error) {
+	comment, err := s.accessibleComment(ctx, transcriptionID, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := comment.Unresolve(); err != nil {
+		return nil, err
+	}
+
+	if err := s.commentRepo.Update(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+type AddHighlightInput struct {
+	TranscriptionID uuid.UUID
+	UserID          uuid.UUID
+	Anchor          AnchorInput
+	Color           string
+	Note            string
+}
+
+// AddHighlight marks part of the transcription
+func (s *CommentService) AddHighlight(ctx context.Context, input AddHighlightInput) (*entities.Highlight, error) {
+	transcription, err := s.transcriptionService.GetTranscription(ctx, input.TranscriptionID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	anchor, err := newAnchor(transcription, input.Anchor)
+	if err != nil {
+		return nil, err
+	}
+
+	highlight, err := entities.NewHighlight(transcription, input.UserID, anchor, input.Color, input.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.highlightRepo.Create(ctx, highlight); err != nil {
+		return nil, err
+	}
+
+	return highlight, nil
+}
+
+// GetHighlights returns the highlights of the transcription, oldest first
+func (s *CommentService) GetHighlights(ctx context.Context, transcriptionID, userID uuid.UUID) ([]*entities.Highlight, error) {
+	if _, err := s.transcriptionService.GetTranscription(ctx, transcriptionID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.highlightRepo.FindByTranscriptionID(ctx, transcriptionID)
+}
+
+// DeleteHighlight removes a highlight for its creator or the transcription's owner
+func (s *CommentService) DeleteHighlight(ctx context.Context, transcriptionID, highlightID, userID uuid.UUID) error {
+	if _, err := s.transcriptionService.GetTranscription(ctx, transcriptionID, userID); err != nil {
+		return err
+	}
+
+	highlight, err := s.highlightRepo.FindByID(ctx, highlightID)
+	if err != nil || highlight.TranscriptionID != transcriptionID {
+		return ErrHighlightNotFound
+	}
+
+	if !highlight.CanDelete(userID) {
+		return ErrCommentForbidden
+	}
+
+	return s.highlightRepo.Delete(ctx, highlight.ID)
+}
+
+// Subscribe removes the annotations of deleted users and those on their
+// transcriptions
+func (s *CommentService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+		userID := event.(*entities.UserDeleted).UserID
+		if err := s.commentRepo.DeleteByUserID(ctx, userID); err != nil {
+			return err
+		}
+		return s.highlightRepo.DeleteByUserID(ctx, userID)
+	})
+}
+
+// accessibleComment returns a comment of a transcription the user can read
+func (s *CommentService) accessibleComment(ctx context.Context, transcriptionID, commentID, userID uuid.UUID) (*entities.Comment, error) {
+	if _, err := s.transcriptionService.GetTranscription(ctx, transcriptionID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.comment(ctx, transcriptionID, commentID)
+}
+
+func (s *CommentService) comment(ctx context.Context, transcriptionID, commentID uuid.UUID) (*entities.Comment, error) {
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil || comment.TranscriptionID != transcriptionID {
+		return nil, ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+func newAnchor(transcription *entities.Transcription, input AnchorInput) (*entities.Anchor, error) {
+	return entities.NewAnchor(transcription, input.StartTime, input.EndTime, input.TextStart, input.TextEnd)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+// EventHandler reacts to a dispatched domain event. Delivery is at-least-once,
+// so handlers must tolerate seeing the same event ID more than once.
+type EventHandler func(ctx context.Context, event entities.DomainEvent) error
+
+// EventBus fans domain events out to in-process subscribers
+type EventBus struct {
+	handlers map[string][]EventHandler
+	mu       sync.RWMutex
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		handlers: make(map[string][]EventHandler),
+	}
+}
+
+func (b *EventBus) Subscribe(eventName string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventName] = append(b.handlers[eventName], handler)
+}
+
+// Publish calls every handler subscribed to the event and returns the joined
+// errors of those that failed
+func (b *EventBus) Publish(ctx context.Context, event entities.DomainEvent) error {
+	b.mu.RLock()
+	handlers := b.handlers[event.EventName()]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s handler: %w", event.EventName(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,549 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrExportNotFound      = errors.New("export not found")
+	ErrUnauthorizedExport  = errors.New("unauthorized access to export")
+	ErrExportInProgress    = errors.New("an export is already in progress")
+	ErrExportNotReady      = errors.New("export is not ready for download")
+	ErrInvalidDownloadLink = errors.New("download link is invalid or has expired")
+)
+
+const (
+	DefaultExportRetention = 7 * 24 * time.Hour
+	DefaultDownloadLinkTTL = 15 * time.Minute
+	DefaultExportTimeout   = time.Hour
+	exportBatchSize        = 5
+	exportManifestVersion  = 1
+)
+
+// ExportService builds self-service archives of a user's data
+type ExportService struct {
+	exportRepo        repositories.ExportJobRepository
+	userRepo          repositories.UserRepository
+	transcriptionRepo repositories.TranscriptionRepository
+	archiveStore      repositories.FileStore
+	audioStore        repositories.FileStore
+	translationRepo   repositories.TranslationRepository
+	signingSecret     []byte
+	retention         time.Duration
+	linkTTL           time.Duration
+	timeout           time.Duration
+}
+
+func NewExportService(
+	exportRepo repositories.ExportJobRepository,
+	userRepo repositories.UserRepository,
+	transcriptionRepo repositories.TranscriptionRepository,
+	archiveStore repositories.FileStore,
+	signingSecret string,
+) *ExportService {
+	return &ExportService{
+		exportRepo:        exportRepo,
+		userRepo:          userRepo,
+		transcriptionRepo: transcriptionRepo,
+		archiveStore:      archiveStore,
+		signingSecret:     []byte(signingSecret),
+		retention:         DefaultExportRetention,
+		linkTTL:           DefaultDownloadLinkTTL,
+		timeout:           DefaultExportTimeout,
+	}
+}
+
+// WithAudioStore includes the stored audio of each transcription in exports
+func (s *ExportService) WithAudioStore(audioStore repositories.FileStore) *ExportService {
+	s.audioStore = audioStore
+	return s
+}
+
+// WithTranslations includes the translations of each transcription in exports
+func (s *ExportService) WithTranslations(translationRepo repositories.TranslationRepository) *ExportService {
+	s.translationRepo = translationRepo
+	return s
+}
+
+// WithTimeout sets how long an export may run before it is considered
+// abandoned, for example because the server restarted while building it
+func (s *ExportService) WithTimeout(timeout time.Duration) *ExportService {
+	s.timeout = timeout
+	return s
+}
+
+// RequestExport queues an export of the user's data. Only one export per
+// user runs at a time; exports abandoned while running are failed.
+func (s *ExportService) RequestExport(ctx context.Context, userID uuid.UUID) (*entities.ExportJob, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	jobs, err := s.exportRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, job := range jobs {
+		if job.IsStale(now, s.timeout) {
+			job.Fail("export was interrupted")
+			if err := s.exportRepo.Update(ctx, job); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if job.IsActive() {
+			return nil, ErrExportInProgress
+		}
+	}
+
+	job := entities.NewExportJob(userID)
+	if err := s.exportRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *ExportService) GetExport(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.ExportJob, error) {
+	job, err := s.exportRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrExportNotFound
+	}
+
+	if !job.BelongsToUser(userID) {
+		return nil, ErrUnauthorizedExport
+	}
+
+	return job, nil
+}
+
+func (s *ExportService) GetUserExports(ctx context.Context, userID uuid.UUID) ([]*entities.ExportJob, error) {
+	return s.exportRepo.FindByUserID(ctx, userID)
+}
+
+// DownloadLink authorizes downloading a completed export without a token
+// until ExpiresAt
+type DownloadLink struct {
+	ExpiresAt time.Time
+	Signature string
+}
+
+// SignDownload returns a fresh time-limited download link for the export
+func (s *ExportService) SignDownload(job *entities.ExportJob) (*DownloadLink, error) {
+	if !job.IsCompleted() || job.IsExpired(time.Now()) {
+		return nil, ErrExportNotReady
+	}
+
+	expiresAt := time.Now().Add(s.linkTTL).Truncate(time.Second)
+	if job.ExpiresAt != nil && job.ExpiresAt.Before(expiresAt) {
+		expiresAt = job.ExpiresAt.Truncate(time.Second)
+	}
+
+	return &DownloadLink{
+		ExpiresAt: expiresAt,
+		Signature: job.SignDownload(s.signingSecret, expiresAt.Unix()),
+	}, nil
+}
+
+// OpenDownload verifies a signed link and opens the archive it points to
+func (s *ExportService) OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (*entities.ExportJob, io.ReadCloser, error) {
+	job, err := s.exportRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, ErrInvalidDownloadLink
+	}
+
+	now := time.Now()
+	if !job.VerifyDownload(s.signingSecret, expires, signature, now) {
+		return nil, nil, ErrInvalidDownloadLink
+	}
+
+	if !job.IsCompleted() || job.IsExpired(now) {
+		return nil, nil, ErrExportNotReady
+	}
+
+	archive, err := s.archiveStore.Open(ctx, job.ArchiveKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return job, archive, nil
+}
+
+// ProcessPending builds the archives of queued export jobs and returns how
+// many jobs were processed
+func (s *ExportService) ProcessPending(ctx context.Context) (int, error) {
+	jobs, err := s.exportRepo.FindPending(ctx, exportBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		job.Start()
+		if err := s.exportRepo.Update(ctx, job); err != nil {
+			return 0, err
+		}
+
+		if err := s.build(ctx, job); err != nil {
+			log.Printf("export %s failed: %v", job.ID, err)
+			job.Fail("export could not be generated")
+		}
+
+		if err := s.exportRepo.Update(ctx, job); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(jobs), nil
+}
+
+// PurgeExpired deletes archives whose retention has elapsed
+func (s *ExportService) PurgeExpired(ctx context.Context) error {
+	jobs, err := s.exportRepo.FindExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := s.delete(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run processes queued exports and purges expired archives every interval
+// until the context is cancelled
+func (s *ExportService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ProcessPending(ctx); err != nil {
+				log.Printf("export processing: %v", err)
+			}
+			if err := s.PurgeExpired(ctx); err != nil {
+				log.Printf("export cleanup: %v", err)
+			}
+		}
+	}
+}
+
+// Subscribe removes the exports of deleted users
+func (s *ExportService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+		jobs, err := s.exportRepo.FindByUserID(ctx, event.(*entities.UserDeleted).UserID)
+		if err != nil {
+			return err
+		}
+
+		for _, job := range jobs {
+			if err := s.delete(ctx, job); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *ExportService) delete(ctx context.Context, job *entities.ExportJob) error {
+	if job.ArchiveKey != "" {
+		if err := s.archiveStore.Delete(ctx, job.ArchiveKey); err != nil {
+			return err
+		}
+	}
+	return s.exportRepo.Delete(ctx, job.ID)
+}
+
+// exportManifest is written to manifest.json at the root of the archive
+type exportManifest struct {
+	FormatVersion  int                   `json:"format_version"`
+	GeneratedAt    time.Time             `json:"generated_at"`
+	User           exportUser            `json:"user"`
+	Transcriptions []exportTranscription `json:"transcriptions"`
+}
+
+type exportUser struct {
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	Name          string    `json:"name"`
+	Plan          string    `json:"plan"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type exportTranscription struct {
+	ID             string              `json:"id"`
+	Status         string              `json:"status"`
+	Title          string              `json:"title,omitempty"`
+	Folder         string              `json:"folder,omitempty"`
+	Tags           []string            `json:"tags,omitempty"`
+	Metadata       map[string]string   `json:"metadata,omitempty"`
+	Text           string              `json:"text"`
+	RawText        string              `json:"raw_text,omitempty"`
+	Revisions      []exportRevision    `json:"revisions,omitempty"`
+	Duration       float64             `json:"duration"`
+	AudioSHA256    string              `json:"audio_sha256,omitempty"`
+	DuplicateOf    string              `json:"duplicate_of,omitempty"`
+	Segments       []exportSegment     `json:"segments,omitempty"`
+	TextFile       string              `json:"text_file,omitempty"`
+	TranscriptFile string              `json:"transcript_file,omitempty"`
+	AudioFile      string              `json:"audio_file,omitempty"`
+	Translations   []exportTranslation `json:"translations,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+type exportSegment struct {
+	Start        float64 `json:"start"`
+	End          float64 `json:"end"`
+	SpeakerLabel string  `json:"speaker_label"`
+	Speaker      string  `json:"speaker"`
+	Text         string  `json:"text"`
+}
+
+type exportRevision struct {
+	Text     string    `json:"text"`
+	EditedBy string    `json:"edited_by"`
+	EditedAt time.Time `json:"edited_at"`
+}
+
+type exportTranslation struct {
+	ID        string    `json:"id"`
+	Language  string    `json:"language"`
+	Version   int       `json:"version"`
+	TextFile  string    `json:"text_file"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// build streams a ZIP archive of the user's profile, transcriptions and audio
+// to the archive store
+func (s *ExportService) build(ctx context.Context, job *entities.ExportJob) error {
+	user, err := s.userRepo.FindByID(ctx, job.UserID)
+	if err != nil {
+		return err
+	}
+
+	transcriptions, err := s.transcriptionRepo.FindByUserID(ctx, job.UserID)
+	if err != nil {
+		return err
+	}
+
+	translations, err := s.translations(ctx, job.UserID)
+	if err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	written := make(chan error, 1)
+	go func() {
+		err := s.writeArchive(ctx, writer, user, transcriptions, translations)
+		writer.CloseWithError(err)
+		written <- err
+	}()
+
+	key := fmt.Sprintf("exports/%s/%s.zip", job.UserID, job.ID)
+	size, err := s.archiveStore.SaveStream(ctx, key, reader)
+	// Unblocks the archive writer when the store gave up early
+	reader.CloseWithError(err)
+	if writeErr := <-written; err == nil {
+		err = writeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	job.Complete(key, size, s.retention)
+	return nil
+}
+
+// writeArchive writes the ZIP archive of an export to w
+func (s *ExportService) writeArchive(
+	ctx context.Context,
+	w io.Writer,
+	user *entities.User,
+	transcriptions []*entities.Transcription,
+	translations map[uuid.UUID][]*entities.Translation,
+) error {
+	archive := zip.NewWriter(w)
+
+	manifest := exportManifest{
+		FormatVersion: exportManifestVersion,
+		GeneratedAt:   time.Now().UTC(),
+		User: exportUser{
+			ID:            user.ID.String(),
+			Email:         user.Email,
+			EmailVerified: user.EmailVerified,
+			Name:          user.Name,
+			Plan:          user.Plan,
+			CreatedAt:     user.CreatedAt,
+			UpdatedAt:     user.UpdatedAt,
+		},
+		Transcriptions: make([]exportTranscription, 0, len(transcriptions)),
+	}
+
+	for _, transcription := range transcriptions {
+		entry := exportTranscription{
+			ID:          transcription.ID.String(),
+			Status:      string(transcription.Status),
+			Title:       transcription.Title,
+			Folder:      transcription.Folder,
+			Tags:        transcription.Tags,
+			Metadata:    transcription.Metadata,
+			Text:        transcription.Text,
+			Duration:    transcription.Duration,
+			AudioSHA256: transcription.AudioHash,
+			CreatedAt:   transcription.CreatedAt,
+			UpdatedAt:   transcription.UpdatedAt,
+		}
+
+		if !transcription.RedactionRequired && transcription.RawText != transcription.Text {
+			entry.RawText = transcription.RawText
+		}
+
+		for _, revision := range transcription.Revisions {
+			entry.Revisions = append(entry.Revisions, exportRevision{
+				Text:     revision.Text,
+				EditedBy: revision.EditedBy.String(),
+				EditedAt: revision.EditedAt,
+			})
+		}
+
+		if transcription.DuplicateOf != nil {
+			entry.DuplicateOf = transcription.DuplicateOf.String()
+		}
+
+		if transcription.Text != "" {
+			entry.TextFile = fmt.Sprintf("transcriptions/%s.txt", transcription.ID)
+			if err := writeZipFile(archive, entry.TextFile, bytes.NewReader([]byte(transcription.Text))); err != nil {
+				return err
+			}
+		}
+
+		if segments := transcription.SpeakerSegments(); len(segments) > 0 {
+			for _, segment := range segments {
+				entry.Segments = append(entry.Segments, exportSegment{
+					Start:        segment.Start,
+					End:          segment.End,
+					SpeakerLabel: segment.Speaker,
+					Speaker:      transcription.SpeakerName(segment.Speaker),
+					Text:         segment.Text,
+				})
+			}
+
+			entry.TranscriptFile = fmt.Sprintf("transcriptions/%s.speakers.txt", transcription.ID)
+			transcript := transcription.SpeakerTranscript(segments)
+			if err := writeZipFile(archive, entry.TranscriptFile, bytes.NewReader([]byte(transcript))); err != nil {
+				return err
+			}
+		}
+
+		if s.audioStore != nil && transcription.AudioKey != "" {
+			name := fmt.Sprintf("audio/%s", transcription.ID)
+			written, err := s.writeAudio(ctx, archive, name, transcription.AudioKey)
+			if err != nil {
+				return err
+			}
+			if written {
+				entry.AudioFile = name
+			}
+		}
+
+		for _, translation := range translations[transcription.ID] {
+			if !translation.IsVisible(transcription) {
+				continue
+			}
+
+			exported := exportTranslation{
+				ID:        translation.ID.String(),
+				Language:  translation.Language,
+				Version:   translation.Version,
+				TextFile:  fmt.Sprintf("translations/%s/%s.v%d.txt", transcription.ID, translation.Language, translation.Version),
+				CreatedAt: translation.CreatedAt,
+			}
+			text := translation.Text
+			if len(translation.Segments) > 0 {
+				text = transcription.SpeakerTranscript(translation.Segments)
+			}
+			if err := writeZipFile(archive, exported.TextFile, bytes.NewReader([]byte(text))); err != nil {
+				return err
+			}
+			entry.Translations = append(entry.Translations, exported)
+		}
+
+		manifest.Transcriptions = append(manifest.Transcriptions, entry)
+	}
+
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeZipFile(archive, "manifest.json", bytes.NewReader(encoded)); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// translations groups the translations of the user's transcriptions by
+// transcription
+func (s *ExportService) translations(ctx context.Context, userID uuid.UUID) (map[uuid.UUID][]*entities.Translation, error) {
+	result := make(map[uuid.UUID][]*entities.Translation)
+	if s.translationRepo == nil {
+		return result, nil
+	}
+
+	translations, err := s.translationRepo.FindByOwnerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, translation := range translations {
+		result[translation.TranscriptionID] = append(result[translation.TranscriptionID], translation)
+	}
+	return result, nil
+}
+
+// writeAudio copies stored audio into the archive. Audio that can no longer
+// be opened is left out rather than failing the whole export.
+func (s *ExportService) writeAudio(ctx context.Context, archive *zip.Writer, name, key string) (bool, error) {
+	audio, err := s.audioStore.Open(ctx, key)
+	if err != nil {
+		log.Printf("export: audio %s unavailable: %v", key, err)
+		return false, nil
+	}
+	defer audio.Close()
+
+	return true, writeZipFile(archive, name, audio)
+}
+
+func writeZipFile(archive *zip.Writer, name string, content io.Reader) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, content)
+	return err
+}
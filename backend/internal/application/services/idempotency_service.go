@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request payload")
+)
+
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+type IdempotencyService struct {
+	idempotencyRepo repositories.IdempotencyRepository
+	ttl             time.Duration
+}
+
+func NewIdempotencyService(idempotencyRepo repositories.IdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+
+	return &IdempotencyService{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             ttl,
+	}
+}
+
+// Begin reserves key for the request identified by requestHash. It returns
+// either the new reservation, which the caller completes after processing
+// the request, or the completed record of an earlier identical request that
+// should be replayed instead.
+func (s *IdempotencyService) Begin(ctx context.Context, key, requestHash string) (*entities.IdempotencyRecord, error) {
+	reservation := entities.NewIdempotencyRecord(key, requestHash, s.ttl)
+	existing, err := s.idempotencyRepo.CreateIfAbsent(ctx, reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return reservation, nil
+	}
+
+	if !existing.Matches(requestHash) {
+		return nil, ErrIdempotencyKeyReused
+	}
+
+	if !existing.IsCompleted() {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+
+	return existing, nil
+}
+
+// Complete stores the response in the reservation for replay. Responses that
+// may differ on retry, such as server errors, conflicts or exceeded quotas,
+// are not stored: the key is released and a retry processes the request again.
+func (s *IdempotencyService) Complete(ctx context.Context, reservation *entities.IdempotencyRecord, status int, headers map[string]string, body []byte) error {
+	if !isReplayableStatus(status) {
+		return s.idempotencyRepo.Delete(ctx, reservation.Key)
+	}
+
+	reservation.Complete(status, headers, body)
+	return s.idempotencyRepo.Update(ctx, reservation)
+}
+
+// Release forgets key so the request can be retried
+func (s *IdempotencyService) Release(ctx context.Context, key string) error {
+	return s.idempotencyRepo.Delete(ctx, key)
+}
+
+// isReplayableStatus reports whether a response is final for its request:
+// successes and validation errors that an identical request always repeats
+func isReplayableStatus(status int) bool {
+	switch {
+	case status >= 200 && status < 300:
+		return true
+	case status == http.StatusBadRequest, status == http.StatusRequestEntityTooLarge,
+		status == http.StatusUnsupportedMediaType, status == http.StatusUnprocessableEntity:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run purges expired records every interval until the context is cancelled
+func (s *IdempotencyService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.idempotencyRepo.DeleteExpired(ctx, now); err != nil {
+				log.Printf("idempotency cleanup: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrTooManyLoginAttempts = errors.New("too many failed login attempts, try again later")
+)
+
+// LoginThrottledError rejects a login attempt made while the account or the
+// client IP is delayed or locked out
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return ErrTooManyLoginAttempts.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return ErrTooManyLoginAttempts
+}
+
+// LoginProtectionPolicy throttles failed logins per account and per client IP.
+// The IP policy is looser since many users can share an address.
+type LoginProtectionPolicy struct {
+	Account entities.LoginThrottlePolicy
+	IP      entities.LoginThrottlePolicy
+}
+
+func DefaultLoginProtectionPolicy() LoginProtectionPolicy {
+	return LoginProtectionPolicy{
+		Account: entities.LoginThrottlePolicy{
+			FreeAttempts:     3,
+			BaseDelay:        time.Second,
+			MaxDelay:         30 * time.Second,
+			LockoutThreshold: 10,
+			LockoutDuration:  15 * time.Minute,
+			FailureWindow:    15 * time.Minute,
+		},
+		IP: entities.LoginThrottlePolicy{
+			FreeAttempts:     10,
+			BaseDelay:        time.Second,
+			MaxDelay:         30 * time.Second,
+			LockoutThreshold: 50,
+			LockoutDuration:  15 * time.Minute,
+			FailureWindow:    15 * time.Minute,
+		},
+	}
+}
+
+type LoginProtectionService struct {
+	throttleRepo repositories.LoginThrottleRepository
+	policy       LoginProtectionPolicy
+}
+
+func NewLoginProtectionService(throttleRepo repositories.LoginThrottleRepository, policy LoginProtectionPolicy) *LoginProtectionService {
+	return &LoginProtectionService{
+		throttleRepo: throttleRepo,
+		policy:       policy,
+	}
+}
+
+// Check returns a LoginThrottledError when either the account or the client
+// IP must wait before trying again. Accounts are tracked by email whether or
+// not they exist, so lockouts do not reveal which emails are registered.
+func (s *LoginProtectionService) Check(ctx context.Context, email, ip string) error {
+	now := time.Now()
+	var retryAfter time.Duration
+
+	for _, key := range s.keys(email, ip) {
+		throttle, err := s.throttleRepo.FindByKey(ctx, key)
+		if err != nil {
+			continue
+		}
+
+		if wait := throttle.RetryAfter(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		return &LoginThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed login against both the account and the IP
+func (s *LoginProtectionService) RecordFailure(ctx context.Context, email, ip string) error {
+	now := time.Now()
+	policies := []entities.LoginThrottlePolicy{s.policy.Account, s.policy.IP}
+
+	for i, key := range s.keys(email, ip) {
+		throttle, err := s.throttleRepo.FindByKey(ctx, key)
+		if err != nil {
+			throttle = entities.NewLoginThrottle(key)
+		}
+
+		throttle.RecordFailure(policies[i], now)
+		if throttle.IsLocked(now) {
+			log.Printf("login lockout: %s locked until %s", key, throttle.LockedUntil.Format(time.RFC3339))
+		}
+
+		if err := s.throttleRepo.Save(ctx, throttle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordSuccess clears the account's failures. The IP counter is kept so a
+// single valid account cannot be used to reset an attacker's budget.
+func (s *LoginProtectionService) RecordSuccess(ctx context.Context, email string) error {
+	return s.Unlock(ctx, email)
+}
+
+// Unlock lifts the lockout and delays of an account
+func (s *LoginProtectionService) Unlock(ctx context.Context, email string) error {
+	return s.throttleRepo.Delete(ctx, accountThrottleKey(email))
+}
+
+// Run purges expired throttles every interval until the context is cancelled
+func (s *LoginProtectionService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.throttleRepo.DeleteExpired(ctx, now); err != nil {
+				log.Printf("login throttle cleanup: %v", err)
+			}
+		}
+	}
+}
+
+func (s *LoginProtectionService) keys(email, ip string) []string {
+	return []string{accountThrottleKey(email), "ip:" + ip}
+}
+
+func accountThrottleKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolled    = errors.New("start two-factor enrollment first")
+)
+
+// DefaultMFAIssuer is the account issuer shown in authenticator apps
+const DefaultMFAIssuer = "Voiceline"
+
+// MFAService manages TOTP two-factor authentication
+type MFAService struct {
+	factorRepo repositories.TOTPFactorRepository
+	userRepo   repositories.UserRepository
+	issuer     string
+	// mu serializes code checks so a code cannot be accepted twice by
+	// concurrent requests
+	mu sync.Mutex
+}
+
+func NewMFAService(factorRepo repositories.TOTPFactorRepository, userRepo repositories.UserRepository, issuer string) *MFAService {
+	return &MFAService{
+		factorRepo: factorRepo,
+		userRepo:   userRepo,
+		issuer:     issuer,
+	}
+}
+
+// MFAEnrollment is what the user adds to their authenticator app
+type MFAEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// BeginEnrollment creates a new secret for the user, replacing an earlier
+// unconfirmed one. Two-factor authentication is not enforced until the
+// enrollment is confirmed.
+func (s *MFAService) BeginEnrollment(ctx context.Context, userID uuid.UUID) (*MFAEnrollment, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, err := s.factorRepo.FindByUserID(ctx, userID); err == nil && existing.IsConfirmed() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	factor, err := entities.NewTOTPFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.factorRepo.Save(ctx, factor); err != nil {
+		return nil, err
+	}
+
+	return &MFAEnrollment{
+		Secret:          factor.Secret,
+		ProvisioningURI: factor.ProvisioningURI(s.issuer, user.Email),
+	}, nil
+}
+
+// ConfirmEnrollment enables two-factor authentication with a code from the
+// authenticator app and returns the recovery codes, which are shown once
+func (s *MFAService) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	factor, err := s.factorRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, ErrMFANotEnrolled
+	}
+
+	if factor.IsConfirmed() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	if err := factor.Confirm(code, time.Now()); err != nil {
+		return nil, err
+	}
+
+	codes, err := factor.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.factorRepo.Save(ctx, factor); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// MFAStatus describes a user's two-factor authentication
+type MFAStatus struct {
+	Enabled                bool
+	RecoveryCodesRemaining int
+}
+
+func (s *MFAService) GetStatus(ctx context.Context, userID uuid.UUID) (*MFAStatus, error) {
+	factor, err := s.factorRepo.FindByUserID(ctx, userID)
+	if err != nil || !factor.IsConfirmed() {
+		return &MFAStatus{}, nil
+	}
+
+	return &MFAStatus{
+		Enabled:                true,
+		RecoveryCodesRemaining: len(factor.RecoveryCodeHashes),
+	}, nil
+}
+
+// IsEnabled reports whether logins of the user require a second factor
+func (s *MFAService) IsEnabled(ctx context.Context, userID uuid.UUID) bool {
+	factor, err := s.factorRepo.FindByUserID(ctx, userID)
+	return err == nil && factor.IsConfirmed()
+}
+
+// Verify checks a code from the authenticator app or an unused recovery
+// code, which is then consumed
+func (s *MFAService) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	factor, err := s.factorRepo.FindByUserID(ctx, userID)
+	if err != nil || !factor.IsConfirmed() {
+		return ErrMFANotEnabled
+	}
+
+	if !factor.VerifyCode(code, time.Now()) && !factor.UseRecoveryCode(code) {
+		return entities.ErrInvalidMFACode
+	}
+
+	return s.factorRepo.Save(ctx, factor)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes after checking a code
+// from the authenticator app
+func (s *MFAService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	factor, err := s.factorRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, ErrMFANotEnabled
+	}
+
+	codes, err := factor.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.factorRepo.Save(ctx, factor); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Disable turns two-factor authentication off after checking a code
+func (s *MFAService) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+	return s.factorRepo.DeleteByUserID(ctx, userID)
+}
+
+// Subscribe removes the factors of deleted users
+func (s *MFAService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+		return s.factorRepo.DeleteByUserID(ctx, event.(*entities.UserDeleted).UserID)
+	})
+}
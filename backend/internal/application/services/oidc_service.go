@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrUnknownOIDCProvider     = errors.New("unknown identity provider")
+	ErrOIDCProviderUnavailable = errors.New("identity provider is unavailable")
+	ErrInvalidOIDCState        = errors.New("login request is invalid or has expired")
+	ErrOIDCLoginFailed         = errors.New("identity provider login failed")
+	ErrOIDCAccountExists       = errors.New("an account with this email already exists; log in with your password")
+	ErrOIDCAccountUnverified   = errors.New("an account with this email exists but the address is not verified; verify it or reset the password first")
+)
+
+// DefaultOIDCLoginTTL is how long a user has to complete a login at the
+// identity provider
+const DefaultOIDCLoginTTL = 10 * time.Minute
+
+// IOIDCProvider talks to an external OpenID Connect identity provider
+type IOIDCProvider interface {
+	// AuthorizationURL returns the provider page that starts an
+	// authorization code login with PKCE
+	AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems an authorization code and returns the claims of the
+	// verified ID token
+	Exchange(ctx context.Context, code, codeVerifier string) (*entities.IdentityClaims, error)
+}
+
+// OIDCService signs users in through external identity providers, linking
+// each external account to a local user
+type OIDCService struct {
+	providers    map[string]IOIDCProvider
+	identityRepo repositories.ExternalIdentityRepository
+	loginRepo    repositories.OIDCLoginRequestRepository
+	userRepo     repositories.UserRepository
+	authService  *AuthService
+	loginTTL     time.Duration
+}
+
+func NewOIDCService(
+	identityRepo repositories.ExternalIdentityRepository,
+	loginRepo repositories.OIDCLoginRequestRepository,
+	userRepo repositories.UserRepository,
+	authService *AuthService,
+) *OIDCService {
+	return &OIDCService{
+		providers:    make(map[string]IOIDCProvider),
+		identityRepo: identityRepo,
+		loginRepo:    loginRepo,
+		userRepo:     userRepo,
+		authService:  authService,
+		loginTTL:     DefaultOIDCLoginTTL,
+	}
+}
+
+// WithProvider offers login through provider under the given name
+func (s *OIDCService) WithProvider(name string, provider IOIDCProvider) *OIDCService {
+	s.providers[strings.ToLower(name)] = provider
+	return s
+}
+
+// Providers returns the names of the configured providers, sorted
+func (s *OIDCService) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OIDCAuthorization is where to send the user to log in at the provider
+type OIDCAuthorization struct {
+	URL       string
+	State     string
+	ExpiresAt time.Time
+}
+
+// StartLogin begins a login with the named provider
+func (s *OIDCService) StartLogin(ctx context.Context, providerName string) (*OIDCAuthorization, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := entities.NewOIDCLoginRequest(strings.ToLower(providerName), s.loginTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := provider.AuthorizationURL(ctx, request.State, request.Nonce, request.CodeChallenge())
+	if err != nil {
+		log.Printf("oidc: %s authorization: %v", providerName, err)
+		return nil, ErrOIDCProviderUnavailable
+	}
+
+	if err := s.loginRepo.Create(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return &OIDCAuthorization{
+		URL:       url,
+		State:     request.State,
+		ExpiresAt: request.ExpiresAt,
+	}, nil
+}
+
+type CompleteOIDCLoginInput struct {
+	Provider   string
+	Code       string
+	State      string
+	IP         string
+	UserAgent  string
+	DeviceName string
+}
+
+// CompleteLogin redeems the code the provider returned and signs in the
+// linked user. On first login the external account is linked to the user
+// with the same email if both sides verified it, or a new user is created.
+// Users with two-factor authentication still have to pass VerifyMFA.
+func (s *OIDCService) CompleteLogin(ctx context.Context, input CompleteOIDCLoginInput) (*AuthOutput, error) {
+	provider, err := s.provider(input.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.loginRepo.Consume(ctx, input.State)
+	if err != nil || request.Provider != strings.ToLower(input.Provider) || request.IsExpired(time.Now()) {
+		return nil, ErrInvalidOIDCState
+	}
+
+	claims, err := provider.Exchange(ctx, input.Code, request.CodeVerifier)
+	if err != nil {
+		log.Printf("oidc: %s code exchange: %v", request.Provider, err)
+		return nil, ErrOIDCLoginFailed
+	}
+
+	if claims.Nonce != request.Nonce {
+		return nil, ErrOIDCLoginFailed
+	}
+
+	// Accounts are keyed by email, so the provider must release it
+	if claims.Email == "" {
+		log.Printf("oidc: %s returned no email for %s", request.Provider, claims.Subject)
+		return nil, ErrOIDCLoginFailed
+	}
+
+	user, err := s.resolveUser(ctx, request.Provider, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authService.SignIn(ctx, user, SessionClient{
+		DeviceName: input.DeviceName,
+		UserAgent:  input.UserAgent,
+		IP:         input.IP,
+	})
+}
+
+func (s *OIDCService) resolveUser(ctx context.Context, providerName string, claims *entities.IdentityClaims) (*entities.User, error) {
+	identity, err := s.identityRepo.FindBySubject(ctx, claims.Issuer, claims.Subject)
+	if err == nil {
+		user, err := s.userRepo.FindByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, ErrUserNotFound
+		}
+
+		identity.RecordLogin(claims)
+		if err := s.identityRepo.Update(ctx, identity); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, claims.Email)
+	if err == nil {
+		// Linking on an address the provider has not verified would let
+		// anyone with an account there take over the local account
+		if !claims.EmailVerified {
+			return nil, ErrOIDCAccountExists
+		}
+
+		// Nor may anyone who registered the address locally without
+		// verifying it keep a password to the account the owner signs in to
+		if !user.EmailVerified {
+			return nil, ErrOIDCAccountUnverified
+		}
+	} else {
+		user, err = s.authService.RegisterExternal(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.identityRepo.Create(ctx, entities.NewExternalIdentity(user.ID, providerName, claims)); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *OIDCService) provider(name string) (IOIDCProvider, error) {
+	provider, exists := s.providers[strings.ToLower(name)]
+	if !exists {
+		return nil, ErrUnknownOIDCProvider
+	}
+	return provider, nil
+}
+
+// Run removes abandoned login requests every interval until the context is
+// cancelled
+func (s *OIDCService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.loginRepo.DeleteExpired(ctx, time.Now()); err != nil {
+				log.Printf("oidc cleanup: %v", err)
+			}
+		}
+	}
+}
+
+// Subscribe unlinks the external identities of deleted users
+func (s *OIDCService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+		return s.identityRepo.DeleteByUserID(ctx, event.(*entities.UserDeleted).UserID)
+	})
+}
@@ -0,0 +1,579 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrOrganizationNotFound     = errors.New("organization not found")
+	ErrNotOrganizationMember    = errors.New("not a member of the organization")
+	ErrOrganizationForbidden    = errors.New("insufficient organization role")
+	ErrOrganizationMemberExists = errors.New("user is already a member of the organization")
+	ErrMemberNotFound           = errors.New("organization member not found")
+	ErrLastOrganizationOwner    = errors.New("an organization needs at least one owner")
+	ErrInvitationNotFound       = errors.New("invitation not found")
+	ErrInvalidInvitation        = errors.New("invalid or expired invitation")
+	ErrInvitationEmailMismatch  = errors.New("invitation was sent to a different email address")
+)
+
+// DefaultInvitationTTL is how long an invitation to an organization can be accepted
+const DefaultInvitationTTL = 7 * 24 * time.Hour
+
+// OrganizationService manages organizations, their members and invitations
+type OrganizationService struct {
+	organizationRepo repositories.OrganizationRepository
+	memberRepo       repositories.OrganizationMemberRepository
+	invitationRepo   repositories.OrganizationInvitationRepository
+	userRepo         repositories.UserRepository
+	mailer           IMailer
+	invitationURL    string
+	invitationTTL    time.Duration
+	// mu serializes membership changes so concurrent requests cannot remove
+	// the last owner
+	mu sync.Mutex
+}
+
+// NewOrganizationService creates the service. Invitation emails link to
+// invitationURL with the token in the "token" query parameter.
+func NewOrganizationService(
+	organizationRepo repositories.OrganizationRepository,
+	memberRepo repositories.OrganizationMemberRepository,
+	invitationRepo repositories.OrganizationInvitationRepository,
+	userRepo repositories.UserRepository,
+	mailer IMailer,
+	invitationURL string,
+) *OrganizationService {
+	return &OrganizationService{
+		organizationRepo: organizationRepo,
+		memberRepo:       memberRepo,
+		invitationRepo:   invitationRepo,
+		userRepo:         userRepo,
+		mailer:           mailer,
+		invitationURL:    invitationURL,
+		invitationTTL:    DefaultInvitationTTL,
+	}
+}
+
+// Membership is an organization together with the requesting user's
+// membership in it
+type Membership struct {
+	Organization *entities.Organization
+	Member       *entities.OrganizationMember
+}
+
+// MemberProfile is a member together with their account
+type MemberProfile struct {
+	Member *entities.OrganizationMember
+	User   *entities.User
+}
+
+type CreateOrganizationInput struct {
+	UserID uuid.UUID
+	Name   string
+}
+
+// CreateOrganization creates an organization owned by the user
+func (s *OrganizationService) CreateOrganization(ctx context.Context, input CreateOrganizationInput) (*Membership, error) {
+	organization, err := entities.NewOrganization(input.Name, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := entities.NewOrganizationMember(organization.ID, input.UserID, entities.OrganizationRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.organizationRepo.Create(ctx, organization); err != nil {
+		return nil, err
+	}
+
+	if err := s.memberRepo.Create(ctx, owner); err != nil {
+		return nil, err
+	}
+
+	return &Membership{Organization: organization, Member: owner}, nil
+}
+
+// GetUserOrganizations returns the organizations the user is a member of
+func (s *OrganizationService) GetUserOrganizations(ctx context.Context, userID uuid.UUID) ([]*Membership, error) {
+	members, err := s.memberRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships := make([]*Membership, 0, len(members))
+	for _, member := range members {
+		organization, err := s.organizationRepo.FindByID(ctx, member.OrganizationID)
+		if err != nil {
+			continue
+		}
+		memberships = append(memberships, &Membership{Organization: organization, Member: member})
+	}
+
+	return memberships, nil
+}
+
+// GetOrganization returns an organization the user is a member of
+func (s *OrganizationService) GetOrganization(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*Membership, error) {
+	return s.membership(ctx, id, userID)
+}
+
+type UpdateOrganizationInput struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Name           string
+}
+
+// UpdateOrganization renames the organization; admins and owners only
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, input UpdateOrganizationInput) (*Membership, error) {
+	membership, err := s.manager(ctx, input.OrganizationID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := membership.Organization.Rename(input.Name); err != nil {
+		return nil, err
+	}
+
+	if err := s.organizationRepo.Update(ctx, membership.Organization); err != nil {
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+// SetRedactionRequired changes whether only redacted text of the
+// organization's transcriptions is returned; admins and owners only
+func (s *OrganizationService) SetRedactionRequired(ctx context.Context, organizationID, userID uuid.UUID, required bool) (*Membership, error) {
+	membership, err := s.manager(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	membership.Organization.RedactionRequired = required
+	membership.Organization.UpdatedAt = time.Now()
+
+	if err := s.organizationRepo.Update(ctx, membership.Organization); err != nil {
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+// RequiresRedaction reports whether the organization's policy requires
+// redacted text
+func (s *OrganizationService) RequiresRedaction(ctx context.Context, organizationID uuid.UUID) bool {
+	organization, err := s.organizationRepo.FindByID(ctx, organizationID)
+	return err == nil && organization.RedactionRequired
+}
+
+// IsMember reports whether the user belongs to the organization
+func (s *OrganizationService) IsMember(ctx context.Context, organizationID, userID uuid.UUID) bool {
+	_, err := s.memberRepo.Find(ctx, organizationID, userID)
+	return err == nil
+}
+
+// IsManager reports whether the user is an admin or owner of the
+// organization
+func (s *OrganizationService) IsManager(ctx context.Context, organizationID, userID uuid.UUID) bool {
+	member, err := s.memberRepo.Find(ctx, organizationID, userID)
+	return err == nil && member.CanManageMembers()
+}
+
+// GetMembers lists the members of an organization the user belongs to
+func (s *OrganizationService) GetMembers(ctx context.Context, organizationID, userID uuid.UUID) ([]*MemberProfile, error) {
+	if _, err := s.membership(ctx, organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]*MemberProfile, 0, len(members))
+	for _, member := range members {
+		user, err := s.userRepo.FindByID(ctx, member.UserID)
+		if err != nil {
+			continue
+		}
+		profiles = append(profiles, &MemberProfile{Member: member, User: user})
+	}
+
+	return profiles, nil
+}
+
+type ChangeMemberRoleInput struct {
+	OrganizationID uuid.UUID
+	ActorID        uuid.UUID
+	UserID         uuid.UUID
+	Role           string
+}
+
+// ChangeMemberRole changes the role of a member. Admins manage admins and
+// members; only owners can grant or take away ownership.
+func (s *OrganizationService) ChangeMemberRole(ctx context.Context, input ChangeMemberRoleInput) (*MemberProfile, error) {
+	if !entities.IsValidOrganizationRole(input.Role) {
+		return nil, entities.ErrInvalidOrganizationRole
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	actor, err := s.manager(ctx, input.OrganizationID, input.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.memberRepo.Find(ctx, input.OrganizationID, input.UserID)
+	if err != nil {
+		return nil, ErrMemberNotFound
+	}
+
+	if (member.IsOwner() || input.Role == entities.OrganizationRoleOwner) && !actor.Member.IsOwner() {
+		return nil, ErrOrganizationForbidden
+	}
+
+	if member.IsOwner() && input.Role != entities.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, member); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := member.ChangeRole(input.Role); err != nil {
+		return nil, err
+	}
+
+	if err := s.memberRepo.Update(ctx, member); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, member.UserID)
+	if err != nil {
+		return nil, ErrMemberNotFound
+	}
+
+	return &MemberProfile{Member: member, User: user}, nil
+}
+
+// RemoveMember removes a member from the organization. Members can always
+// leave; removing others takes an admin, and removing an owner an owner.
+func (s *OrganizationService) RemoveMember(ctx context.Context, organizationID, actorID, userID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	actor, err := s.membership(ctx, organizationID, actorID)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.memberRepo.Find(ctx, organizationID, userID)
+	if err != nil {
+		return ErrMemberNotFound
+	}
+
+	if actorID != userID {
+		if !actor.Member.CanManageMembers() || (member.IsOwner() && !actor.Member.IsOwner()) {
+			return ErrOrganizationForbidden
+		}
+	}
+
+	if member.IsOwner() {
+		if err := s.ensureAnotherOwner(ctx, member); err != nil {
+			return err
+		}
+	}
+
+	return s.memberRepo.Delete(ctx, organizationID, userID)
+}
+
+type InviteMemberInput struct {
+	OrganizationID uuid.UUID
+	ActorID        uuid.UUID
+	Email          string
+	Role           string
+}
+
+// InviteMember emails an invitation to join the organization, replacing a
+// pending invitation to the same address
+func (s *OrganizationService) InviteMember(ctx context.Context, input InviteMemberInput) (*entities.OrganizationInvitation, error) {
+	actor, err := s.manager(ctx, input.OrganizationID, input.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Role == entities.OrganizationRoleOwner && !actor.Member.IsOwner() {
+		return nil, ErrOrganizationForbidden
+	}
+
+	invitation, secret, err := entities.NewOrganizationInvitation(input.OrganizationID, input.Email, input.Role, input.ActorID, s.invitationTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if user, err := s.userRepo.FindByEmail(ctx, invitation.Email); err == nil && s.IsMember(ctx, input.OrganizationID, user.ID) {
+		return nil, ErrOrganizationMemberExists
+	}
+
+	existing, err := s.invitationRepo.FindByOrganizationID(ctx, input.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, earlier := range existing {
+		if earlier.AcceptedAt == nil && earlier.IsFor(invitation.Email) {
+			if err := s.invitationRepo.Delete(ctx, earlier.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	inviter := "A teammate"
+	if user, err := s.userRepo.FindByID(ctx, input.ActorID); err == nil {
+		inviter = user.Name
+	}
+
+	body := fmt.Sprintf(
+		"Hi,\n\n%s invited you to join %s on Voiceline. Accept the invitation here:\n\n%s\n\nThe invitation expires in %s.\n",
+		inviter, actor.Organization.Name, s.link(secret), s.invitationTTL,
+	)
+	if err := s.mailer.Send(ctx, invitation.Email, "Join "+actor.Organization.Name+" on Voiceline", body); err != nil {
+		log.Printf("invitation mail for organization %s: %v", input.OrganizationID, err)
+	}
+
+	return invitation, nil
+}
+
+// GetInvitations lists the pending invitations of the organization; admins
+// and owners only
+func (s *OrganizationService) GetInvitations(ctx context.Context, organizationID, userID uuid.UUID) ([]*entities.OrganizationInvitation, error) {
+	if _, err := s.manager(ctx, organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.invitationRepo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	pending := make([]*entities.OrganizationInvitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		if invitation.IsPending(now) {
+			pending = append(pending, invitation)
+		}
+	}
+	return pending, nil
+}
+
+// RevokeInvitation withdraws a pending invitation; admins and owners only
+func (s *OrganizationService) RevokeInvitation(ctx context.Context, organizationID, invitationID, userID uuid.UUID) error {
+	if _, err := s.manager(ctx, organizationID, userID); err != nil {
+		return err
+	}
+
+	invitation, err := s.invitationRepo.FindByID(ctx, invitationID)
+	if err != nil || invitation.OrganizationID != organizationID || invitation.AcceptedAt != nil {
+		return ErrInvitationNotFound
+	}
+
+	return s.invitationRepo.Delete(ctx, invitationID)
+}
+
+// AcceptInvitation adds the user to the organization of an emailed
+// invitation. The invitation only works for the account with the address it
+// was sent to.
+func (s *OrganizationService) AcceptInvitation(ctx context.Context, token string, userID uuid.UUID) (*Membership, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	invitation, err := s.invitationRepo.FindByTokenHash(ctx, entities.HashUserToken(token))
+	if err != nil || !invitation.IsPending(time.Now()) {
+		return nil, ErrInvalidInvitation
+	}
+
+	if !invitation.IsFor(user.Email) {
+		return nil, ErrInvitationEmailMismatch
+	}
+
+	organization, err := s.organizationRepo.FindByID(ctx, invitation.OrganizationID)
+	if err != nil {
+		return nil, ErrInvalidInvitation
+	}
+
+	if s.IsMember(ctx, organization.ID, user.ID) {
+		return nil, ErrOrganizationMemberExists
+	}
+
+	member, err := entities.NewOrganizationMember(organization.ID, user.ID, invitation.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invitation.Accept(time.Now()); err != nil {
+		return nil, ErrInvalidInvitation
+	}
+
+	if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	if err := s.memberRepo.Create(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return &Membership{Organization: organization, Member: member}, nil
+}
+
+// Run periodically removes expired invitations until ctx is cancelled
+func (s *OrganizationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.invitationRepo.DeleteExpired(ctx, time.Now()); err != nil {
+				log.Printf("invitation cleanup: %v", err)
+			}
+		}
+	}
+}
+
+// Subscribe removes deleted users from their organizations. The longest
+// standing member inherits an organization left without an owner, and
+// organizations left without members are deleted.
+func (s *OrganizationService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+		return s.removeUser(ctx, event.(*entities.UserDeleted).UserID)
+	})
+}
+
+func (s *OrganizationService) removeUser(ctx context.Context, userID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members, err := s.memberRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if err := s.memberRepo.Delete(ctx, member.OrganizationID, userID); err != nil {
+			return err
+		}
+
+		remaining, err := s.memberRepo.FindByOrganizationID(ctx, member.OrganizationID)
+		if err != nil {
+			return err
+		}
+
+		if len(remaining) == 0 {
+			if err := s.invitationRepo.DeleteByOrganizationID(ctx, member.OrganizationID); err != nil {
+				return err
+			}
+			if err := s.organizationRepo.Delete(ctx, member.OrganizationID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if member.IsOwner() && !hasOwner(remaining) {
+			successor := remaining[0]
+			if err := successor.ChangeRole(entities.OrganizationRoleOwner); err != nil {
+				return err
+			}
+			if err := s.memberRepo.Update(ctx, successor); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// membership returns the organization with the user's membership
+func (s *OrganizationService) membership(ctx context.Context, organizationID, userID uuid.UUID) (*Membership, error) {
+	organization, err := s.organizationRepo.FindByID(ctx, organizationID)
+	if err != nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	member, err := s.memberRepo.Find(ctx, organizationID, userID)
+	if err != nil {
+		return nil, ErrNotOrganizationMember
+	}
+
+	return &Membership{Organization: organization, Member: member}, nil
+}
+
+// manager is membership for actions reserved to admins and owners
+func (s *OrganizationService) manager(ctx context.Context, organizationID, userID uuid.UUID) (*Membership, error) {
+	membership, err := s.membership(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !membership.Member.CanManageMembers() {
+		return nil, ErrOrganizationForbidden
+	}
+
+	return membership, nil
+}
+
+// ensureAnotherOwner fails when owner is the organization's only owner
+func (s *OrganizationService) ensureAnotherOwner(ctx context.Context, owner *entities.OrganizationMember) error {
+	members, err := s.memberRepo.FindByOrganizationID(ctx, owner.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if member.IsOwner() && member.UserID != owner.UserID {
+			return nil
+		}
+	}
+	return ErrLastOrganizationOwner
+}
+
+func hasOwner(members []*entities.OrganizationMember) bool {
+	for _, member := range members {
+		if member.IsOwner() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *OrganizationService) link(secret string) string {
+	parsed, err := url.Parse(s.invitationURL)
+	if err != nil {
+		return secret
+	}
+
+	query := parsed.Query()
+	query.Set("token", secret)
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
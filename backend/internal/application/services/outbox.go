@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+const (
+	outboxBatchSize   = 100
+	outboxMaxAttempts = 10
+	outboxRetryDelay  = 5 * time.Second
+)
+
+// eventSource is implemented by entities that record domain events
+type eventSource interface {
+	PullEvents() []entities.DomainEvent
+}
+
+// outboxWriter persists an entity and the events it recorded in one transaction
+type outboxWriter struct {
+	transactor repositories.Transactor
+	outboxRepo repositories.OutboxRepository
+}
+
+// save runs write and appends the source's pending events to the outbox
+// atomically. Without an outbox the events are discarded.
+func (w *outboxWriter) save(ctx context.Context, source eventSource, write func(ctx context.Context) error) error {
+	if w == nil {
+		source.PullEvents()
+		return write(ctx)
+	}
+
+	return w.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+
+		messages, err := entities.NewOutboxMessages(source.PullEvents())
+		if err != nil {
+			return err
+		}
+
+		return w.outboxRepo.Append(ctx, messages...)
+	})
+}
+
+// OutboxDispatcher relays outbox messages to the event bus
+type OutboxDispatcher struct {
+	outboxRepo  repositories.OutboxRepository
+	eventBus    *EventBus
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+func NewOutboxDispatcher(outboxRepo repositories.OutboxRepository, eventBus *EventBus) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		outboxRepo:  outboxRepo,
+		eventBus:    eventBus,
+		maxAttempts: outboxMaxAttempts,
+		retryDelay:  outboxRetryDelay,
+	}
+}
+
+// WithRetryPolicy overrides how often and how soon a message whose handlers
+// failed is dispatched again
+func (d *OutboxDispatcher) WithRetryPolicy(maxAttempts int, retryDelay time.Duration) *OutboxDispatcher {
+	d.maxAttempts = maxAttempts
+	d.retryDelay = retryDelay
+	return d
+}
+
+// DispatchPending publishes every due message and returns how many were
+// dispatched successfully. Messages whose handlers fail are retried later.
+func (d *OutboxDispatcher) DispatchPending(ctx context.Context) (int, error) {
+	messages, err := d.outboxRepo.FindDue(ctx, time.Now(), outboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatched := 0
+	for _, message := range messages {
+		if err := d.dispatch(ctx, message); err != nil {
+			message.RecordFailure(err.Error(), d.retryDelay, d.maxAttempts)
+			log.Printf("outbox: dispatching %s %s failed: %v", message.EventName, message.ID, err)
+		} else {
+			message.MarkDispatched()
+			dispatched++
+		}
+
+		if err := d.outboxRepo.Update(ctx, message); err != nil {
+			return dispatched, err
+		}
+	}
+
+	return dispatched, nil
+}
+
+// Run dispatches pending messages every interval until the context is cancelled
+func (d *OutboxDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.DispatchPending(ctx); err != nil {
+				log.Printf("outbox dispatcher: %v", err)
+			}
+		}
+	}
+}
+
+func (d *OutboxDispatcher) dispatch(ctx context.Context, message *entities.OutboxMessage) error {
+	event, err := message.Event()
+	if err != nil {
+		return err
+	}
+
+	return d.eventBus.Publish(ctx, event)
+}
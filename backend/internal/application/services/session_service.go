@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrUnauthorizedSession = errors.New("unauthorized access to session")
+	ErrSessionRevoked      = errors.New("session has been revoked or has expired")
+)
+
+// sessionActivityInterval bounds how often LastSeenAt is written for a busy
+// session
+const sessionActivityInterval = time.Minute
+
+// SessionClient describes the device a login comes from
+type SessionClient struct {
+	DeviceName string
+	UserAgent  string
+	IP         string
+}
+
+// SessionService tracks the devices users are logged in on
+type SessionService struct {
+	sessionRepo repositories.SessionRepository
+}
+
+func NewSessionService(sessionRepo repositories.SessionRepository) *SessionService {
+	return &SessionService{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// CreateSession starts a session lasting ttl, the lifetime of the token
+// issued for it
+func (s *SessionService) CreateSession(ctx context.Context, userID uuid.UUID, client SessionClient, ttl time.Duration) (*entities.Session, error) {
+	session := entities.NewSession(userID, client.DeviceName, client.UserAgent, client.IP, ttl)
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetUserSessions returns the user's active sessions, most recently seen first
+func (s *SessionService) GetUserSessions(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error) {
+	sessions, err := s.sessionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := make([]*entities.Session, 0, len(sessions))
+	for _, session := range sessions {
+		if session.IsActive(now) {
+			active = append(active, session)
+		}
+	}
+	return active, nil
+}
+
+// RevokeSession logs the device out; its token is rejected from then on
+func (s *SessionService) RevokeSession(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	session, err := s.sessionRepo.FindByID(ctx, id)
+	if err != nil {
+		return ErrSessionNotFound
+	}
+
+	if !session.BelongsToUser(userID) {
+		return ErrUnauthorizedSession
+	}
+
+	if !session.IsActive(time.Now()) {
+		return ErrSessionNotFound
+	}
+
+	session.Revoke()
+	return s.sessionRepo.Update(ctx, session)
+}
+
+// RevokeOtherSessions logs the user out on every device except the session
+// keep, which may be uuid.Nil to log out everywhere
+func (s *SessionService) RevokeOtherSessions(ctx context.Context, userID uuid.UUID, keep uuid.UUID) error {
+	sessions, err := s.sessionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, session := range sessions {
+		if session.ID == keep || !session.IsActive(now) {
+			continue
+		}
+
+		session.Revoke()
+		if err := s.sessionRepo.Update(ctx, session); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsRecent reports whether the user's session is active and was logged in to
+// within the given time, which proves a fresh authentication
+func (s *SessionService) IsRecent(ctx context.Context, id uuid.UUID, userID uuid.UUID, within time.Duration) bool {
+	session, err := s.sessionRepo.FindByID(ctx, id)
+	if err != nil || !session.BelongsToUser(userID) {
+		return false
+	}
+
+	now := time.Now()
+	return session.IsActive(now) && now.Sub(session.CreatedAt) <= within
+}
+
+// Validate checks that the session of a token is still active and records
+// the activity from ip
+func (s *SessionService) Validate(ctx context.Context, id uuid.UUID, userID uuid.UUID, ip string) error {
+	session, err := s.sessionRepo.FindByID(ctx, id)
+	if err != nil || !session.BelongsToUser(userID) {
+		return ErrSessionRevoked
+	}
+
+	now := time.Now()
+	if !session.IsActive(now) {
+		return ErrSessionRevoked
+	}
+
+	// The request is authenticated either way, so a failed write is only logged
+	if session.Touch(now, ip, sessionActivityInterval) {
+		if err := s.sessionRepo.Update(ctx, session); err != nil {
+			log.Printf("failed to record activity of session %s: %v", session.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Run periodically removes expired sessions until ctx is cancelled
+func (s *SessionService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.sessionRepo.DeleteExpired(ctx, time.Now()); err != nil {
+				log.Printf("session cleanup: %v", err)
+			}
+		}
+	}
+}
+
+// Subscribe removes the sessions of deleted users
+func (s *SessionService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+		return s.sessionRepo.DeleteByUserID(ctx, event.(*entities.UserDeleted).UserID)
+	})
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrShareNotFound     = errors.New("share not found")
+	ErrShareLinkNotFound = errors.New("share link not found")
+	ErrInvalidShareLink  = errors.New("invalid or expired share link")
+)
+
+// SharingService manages the grants and public links through which owners
+// share individual transcriptions
+type SharingService struct {
+	shareRepo         repositories.TranscriptionShareRepository
+	linkRepo          repositories.ShareLinkRepository
+	transcriptionRepo repositories.TranscriptionRepository
+	userRepo          repositories.UserRepository
+	linkURL           string
+	mailer            IMailer
+	transcriptionURL  string
+}
+
+// NewSharingService creates the service. Public links point to linkURL
+// followed by the token as the last path segment.
+func NewSharingService(
+	shareRepo repositories.TranscriptionShareRepository,
+	linkRepo repositories.ShareLinkRepository,
+	transcriptionRepo repositories.TranscriptionRepository,
+	userRepo repositories.UserRepository,
+	linkURL string,
+) *SharingService {
+	return &SharingService{
+		shareRepo:         shareRepo,
+		linkRepo:          linkRepo,
+		transcriptionRepo: transcriptionRepo,
+		userRepo:          userRepo,
+		linkURL:           strings.TrimRight(linkURL, "/"),
+	}
+}
+
+// WithNotifications emails the addresses transcriptions are shared with a
+// link to transcriptionURL followed by the transcription ID
+func (s *SharingService) WithNotifications(mailer IMailer, transcriptionURL string) *SharingService {
+	s.mailer = mailer
+	s.transcriptionURL = strings.TrimRight(transcriptionURL, "/")
+	return s
+}
+
+// CreatedShareLink is a new link together with its URL, which is only
+// available at creation
+type CreatedShareLink struct {
+	Link *entities.ShareLink
+	URL  string
+}
+
+type ShareTranscriptionInput struct {
+	TranscriptionID uuid.UUID
+	OwnerID         uuid.UUID
+	Email           string
+	Permission      string
+}
+
+// ShareWithUser shares the owner's transcription with an email address and
+// notifies it. The share goes to the account that verified the address, and
+// stays pending until one does, so the result does not reveal whether the
+// address is registered. Sharing again with the same address changes the
+// permission of the existing grant.
+func (s *SharingService) ShareWithUser(ctx context.Context, input ShareTranscriptionInput) (*entities.TranscriptionShare, error) {
+	transcription, err := s.ownedTranscription(ctx, input.TranscriptionID, input.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := entities.NewTranscriptionShare(transcription, input.Email, input.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	if user, err := s.userRepo.FindByEmail(ctx, share.Email); err == nil && user.EmailVerified {
+		if err := share.Claim(user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := s.shareRepo.FindByTranscriptionID(ctx, transcription.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, earlier := range existing {
+		if earlier.IsFor(share.Email) || (!share.IsPending() && earlier.UserID == share.UserID) {
+			if err := earlier.ChangePermission(input.Permission); err != nil {
+				return nil, err
+			}
+			if err := s.shareRepo.Update(ctx, earlier); err != nil {
+				return nil, err
+			}
+			return earlier, nil
+		}
+	}
+
+	if err := s.shareRepo.Create(ctx, share); err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, transcription, share)
+	return share, nil
+}
+
+// GetShares returns the shares of the owner's transcription, oldest first
+func (s *SharingService) GetShares(ctx context.Context, transcriptionID, ownerID uuid.UUID) ([]*entities.TranscriptionShare, error) {
+	if _, err := s.ownedTranscription(ctx, transcriptionID, ownerID); err != nil {
+		return nil, err
+	}
+
+	return s.shareRepo.FindByTranscriptionID(ctx, transcriptionID)
+}
+
+// RevokeShare withdraws a user's access to the owner's transcription
+func (s *SharingService) RevokeShare(ctx context.Context, transcriptionID, shareID, ownerID uuid.UUID) error {
+	if _, err := s.ownedTranscription(ctx, transcriptionID, ownerID); err != nil {
+		return err
+	}
+
+	share, err := s.shareRepo.FindByID(ctx, shareID)
+	if err != nil || share.TranscriptionID != transcriptionID {
+		return ErrShareNotFound
+	}
+
+	return s.shareRepo.Delete(ctx, share.ID)
+}
+
+// GetSharedWithUser returns the transcriptions other users shared with the
+// user, most recently shared first
+func (s *SharingService) GetSharedWithUser(ctx context.Context, userID uuid.UUID) ([]*entities.Transcription, error) {
+	if err := s.claim(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	shares, err := s.shareRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transcriptions := make([]*entities.Transcription, 0, len(shares))
+	for _, share := range shares {
+		transcription, err := s.transcriptionRepo.FindByID(ctx, share.TranscriptionID)
+		if err != nil {
+			continue
+		}
+		transcriptions = append(transcriptions, transcription)
+	}
+
+	return transcriptions, nil
+}
+
+// Permission returns the permission the user was granted on the
+// transcription, or false when it was not shared with them
+func (s *SharingService) Permission(ctx context.Context, transcriptionID, userID uuid.UUID) (string, bool) {
+	share, err := s.shareRepo.Find(ctx, transcriptionID, userID)
+	if err != nil {
+		if s.claim(ctx, userID) != nil {
+			return "", false
+		}
+		if share, err = s.shareRepo.Find(ctx, transcriptionID, userID); err != nil {
+			return "", false
+		}
+	}
+	return share.Permission, true
+}
+
+// claim grants the user the pending shares made out to their address once
+// it is verified
+func (s *SharingService) claim(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil || !user.EmailVerified {
+		return nil
+	}
+
+	pending, err := s.shareRepo.FindPendingByEmail(ctx, user.Email)
+	if err != nil {
+		return err
+	}
+
+	for _, share := range pending {
+		if _, err := s.shareRepo.Find(ctx, share.TranscriptionID, user.ID); err == nil {
+			// The user already holds a grant made out to a previous address
+			if err := s.shareRepo.Delete(ctx, share.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if share.Claim(user.ID) != nil {
+			continue
+		}
+		if err := s.shareRepo.Update(ctx, share); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notify emails the address a transcription was shared with. Failures are
+// logged since the share stands without the email.
+func (s *SharingService) notify(ctx context.Context, transcription *entities.Transcription, share *entities.TranscriptionShare) {
+	if s.mailer == nil {
+		return
+	}
+
+	owner := "A Voiceline user"
+	if user, err := s.userRepo.FindByID(ctx, share.OwnerID); err == nil {
+		owner = user.Name
+	}
+
+	title := transcription.Title
+	if title == "" {
+		title = "a transcription"
+	} else {
+		title = fmt.Sprintf("%q", title)
+	}
+
+	body := fmt.Sprintf(
+		"Hi,\n\n%s shared %s with you on Voiceline. Open it here:\n\n%s\n\n"+
+			"If you do not have an account yet, sign up and verify this email address to get access.\n",
+		owner, title, s.transcriptionURL+"/"+transcription.ID.String(),
+	)
+	if err := s.mailer.Send(ctx, share.Email, owner+" shared a transcription with you", body); err != nil {
+		log.Printf("share notification for transcription %s: %v", transcription.ID, err)
+	}
+}
+
+// CreateLink creates a public read-only link to the owner's transcription.
+// A nil expiresAt keeps the link valid until it is revoked.
+func (s *SharingService) CreateLink(ctx context.Context, transcriptionID, ownerID uuid.UUID, expiresAt *time.Time) (*CreatedShareLink, error) {
+	transcription, err := s.ownedTranscription(ctx, transcriptionID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	link, secret, err := entities.NewShareLink(transcription, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.linkRepo.Create(ctx, link); err != nil {
+		return nil, err
+	}
+
+	return &CreatedShareLink{Link: link, URL: s.linkURL + "/" + secret}, nil
+}
+
+// GetLinks returns the public links of the owner's transcription, including
+// revoked and expired ones
+func (s *SharingService) GetLinks(ctx context.Context, transcriptionID, ownerID uuid.UUID) ([]*entities.ShareLink, error) {
+	if _, err := s.ownedTranscription(ctx, transcriptionID, ownerID); err != nil {
+		return nil, err
+	}
+
+	return s.linkRepo.FindByTranscriptionID(ctx, transcriptionID)
+}
+
+// RevokeLink disables a public link of the owner's transcription
+func (s *SharingService) RevokeLink(ctx context.Context, transcriptionID, linkID, ownerID uuid.UUID) error {
+	if _, err := s.ownedTranscription(ctx, transcriptionID, ownerID); err != nil {
+		return err
+	}
+
+	link, err := s.linkRepo.FindByID(ctx, linkID)
+	if err != nil || link.TranscriptionID != transcriptionID {
+		return ErrShareLinkNotFound
+	}
+
+	link.Revoke()
+	return s.linkRepo.Update(ctx, link)
+}
+
+// GetSharedTranscription returns the transcription behind an active public
+// link token
+func (s *SharingService) GetSharedTranscription(ctx context.Context, token string) (*entities.Transcription, error) {
+	link, err := s.linkRepo.FindByTokenHash(ctx, entities.HashUserToken(token))
+	if err != nil || !link.IsActive(time.Now()) {
+		return nil, ErrInvalidShareLink
+	}
+
+	transcription, err := s.transcriptionRepo.FindByID(ctx, link.TranscriptionID)
+	if err != nil {
+		return nil, ErrInvalidShareLink
+	}
+
+	return transcription, nil
+}
+
+// Subscribe removes the grants and links of deleted users
+func (s *SharingService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+		userID := event.(*entities.UserDeleted).UserID
+		if err := s.shareRepo.DeleteByUserID(ctx, userID); err != nil {
+			return err
+		}
+		return s.linkRepo.DeleteByOwnerID(ctx, userID)
+	})
+}
+
+func (s *SharingService) ownedTranscription(ctx context.Context, transcriptionID, ownerID uuid.UUID) (*entities.Transcription, error) {
+	transcription, err := s.transcriptionRepo.FindByID(ctx, transcriptionID)
+	if err != nil {
+		return nil, ErrTranscriptionNotFound
+	}
+
+	if !transcription.BelongsToUser(ownerID) {
+		return nil, ErrUnauthorizedAccess
+	}
+
+	return transcription, nil
+}
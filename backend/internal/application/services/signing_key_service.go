@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrNoSigningKey      = errors.New("no active signing key")
+	ErrUnknownSigningKey = errors.New("unknown or expired signing key")
+)
+
+// SigningKeyPolicy configures the algorithm and rotation schedule of the keys
+// that sign access tokens
+type SigningKeyPolicy struct {
+	Algorithm string
+	// RotationInterval is how long a key signs new tokens before it is replaced
+	RotationInterval time.Duration
+	// VerificationPeriod is how long a retired key keeps verifying the tokens
+	// it signed; it must cover the token lifetime
+	VerificationPeriod time.Duration
+}
+
+// DefaultSigningKeyPolicy rotates Ed25519 keys monthly and keeps retired keys
+// a day longer than tokens live
+func DefaultSigningKeyPolicy() SigningKeyPolicy {
+	return SigningKeyPolicy{
+		Algorithm:          entities.SigningAlgorithmEdDSA,
+		RotationInterval:   30 * 24 * time.Hour,
+		VerificationPeriod: accessTokenTTL + 24*time.Hour,
+	}
+}
+
+// SigningKeyService manages the asymmetric keys that sign access tokens.
+// Rotation and lookups are serialized so keys are not read while they are
+// being retired.
+type SigningKeyService struct {
+	keyRepo repositories.SigningKeyRepository
+	policy  SigningKeyPolicy
+	mu      sync.RWMutex
+}
+
+func NewSigningKeyService(keyRepo repositories.SigningKeyRepository, policy SigningKeyPolicy) *SigningKeyService {
+	return &SigningKeyService{
+		keyRepo: keyRepo,
+		policy:  policy,
+	}
+}
+
+// Rotate makes a new key active and retires the previous one
+func (s *SigningKeyService) Rotate(ctx context.Context) (*entities.SigningKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.rotate(ctx)
+}
+
+// EnsureActiveKey rotates when there is no active key or it is due, and
+// removes retired keys that can no longer verify tokens
+func (s *SigningKeyService) EnsureActiveKey(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys, err := s.keyRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	rotate := true
+	for _, key := range keys {
+		if key.IsActive() && !key.IsDue(now, s.policy.RotationInterval) {
+			rotate = false
+		}
+
+		if key.IsExpired(now) {
+			if err := s.keyRepo.Delete(ctx, key.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if rotate {
+		_, err = s.rotate(ctx)
+	}
+	return err
+}
+
+// SigningKey returns the key that signs new tokens
+func (s *SigningKeyService) SigningKey(ctx context.Context) (*entities.SigningKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys, err := s.keyRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if key.IsActive() {
+			return key, nil
+		}
+	}
+
+	return nil, ErrNoSigningKey
+}
+
+// VerificationKey returns the key with the given ID if it may still verify
+// tokens
+func (s *SigningKeyService) VerificationKey(ctx context.Context, id string) (*entities.SigningKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, err := s.keyRepo.FindByID(ctx, id)
+	if err != nil || key.IsExpired(time.Now()) {
+		return nil, ErrUnknownSigningKey
+	}
+
+	return key, nil
+}
+
+// VerificationKeys returns every key that may still verify tokens, newest
+// first, for publishing as a JWKS
+func (s *SigningKeyService) VerificationKeys(ctx context.Context) ([]*entities.SigningKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys, err := s.keyRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]*entities.SigningKey, 0, len(keys))
+	for _, key := range keys {
+		if !key.IsExpired(now) {
+			result = append(result, key)
+		}
+	}
+
+	return result, nil
+}
+
+// Run keeps the rotation schedule every interval until the context is
+// cancelled
+func (s *SigningKeyService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.EnsureActiveKey(ctx); err != nil {
+				log.Printf("signing key rotation: %v", err)
+			}
+		}
+	}
+}
+
+func (s *SigningKeyService) rotate(ctx context.Context) (*entities.SigningKey, error) {
+	key, err := entities.NewSigningKey(s.policy.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.keyRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.keyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	for _, previous := range keys {
+		if !previous.IsActive() {
+			continue
+		}
+
+		previous.Retire(s.policy.VerificationPeriod)
+		if err := s.keyRepo.Update(ctx, previous); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
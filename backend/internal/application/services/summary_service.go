@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrSummaryNotFound     = errors.New("transcription has not been summarized")
+	ErrSummarizationFailed = errors.New("summarization failed")
+)
+
+// ISummarizer condenses transcription text into a summary, key points and
+// action items. Only Text, KeyPoints, ActionItems and Model of the result
+// are used.
+type ISummarizer interface {
+	Summarize(ctx context.Context, text string) (*entities.Summary, error)
+}
+
+// SummaryService generates and caches summaries of transcriptions
+type SummaryService struct {
+	transcriptionRepo    repositories.TranscriptionRepository
+	transcriptionService *TranscriptionService
+	summarizer           ISummarizer
+	usageService         *UsageService
+}
+
+// NewSummaryService creates a new SummaryService
+func NewSummaryService(
+	transcriptionRepo repositories.TranscriptionRepository,
+	transcriptionService *TranscriptionService,
+	summarizer ISummarizer,
+) *SummaryService {
+	return &SummaryService{
+		transcriptionRepo:    transcriptionRepo,
+		transcriptionService: transcriptionService,
+		summarizer:           summarizer,
+	}
+}
+
+// WithUsageMetering holds summaries to the requesting user's quota and
+// records them in the usage ledger, for summarizers that call a paid provider
+func (s *SummaryService) WithUsageMetering(usageService *UsageService) *SummaryService {
+	s.usageService = usageService
+	return s
+}
+
+// Summarize summarizes a transcription the user can read and returns it with
+// its summary. The cached summary is reused while the text is unchanged.
+func (s *SummaryService) Summarize(ctx context.Context, transcriptionID, userID uuid.UUID) (*entities.Transcription, error) {
+	transcription, err := s.transcriptionService.GetTranscription(ctx, transcriptionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !transcription.IsCompleted() {
+		return nil, entities.ErrTranscriptionNotCompleted
+	}
+
+	if transcription.HasCurrentSummary() {
+		return transcription, nil
+	}
+
+	// Cached summaries cost nothing, so only new ones are held to the quota
+	if s.usageService != nil {
+		if err := s.usageService.CheckQuota(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.generate(ctx, transcription, userID)
+}
+
+// GetSummary returns a summarized transcription the user can read. Its
+// summary may be stale after an edit until it is regenerated.
+func (s *SummaryService) GetSummary(ctx context.Context, transcriptionID, userID uuid.UUID) (*entities.Transcription, error) {
+	transcription, err := s.transcriptionService.GetTranscription(ctx, transcriptionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transcription.Summary == nil {
+		return nil, ErrSummaryNotFound
+	}
+
+	return transcription, nil
+}
+
+// Subscribe regenerates cached summaries when their transcription's text is
+// edited, on the quota of the editor. Transcriptions that were never
+// summarized, or whose editor is out of quota, keep their stale summary.
+func (s *SummaryService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventTranscriptionEdited, func(ctx context.Context, event entities.DomainEvent) error {
+		edited := event.(*entities.TranscriptionEdited)
+		transcription, err := s.transcriptionRepo.FindByID(ctx, edited.TranscriptionID)
+		if err != nil || transcription.Summary == nil || transcription.HasCurrentSummary() {
+			return nil
+		}
+
+		if s.usageService != nil && s.usageService.CheckQuota(ctx, edited.EditedBy) != nil {
+			return nil
+		}
+
+		_, err = s.generate(ctx, transcription, edited.EditedBy)
+		return err
+	})
+}
+
+// generate summarizes the transcription's text on behalf of userID and
+// caches the result unless the text was edited meanwhile, in which case that
+// edit regenerates it
+func (s *SummaryService) generate(ctx context.Context, transcription *entities.Transcription, userID uuid.UUID) (*entities.Transcription, error) {
+	text := transcription.Text
+
+	result, err := s.summarizer.Summarize(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSummarizationFailed, err)
+	}
+
+	if s.usageService != nil {
+		if err := s.usageService.RecordOperation(ctx, userID, transcription, entities.UsageOperationSummary); err != nil {
+			log.Printf("failed to record summary usage for transcription %s: %v", transcription.ID, err)
+		}
+	}
+
+	current, err := s.transcriptionRepo.FindByID(ctx, transcription.ID)
+	if err != nil {
+		return nil, ErrTranscriptionNotFound
+	}
+
+	if current.Text != text {
+		return nil, fmt.Errorf("%w: text was edited while summarizing", ErrSummarizationFailed)
+	}
+
+	if err := current.Summarize(result); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSummarizationFailed, err)
+	}
+
+	if err := s.transcriptionRepo.Update(ctx, current); err != nil {
+		return nil, err
+	}
+
+	return current, nil
+}
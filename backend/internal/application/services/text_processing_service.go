@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+// ITextProcessor is a stage of the pipeline that post-processes transcript
+// text. It receives the settings of the transcription's owner for stages
+// configured per user.
+type ITextProcessor interface {
+	Process(ctx context.Context, text string, settings *entities.TextProcessingSettings) (string, error)
+}
+
+// TextProcessorFunc adapts a plain text transformation to ITextProcessor
+type TextProcessorFunc func(text string) string
+
+// Process applies the transformation
+func (f TextProcessorFunc) Process(ctx context.Context, text string, settings *entities.TextProcessingSettings) (string, error) {
+	return f(text), nil
+}
+
+type replacementProcessor struct{}
+
+func (replacementProcessor) Process(ctx context.Context, text string, settings *entities.TextProcessingSettings) (string, error) {
+	return settings.Replace(text), nil
+}
+
+// UpdateTextProcessingInput replaces a user's stages and replacement rules
+type UpdateTextProcessingInput struct {
+	UserID       uuid.UUID
+	Stages       []entities.TextStage
+	Replacements []entities.TextReplacement
+}
+
+// TextProcessingService runs each user's chain of text processing stages
+// between the provider's response and the completed transcription
+type TextProcessingService struct {
+	settingsRepo  repositories.TextProcessingSettingsRepository
+	processors    map[entities.TextStage]ITextProcessor
+	defaultStages []entities.TextStage
+}
+
+// NewTextProcessingService creates a new TextProcessingService with the
+// built-in stages. Users without settings get no processing.
+func NewTextProcessingService(settingsRepo repositories.TextProcessingSettingsRepository) *TextProcessingService {
+	return &TextProcessingService{
+		settingsRepo: settingsRepo,
+		processors: map[entities.TextStage]ITextProcessor{
+			entities.TextStageFillers:      TextProcessorFunc(entities.RemoveFillers),
+			entities.TextStageNumbers:      TextProcessorFunc(entities.FormatNumbers),
+			entities.TextStageNormalize:    TextProcessorFunc(entities.NormalizeText),
+			entities.TextStageReplacements: replacementProcessor{},
+			entities.TextStageProfanity:    TextProcessorFunc(entities.MaskProfanity),
+		},
+		defaultStages: make([]entities.TextStage, 0),
+	}
+}
+
+// WithProcessor replaces the implementation of a stage, for example with one
+// backed by a language model
+func (s *TextProcessingService) WithProcessor(stage entities.TextStage, processor ITextProcessor) *TextProcessingService {
+	s.processors[stage] = processor
+	return s
+}
+
+// WithDefaultStages sets the stages run for users who have not chosen their own
+func (s *TextProcessingService) WithDefaultStages(stages []entities.TextStage) *TextProcessingService {
+	s.defaultStages = stages
+	return s
+}
+
+// GetSettings returns the user's settings, or the defaults when they have
+// none saved
+func (s *TextProcessingService) GetSettings(ctx context.Context, userID uuid.UUID) (*entities.TextProcessingSettings, error) {
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return entities.NewTextProcessingSettings(userID, s.defaultStages), nil
+	}
+	return settings, nil
+}
+
+// UpdateSettings replaces the user's stages and replacement rules. They apply
+// to transcriptions completed from then on.
+func (s *TextProcessingService) UpdateSettings(ctx context.Context, input UpdateTextProcessingInput) (*entities.TextProcessingSettings, error) {
+	settings, err := s.GetSettings(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := settings.Update(input.Stages, input.Replacements); err != nil {
+		return nil, err
+	}
+
+	if err := s.settingsRepo.Save(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// Pipeline returns the user's stages composed into one function. Processing
+// is best effort: a failing stage is logged and skipped.
+func (s *TextProcessingService) Pipeline(ctx context.Context, userID uuid.UUID) func(string) string {
+	settings, err := s.GetSettings(ctx, userID)
+	if err != nil {
+		log.Printf("failed to load text processing settings of user %s: %v", userID, err)
+		return func(text string) string { return text }
+	}
+
+	return func(text string) string {
+		for _, stage := range settings.Stages {
+			processor, ok := s.processors[stage]
+			if !ok {
+				continue
+			}
+
+			processed, err := processor.Process(ctx, text, settings)
+			if err != nil {
+				log.Printf("text processing stage %s failed: %v", stage, err)
+				continue
+			}
+			text = processed
+		}
+		return text
+	}
+}
+
+// Subscribe removes the settings of deleted users
+func (s *TextProcessingService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+		return s.settingsRepo.DeleteByUserID(ctx, event.(*entities.UserDeleted).UserID)
+	})
+}
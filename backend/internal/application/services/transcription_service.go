@@ -1,9 +1,15 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
+	"log"
+	"sort"
 
 	"github.com/google/uuid"
 	"github.com/voiceline/backend/internal/domain/entities"
@@ -13,15 +19,51 @@ import (
 var (
 	ErrTranscriptionNotFound = errors.New("transcription not found")
 	ErrUnauthorizedAccess    = errors.New("unauthorized access to transcription")
+	ErrInvalidDedupScope     = errors.New("deduplication scope must be disabled, user or global")
 )
 
 type ITranscriptionService interface {
 	TranscribeAudio(ctx context.Context, audio io.Reader) (string, float64, error)
 }
 
+// IDiarizer splits transcribed audio into segments attributed to speakers.
+// It receives the audio together with the provider's text and duration.
+type IDiarizer interface {
+	Diarize(ctx context.Context, audio io.Reader, text string, duration float64) ([]entities.Segment, error)
+}
+
+// DeduplicationScope controls whose earlier transcriptions of identical audio
+// may be reused instead of calling the provider again
+type DeduplicationScope string
+
+const (
+	DeduplicationDisabled DeduplicationScope = "disabled"
+	DeduplicationUser     DeduplicationScope = "user"
+	DeduplicationGlobal   DeduplicationScope = "global"
+)
+
+// ParseDeduplicationScope parses a deduplication scope, rejecting unknown ones
+func ParseDeduplicationScope(value string) (DeduplicationScope, error) {
+	switch scope := DeduplicationScope(value); scope {
+	case DeduplicationDisabled, DeduplicationUser, DeduplicationGlobal:
+		return scope, nil
+	default:
+		return "", ErrInvalidDedupScope
+	}
+}
+
 type TranscriptionService struct {
 	transcriptionRepo repositories.TranscriptionRepository
 	transcriptionSvc  ITranscriptionService
+	outbox            *outboxWriter
+	dedupScope        DeduplicationScope
+	usageService      *UsageService
+	audioStore        repositories.FileStore
+	organizations     *OrganizationService
+	sharing           *SharingService
+	diarizer          IDiarizer
+	textProcessing    *TextProcessingService
+	redactionKinds    []entities.PIIKind
 }
 
 func NewTranscriptionService(
@@ -31,38 +73,153 @@ func NewTranscriptionService(
 	return &TranscriptionService{
 		transcriptionRepo: transcriptionRepo,
 		transcriptionSvc:  transcriptionSvc,
+		dedupScope:        DeduplicationUser,
+		redactionKinds:    entities.AllPIIKinds,
 	}
 }
 
+// WithDeduplication sets whose completed transcriptions of the same audio are
+// reused. Defaults to the uploading user's own transcriptions.
+func (s *TranscriptionService) WithDeduplication(scope DeduplicationScope) *TranscriptionService {
+	s.dedupScope = scope
+	return s
+}
+
+// WithUsageMetering enforces monthly quotas before calling the provider and
+// records every provider call in the usage ledger
+func (s *TranscriptionService) WithUsageMetering(usageService *UsageService) *TranscriptionService {
+	s.usageService = usageService
+	return s
+}
+
+// WithAudioStore keeps the uploaded audio of every transcription
+func (s *TranscriptionService) WithAudioStore(audioStore repositories.FileStore) *TranscriptionService {
+	s.audioStore = audioStore
+	return s
+}
+
+// WithOrganizations lets users create transcriptions in organizations they
+// belong to and read those of the other members
+func (s *TranscriptionService) WithOrganizations(organizationService *OrganizationService) *TranscriptionService {
+	s.organizations = organizationService
+	return s
+}
+
+// WithSharing lets users read, and with an edit grant correct, the
+// transcriptions other users shared with them
+func (s *TranscriptionService) WithSharing(sharingService *SharingService) *TranscriptionService {
+	s.sharing = sharingService
+	return s
+}
+
+// WithDiarizer attributes the text of new transcriptions to speakers
+func (s *TranscriptionService) WithDiarizer(diarizer IDiarizer) *TranscriptionService {
+	s.diarizer = diarizer
+	return s
+}
+
+// WithTextProcessing runs the owner's text processing stages on the text of
+// new transcriptions before they complete
+func (s *TranscriptionService) WithTextProcessing(textProcessing *TextProcessingService) *TranscriptionService {
+	s.textProcessing = textProcessing
+	return s
+}
+
+// WithRedaction selects the kinds of personal data redacted from completed
+// transcriptions. All kinds are redacted by default.
+func (s *TranscriptionService) WithRedaction(kinds []entities.PIIKind) *TranscriptionService {
+	s.redactionKinds = kinds
+	return s
+}
+
+// WithOutbox records the domain events emitted by transcriptions in the
+// outbox, atomically with the state change that produced them
+func (s *TranscriptionService) WithOutbox(transactor repositories.Transactor, outboxRepo repositories.OutboxRepository) *TranscriptionService {
+	s.outbox = &outboxWriter{transactor: transactor, outboxRepo: outboxRepo}
+	return s
+}
+
 type TranscribeAudioInput struct {
 	UserID uuid.UUID
-	Audio  io.Reader
+	// OrganizationID creates the transcription in an organization the user
+	// belongs to instead of their personal space
+	OrganizationID *uuid.UUID
+	Details        TranscriptionDetails
+	Audio          io.Reader
 }
 
 func (s *TranscriptionService) Transcribe(ctx context.Context, input TranscribeAudioInput) (*entities.Transcription, error) {
-	transcription := entities.NewTranscription(input.UserID)
+	if input.OrganizationID != nil && !s.isMember(ctx, *input.OrganizationID, input.UserID) {
+		return nil, ErrNotOrganizationMember
+	}
 
-	if err := s.transcriptionRepo.Create(ctx, transcription); err != nil {
+	audio, err := io.ReadAll(input.Audio)
+	if err != nil {
 		return nil, err
 	}
 
-	text, duration, err := s.transcriptionSvc.TranscribeAudio(ctx, input.Audio)
+	audioHash := hashAudio(audio)
+	source, err := s.findDuplicate(ctx, input.UserID, audioHash)
 	if err != nil {
-		transcription.Fail()
-		_ = s.transcriptionRepo.Update(ctx, transcription)
 		return nil, err
 	}
 
-	if err := transcription.Complete(text, duration); err != nil {
-		transcription.Fail()
-		_ = s.transcriptionRepo.Update(ctx, transcription)
+	// Reused results cost nothing, so only provider calls are held to the quota
+	if source == nil && s.usageService != nil {
+		if err := s.usageService.CheckQuota(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	transcription := entities.NewTranscription(input.UserID)
+	transcription.OrganizationID = input.OrganizationID
+	transcription.RedactionRequired = s.requiresRedaction(ctx, input.OrganizationID)
+	transcription.AudioHash = audioHash
+	if err := input.Details.apply(transcription); err != nil {
+		return nil, err
+	}
+
+	if err := s.storeAudio(ctx, transcription, audio); err != nil {
+		return nil, err
+	}
+
+	if err := s.create(ctx, transcription); err != nil {
+		s.deleteAudio(ctx, transcription)
 		return nil, err
 	}
 
-	if err := s.transcriptionRepo.Update(ctx, transcription); err != nil {
+	if source != nil {
+		if err := transcription.CompleteFromDuplicate(source); err != nil {
+			return nil, s.fail(ctx, transcription, err)
+		}
+		s.processText(ctx, transcription)
+		transcription.Redact(s.redactionKinds)
+
+		if err := s.update(ctx, transcription); err != nil {
+			return nil, err
+		}
+
+		return transcription, nil
+	}
+
+	text, duration, err := s.transcriptionSvc.TranscribeAudio(ctx, bytes.NewReader(audio))
+	if err != nil {
+		return nil, s.fail(ctx, transcription, err)
+	}
+
+	if err := transcription.Complete(text, duration); err != nil {
+		return nil, s.fail(ctx, transcription, err)
+	}
+
+	s.diarize(ctx, transcription, audio)
+	s.processText(ctx, transcription)
+	transcription.Redact(s.redactionKinds)
+
+	if err := s.update(ctx, transcription); err != nil {
 		return nil, err
 	}
 
+	s.recordUsage(ctx, transcription)
 	return transcription, nil
 }
 
@@ -72,18 +229,476 @@ func (s *TranscriptionService) GetTranscription(ctx context.Context, id uuid.UUI
 		return nil, ErrTranscriptionNotFound
 	}
 
-	if !transcription.BelongsToUser(userID) {
+	if !s.canAccess(ctx, transcription, userID) {
+		return nil, ErrUnauthorizedAccess
+	}
+
+	return transcription, nil
+}
+
+// TranscriptionDetails are the user-defined labels of a transcription. Nil
+// fields are left unchanged and a non-nil Metadata replaces all entries.
+type TranscriptionDetails struct {
+	Title    *string
+	Folder   *string
+	Tags     *[]string
+	Metadata map[string]string
+}
+
+// validate checks the details against a scratch transcription, so that an
+// invalid field is reported before any field of the real one changes
+func (d TranscriptionDetails) validate() error {
+	return d.apply(&entities.Transcription{})
+}
+
+func (d TranscriptionDetails) apply(transcription *entities.Transcription) error {
+	if d.Title != nil {
+		if err := transcription.SetTitle(*d.Title); err != nil {
+			return err
+		}
+	}
+
+	if d.Folder != nil {
+		if err := transcription.SetFolder(*d.Folder); err != nil {
+			return err
+		}
+	}
+
+	if d.Tags != nil {
+		if err := transcription.SetTags(*d.Tags); err != nil {
+			return err
+		}
+	}
+
+	if d.Metadata != nil {
+		if err := transcription.SetMetadata(d.Metadata); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type UpdateTranscriptionInput struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	// Text corrects the text of a completed transcription
+	Text    *string
+	Details TranscriptionDetails
+}
+
+// UpdateTranscription corrects the text and changes the labels of a
+// transcription. Owners, admins and owners of its organization and users
+// granted edit access may update it.
+func (s *TranscriptionService) UpdateTranscription(ctx context.Context, input UpdateTranscriptionInput) (*entities.Transcription, error) {
+	transcription, err := s.GetTranscription(ctx, input.ID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.canEdit(ctx, transcription, input.UserID) {
 		return nil, ErrUnauthorizedAccess
 	}
 
+	// Repositories hand out the stored transcription, so nothing may change
+	// before the whole update is known to succeed
+	if err := input.Details.validate(); err != nil {
+		return nil, err
+	}
+	if input.Text != nil {
+		if err := transcription.CheckEdit(*input.Text); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := input.Details.apply(transcription); err != nil {
+		return nil, err
+	}
+
+	if input.Text != nil {
+		if err := transcription.EditText(*input.Text, input.UserID); err != nil {
+			return nil, err
+		}
+		transcription.Redact(s.redactionKinds)
+	}
+
+	if err := s.update(ctx, transcription); err != nil {
+		return nil, err
+	}
+
 	return transcription, nil
 }
 
-func (s *TranscriptionService) GetUserTranscriptions(ctx context.Context, userID uuid.UUID) ([]*entities.Transcription, error) {
+// RenameSpeaker names a speaker of a diarized transcription the user can
+// edit. An empty name restores the speaker's label.
+func (s *TranscriptionService) RenameSpeaker(ctx context.Context, id, userID uuid.UUID, label, name string) (*entities.Transcription, error) {
+	transcription, err := s.GetTranscription(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.canEdit(ctx, transcription, userID) {
+		return nil, ErrUnauthorizedAccess
+	}
+
+	if err := transcription.RenameSpeaker(label, name); err != nil {
+		return nil, err
+	}
+
+	if err := s.update(ctx, transcription); err != nil {
+		return nil, err
+	}
+
+	return transcription, nil
+}
+
+// SetRedactionPolicy changes whether only the redacted text of an
+// organization's transcriptions is returned and applies the policy to its
+// existing transcriptions; admins and owners only
+func (s *TranscriptionService) SetRedactionPolicy(ctx context.Context, organizationID, userID uuid.UUID, required bool) (*Membership, error) {
+	if s.organizations == nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	membership, err := s.organizations.SetRedactionRequired(ctx, organizationID, userID, required)
+	if err != nil {
+		return nil, err
+	}
+
+	transcriptions, err := s.transcriptionRepo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, transcription := range transcriptions {
+		var changed bool
+		if required {
+			changed = transcription.RequireRedaction(s.redactionKinds)
+		} else {
+			changed = transcription.ReleaseRedaction()
+		}
+		if !changed {
+			continue
+		}
+
+		if err := s.update(ctx, transcription); err != nil {
+			return nil, err
+		}
+	}
+
+	return membership, nil
+}
+
+// TranscriptionFilter narrows transcription lists. Empty fields match all.
+type TranscriptionFilter struct {
+	Tag string
+	// Folder matches the folder and its subfolders
+	Folder string
+}
+
+func (f TranscriptionFilter) matches(transcription *entities.Transcription) bool {
+	if f.Tag != "" && !transcription.HasTag(f.Tag) {
+		return false
+	}
+	return f.Folder == "" || transcription.InFolder(f.Folder)
+}
+
+func (f TranscriptionFilter) apply(transcriptions []*entities.Transcription) []*entities.Transcription {
+	if f.Tag == "" && f.Folder == "" {
+		return transcriptions
+	}
+
+	filtered := make([]*entities.Transcription, 0, len(transcriptions))
+	for _, transcription := range transcriptions {
+		if f.matches(transcription) {
+			filtered = append(filtered, transcription)
+		}
+	}
+	return filtered
+}
+
+// TagCount is a tag with the number of transcriptions carrying it
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// GetUserTags returns the tags on the user's transcriptions, alphabetically
+func (s *TranscriptionService) GetUserTags(ctx context.Context, userID uuid.UUID) ([]*TagCount, error) {
 	transcriptions, err := s.transcriptionRepo.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return transcriptions, nil
+	counts := make(map[string]int)
+	for _, transcription := range transcriptions {
+		for _, tag := range transcription.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]*TagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, &TagCount{Tag: tag, Count: count})
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Tag < tags[j].Tag
+	})
+
+	return tags, nil
+}
+
+// MergeTags replaces the tags in from with into on every transcription of
+// the user. Renaming a tag is merging it alone, and merging into an existing
+// tag combines both. It returns the number of transcriptions changed.
+func (s *TranscriptionService) MergeTags(ctx context.Context, userID uuid.UUID, from []string, into string) (int, error) {
+	into, err := entities.NormalizeTag(into)
+	if err != nil {
+		return 0, err
+	}
+
+	sources := make([]string, len(from))
+	for i, tag := range from {
+		if sources[i], err = entities.NormalizeTag(tag); err != nil {
+			return 0, err
+		}
+	}
+
+	transcriptions, err := s.transcriptionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, transcription := range transcriptions {
+		changed, err := transcription.ReplaceTags(sources, into)
+		if err != nil {
+			return updated, err
+		}
+		if !changed {
+			continue
+		}
+
+		if err := s.update(ctx, transcription); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
+// GetOrganizationTranscriptions returns the transcriptions created in an
+// organization the user belongs to
+func (s *TranscriptionService) GetOrganizationTranscriptions(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID, filter TranscriptionFilter) ([]*entities.Transcription, error) {
+	if !s.isMember(ctx, organizationID, userID) {
+		return nil, ErrNotOrganizationMember
+	}
+
+	transcriptions, err := s.transcriptionRepo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return filter.apply(transcriptions), nil
+}
+
+func (s *TranscriptionService) GetUserTranscriptions(ctx context.Context, userID uuid.UUID, filter TranscriptionFilter) ([]*entities.Transcription, error) {
+	transcriptions, err := s.transcriptionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return filter.apply(transcriptions), nil
+}
+
+// DeleteUserTranscriptions removes every transcription of the user along
+// with its stored audio
+func (s *TranscriptionService) DeleteUserTranscriptions(ctx context.Context, userID uuid.UUID) error {
+	transcriptions, err := s.transcriptionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, transcription := range transcriptions {
+		if s.audioStore != nil && transcription.AudioKey != "" {
+			if err := s.audioStore.Delete(ctx, transcription.AudioKey); err != nil {
+				return err
+			}
+		}
+
+		if err := s.transcriptionRepo.Delete(ctx, transcription.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// canAccess reports whether the user owns the transcription, belongs to
+// the organization it was created in or was granted access to it
+func (s *TranscriptionService) canAccess(ctx context.Context, transcription *entities.Transcription, userID uuid.UUID) bool {
+	if transcription.BelongsToUser(userID) {
+		return true
+	}
+
+	if transcription.OrganizationID != nil && s.isMember(ctx, *transcription.OrganizationID, userID) {
+		return true
+	}
+
+	_, shared := s.permission(ctx, transcription.ID, userID)
+	return shared
+}
+
+// canEdit reports whether the user owns the transcription, administers the
+// organization it was created in or was granted edit access to it. Other
+// members of the organization may only read it.
+func (s *TranscriptionService) canEdit(ctx context.Context, transcription *entities.Transcription, userID uuid.UUID) bool {
+	if transcription.BelongsToUser(userID) {
+		return true
+	}
+
+	if transcription.OrganizationID != nil && s.isManager(ctx, *transcription.OrganizationID, userID) {
+		return true
+	}
+
+	permission, _ := s.permission(ctx, transcription.ID, userID)
+	return permission == entities.SharePermissionEdit
+}
+
+func (s *TranscriptionService) permission(ctx context.Context, transcriptionID, userID uuid.UUID) (string, bool) {
+	if s.sharing == nil {
+		return "", false
+	}
+	return s.sharing.Permission(ctx, transcriptionID, userID)
+}
+
+func (s *TranscriptionService) requiresRedaction(ctx context.Context, organizationID *uuid.UUID) bool {
+	return organizationID != nil && s.organizations != nil && s.organizations.RequiresRedaction(ctx, *organizationID)
+}
+
+func (s *TranscriptionService) isManager(ctx context.Context, organizationID, userID uuid.UUID) bool {
+	return s.organizations != nil && s.organizations.IsManager(ctx, organizationID, userID)
+}
+
+func (s *TranscriptionService) isMember(ctx context.Context, organizationID, userID uuid.UUID) bool {
+	return s.organizations != nil && s.organizations.IsMember(ctx, organizationID, userID)
+}
+
+// findDuplicate returns the oldest completed transcription of the same audio
+// within the configured deduplication scope, or nil
+func (s *TranscriptionService) findDuplicate(ctx context.Context, userID uuid.UUID, audioHash string) (*entities.Transcription, error) {
+	if s.dedupScope != DeduplicationUser && s.dedupScope != DeduplicationGlobal {
+		return nil, nil
+	}
+
+	candidates, err := s.transcriptionRepo.FindByAudioHash(ctx, audioHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		if !candidate.IsCompleted() {
+			continue
+		}
+
+		if s.dedupScope == DeduplicationUser && !candidate.BelongsToUser(userID) {
+			continue
+		}
+
+		return candidate, nil
+	}
+
+	return nil, nil
+}
+
+// diarize attributes the completed transcription's text to speakers. The
+// text is usable without speakers, so diarization failures are logged
+// rather than failing the transcription.
+func (s *TranscriptionService) diarize(ctx context.Context, transcription *entities.Transcription, audio []byte) {
+	if s.diarizer == nil {
+		return
+	}
+
+	segments, err := s.diarizer.Diarize(ctx, bytes.NewReader(audio), transcription.Text, transcription.Duration)
+	if err == nil {
+		err = transcription.SetSegments(segments)
+	}
+	if err != nil {
+		log.Printf("failed to diarize transcription %s: %v", transcription.ID, err)
+	}
+}
+
+// processText applies the owner's text processing stages. The provider's
+// text stays in RawText.
+func (s *TranscriptionService) processText(ctx context.Context, transcription *entities.Transcription) {
+	if s.textProcessing == nil {
+		return
+	}
+
+	transcription.ProcessText(s.textProcessing.Pipeline(ctx, transcription.UserID))
+}
+
+// recordUsage adds the provider call to the usage ledger. The transcription
+// has already been saved, so a ledger failure is logged rather than returned.
+func (s *TranscriptionService) recordUsage(ctx context.Context, transcription *entities.Transcription) {
+	if s.usageService == nil {
+		return
+	}
+
+	if err := s.usageService.RecordTranscription(ctx, transcription); err != nil {
+		log.Printf("failed to record usage for transcription %s: %v", transcription.ID, err)
+	}
+}
+
+func (s *TranscriptionService) storeAudio(ctx context.Context, transcription *entities.Transcription, audio []byte) error {
+	if s.audioStore == nil {
+		return nil
+	}
+
+	key := fmt.Sprintf("%s/%s", transcription.UserID, transcription.ID)
+	if err := s.audioStore.Save(ctx, key, audio); err != nil {
+		return err
+	}
+
+	transcription.AudioKey = key
+	return nil
+}
+
+func (s *TranscriptionService) deleteAudio(ctx context.Context, transcription *entities.Transcription) {
+	if s.audioStore == nil || transcription.AudioKey == "" {
+		return
+	}
+
+	if err := s.audioStore.Delete(ctx, transcription.AudioKey); err != nil {
+		log.Printf("failed to delete audio of transcription %s: %v", transcription.ID, err)
+	}
+}
+
+func hashAudio(audio []byte) string {
+	sum := sha256.Sum256(audio)
+	return hex.EncodeToString(sum[:])
+}
+
+// fail marks the transcription as failed and returns cause, joined with the
+// persistence error if the failed state could not be saved
+func (s *TranscriptionService) fail(ctx context.Context, transcription *entities.Transcription, cause error) error {
+	transcription.Fail()
+	if err := s.update(ctx, transcription); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
+
+func (s *TranscriptionService) create(ctx context.Context, transcription *entities.Transcription) error {
+	return s.outbox.save(ctx, transcription, func(ctx context.Context) error {
+		return s.transcriptionRepo.Create(ctx, transcription)
+	})
+}
+
+func (s *TranscriptionService) update(ctx context.Context, transcription *entities.Transcription) error {
+	return s.outbox.save(ctx, transcription, func(ctx context.Context) error {
+		return s.transcriptionRepo.Update(ctx, transcription)
+	})
 }
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrTranslationNotFound = errors.New("translation not found")
+	ErrTranslationFailed   = errors.New("translation failed")
+)
+
+// ITranslator translates passages of text into a language given as a BCP 47
+// code. It returns one translated passage per input passage, in order.
+type ITranslator interface {
+	Translate(ctx context.Context, passages []string, language string) ([]string, error)
+}
+
+// TranslationService translates transcriptions and keeps every translation
+// as a version
+type TranslationService struct {
+	translationRepo      repositories.TranslationRepository
+	transcriptionService *TranscriptionService
+	translator           ITranslator
+	usageService         *UsageService
+}
+
+// NewTranslationService creates a new TranslationService
+func NewTranslationService(
+	translationRepo repositories.TranslationRepository,
+	transcriptionService *TranscriptionService,
+	translator ITranslator,
+) *TranslationService {
+	return &TranslationService{
+		translationRepo:      translationRepo,
+		transcriptionService: transcriptionService,
+		translator:           translator,
+	}
+}
+
+// WithUsageMetering holds translations to the requesting user's quota and
+// records them in the usage ledger
+func (s *TranslationService) WithUsageMetering(usageService *UsageService) *TranslationService {
+	s.usageService = usageService
+	return s
+}
+
+// Translate translates a completed transcription the user can read into
+// language. The latest version in that language is returned unchanged while
+// it matches the text; created reports whether a new version was added.
+func (s *TranslationService) Translate(ctx context.Context, transcriptionID, userID uuid.UUID, language string) (translation *entities.Translation, created bool, err error) {
+	language, err = entities.NormalizeLanguage(language)
+	if err != nil {
+		return nil, false, err
+	}
+
+	transcription, err := s.transcriptionService.GetTranscription(ctx, transcriptionID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !transcription.IsCompleted() {
+		return nil, false, entities.ErrTranscriptionNotCompleted
+	}
+
+	translations, err := s.translationRepo.FindByTranscriptionID(ctx, transcriptionID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var latest *entities.Translation
+	version := 1
+	for _, existing := range translations {
+		if existing.Language == language {
+			latest = existing
+			version = existing.Version + 1
+		}
+	}
+
+	if latest != nil && latest.IsVisible(transcription) && latest.IsCurrent(transcription.Text) {
+		return latest, false, nil
+	}
+
+	// Current translations cost nothing, so only new ones are held to the quota
+	if s.usageService != nil {
+		if err := s.usageService.CheckQuota(ctx, userID); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// The text is translated together with each speaker segment that still
+	// matches it
+	passages := []string{transcription.Text}
+	for _, segment := range transcription.SpeakerSegments() {
+		passages = append(passages, segment.Text)
+	}
+
+	translated, err := s.translator.Translate(ctx, passages, language)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrTranslationFailed, err)
+	}
+
+	if s.usageService != nil {
+		if err := s.usageService.RecordOperation(ctx, userID, transcription, entities.UsageOperationTranslation); err != nil {
+			log.Printf("failed to record translation usage for transcription %s: %v", transcription.ID, err)
+		}
+	}
+
+	if len(translated) != len(passages) {
+		return nil, false, fmt.Errorf("%w: expected %d passages, got %d", ErrTranslationFailed, len(passages), len(translated))
+	}
+
+	translation, err = entities.NewTranslation(transcription, userID, language, version, translated[0], translated[1:])
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrTranslationFailed, err)
+	}
+
+	if err := s.translationRepo.Create(ctx, translation); err != nil {
+		return nil, false, err
+	}
+
+	return translation, true, nil
+}
+
+// GetTranslations lists the translations of a transcription the user can
+// read by language and version, optionally only those into language
+func (s *TranslationService) GetTranslations(ctx context.Context, transcriptionID, userID uuid.UUID, language string) ([]*entities.Translation, error) {
+	if language != "" {
+		normalized, err := entities.NormalizeLanguage(language)
+		if err != nil {
+			return nil, err
+		}
+		language = normalized
+	}
+
+	transcription, err := s.transcriptionService.GetTranscription(ctx, transcriptionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.translationRepo.FindByTranscriptionID(ctx, transcriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.Translation, 0, len(translations))
+	for _, translation := range translations {
+		if translation.IsVisible(transcription) && (language == "" || translation.Language == language) {
+			result = append(result, translation)
+		}
+	}
+
+	return result, nil
+}
+
+// GetTranslation returns a translation of a transcription the user can read
+func (s *TranslationService) GetTranslation(ctx context.Context, transcriptionID, translationID, userID uuid.UUID) (*entities.Translation, error) {
+	translation, _, err := s.translation(ctx, transcriptionID, translationID, userID)
+	return translation, err
+}
+
+// ExportTranslation returns a translation of a transcription the user can
+// read as plain text. Translations of diarized transcriptions are rendered
+// as one line per speaker turn with the speakers' names.
+func (s *TranslationService) ExportTranslation(ctx context.Context, transcriptionID, translationID, userID uuid.UUID) (*entities.Translation, string, error) {
+	translation, transcription, err := s.translation(ctx, transcriptionID, translationID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(translation.Segments) == 0 {
+		return translation, translation.Text, nil
+	}
+
+	return translation, transcription.SpeakerTranscript(translation.Segments), nil
+}
+
+func (s *TranslationService) translation(ctx context.Context, transcriptionID, translationID, userID uuid.UUID) (*entities.Translation, *entities.Transcription, error) {
+	transcription, err := s.transcriptionService.GetTranscription(ctx, transcriptionID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	translation, err := s.translationRepo.FindByID(ctx, translationID)
+	if err != nil || translation.TranscriptionID != transcriptionID || !translation.IsVisible(transcription) {
+		return nil, nil, ErrTranslationNotFound
+	}
+
+	return translation, transcription, nil
+}
+
+// Subscribe removes the translations of deleted users' transcriptions
+func (s *TranslationService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+		return s.translationRepo.DeleteByOwnerID(ctx, event.(*entities.UserDeleted).UserID)
+	})
+}
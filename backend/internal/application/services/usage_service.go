@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrQuotaExceeded = errors.New("monthly transcription quota exceeded")
+)
+
+// UsagePolicy configures plan quotas and how provider usage is priced.
+// Summaries and translations are priced per minute of the transcription they
+// process at TextCostPerMinute but do not use up quota minutes.
+type UsagePolicy struct {
+	PlanMonthlyMinutes map[string]float64
+	Provider           string
+	CostPerMinute      float64
+	TextProvider       string
+	TextCostPerMinute  float64
+}
+
+// DefaultUsagePolicy prices usage at OpenAI Whisper's per-minute rate, and
+// text processing at a chat model's rate for a minute of speech
+func DefaultUsagePolicy() UsagePolicy {
+	return UsagePolicy{
+		PlanMonthlyMinutes: map[string]float64{
+			entities.PlanFree: 60,
+			entities.PlanPro:  1200,
+		},
+		Provider:          "openai/whisper-1",
+		CostPerMinute:     0.006,
+		TextProvider:      "openai/chat",
+		TextCostPerMinute: 0.001,
+	}
+}
+
+type UsageService struct {
+	usageRepo repositories.UsageRepository
+	quotaRepo repositories.UsageQuotaRepository
+	userRepo  repositories.UserRepository
+	policy    UsagePolicy
+}
+
+func NewUsageService(
+	usageRepo repositories.UsageRepository,
+	quotaRepo repositories.UsageQuotaRepository,
+	userRepo repositories.UserRepository,
+	policy UsagePolicy,
+) *UsageService {
+	return &UsageService{
+		usageRepo: usageRepo,
+		quotaRepo: quotaRepo,
+		userRepo:  userRepo,
+		policy:    policy,
+	}
+}
+
+// UsageSummary is a user's consumption in the current billing period
+type UsageSummary struct {
+	Plan           string
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	QuotaMinutes   float64
+	AudioSeconds   float64
+	EstimatedCost  float64
+	Transcriptions int
+	Summaries      int
+	Translations   int
+}
+
+func (u *UsageSummary) UsedMinutes() float64 {
+	return u.AudioSeconds / 60
+}
+
+func (u *UsageSummary) RemainingMinutes() float64 {
+	return math.Max(0, u.QuotaMinutes-u.UsedMinutes())
+}
+
+func (u *UsageSummary) IsExceeded() bool {
+	return u.UsedMinutes() >= u.QuotaMinutes
+}
+
+func (s *UsageService) GetCurrentUsage(ctx context.Context, userID uuid.UUID) (*UsageSummary, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	quota, err := s.findQuota(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	periodStart, periodEnd := entities.BillingPeriod(time.Now())
+	records, err := s.usageRepo.FindByUserIDSince(ctx, userID, quota.CountsFrom(periodStart))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &UsageSummary{
+		Plan:         s.planOf(user),
+		PeriodStart:  periodStart,
+		PeriodEnd:    periodEnd,
+		QuotaMinutes: s.policy.PlanMonthlyMinutes[s.planOf(user)],
+	}
+
+	if quota.MonthlyMinutes != nil {
+		summary.QuotaMinutes = *quota.MonthlyMinutes
+	}
+
+	for _, record := range records {
+		summary.AudioSeconds += record.AudioSeconds
+		summary.EstimatedCost += record.EstimatedCost
+		switch record.Operation {
+		case entities.UsageOperationSummary:
+			summary.Summaries++
+		case entities.UsageOperationTranslation:
+			summary.Translations++
+		default:
+			summary.Transcriptions++
+		}
+	}
+
+	return summary, nil
+}
+
+// CheckQuota returns ErrQuotaExceeded when the user has no minutes left in
+// the current period
+func (s *UsageService) CheckQuota(ctx context.Context, userID uuid.UUID) error {
+	summary, err := s.GetCurrentUsage(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if summary.IsExceeded() {
+		return ErrQuotaExceeded
+	}
+
+	return nil
+}
+
+// RecordTranscription adds a ledger entry for audio sent to the provider
+func (s *UsageService) RecordTranscription(ctx context.Context, transcription *entities.Transcription) error {
+	record := entities.NewUsageRecord(
+		transcription.UserID,
+		transcription.ID,
+		transcription.Duration,
+		s.policy.Provider,
+		s.policy.CostPerMinute,
+	)
+	return s.usageRepo.Create(ctx, record)
+}
+
+// RecordOperation adds a ledger entry for a summary or translation of the
+// transcription made for the user, priced by the transcription's duration
+func (s *UsageService) RecordOperation(ctx context.Context, userID uuid.UUID, transcription *entities.Transcription, operation string) error {
+	record := entities.NewOperationUsageRecord(
+		userID,
+		transcription.ID,
+		operation,
+		transcription.Duration,
+		s.policy.TextProvider,
+		s.policy.TextCostPerMinute,
+	)
+	return s.usageRepo.Create(ctx, record)
+}
+
+// SetUserQuota overrides the plan quota of a user; nil restores the plan default
+func (s *UsageService) SetUserQuota(ctx context.Context, userID uuid.UUID, monthlyMinutes *float64) error {
+	quota, err := s.findQuota(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if monthlyMinutes == nil {
+		quota.ClearMonthlyMinutes()
+	} else if err := quota.SetMonthlyMinutes(*monthlyMinutes); err != nil {
+		return err
+	}
+
+	return s.quotaRepo.Save(ctx, quota)
+}
+
+// ResetUserQuota discards the user's consumption so far in the current period
+func (s *UsageService) ResetUserQuota(ctx context.Context, userID uuid.UUID) error {
+	quota, err := s.findQuota(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	quota.Reset()
+	return s.quotaRepo.Save(ctx, quota)
+}
+
+func (s *UsageService) findQuota(ctx context.Context, userID uuid.UUID) (*entities.UsageQuota, error) {
+	quota, err := s.quotaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return entities.NewUsageQuota(userID), nil
+	}
+	return quota, nil
+}
+
+// planOf falls back to the free plan for users without a known plan
+func (s *UsageService) planOf(user *entities.User) string {
+	if _, ok := s.policy.PlanMonthlyMinutes[user.Plan]; ok {
+		return user.Plan
+	}
+	return entities.PlanFree
+}
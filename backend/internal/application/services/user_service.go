@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrReauthenticationRequired = errors.New("confirm with a two-factor code or sign in again through your identity provider")
+)
+
+// reauthenticationWindow is how recent a login through an identity provider
+// must be to stand in for the password of an account that has none
+const reauthenticationWindow = 5 * time.Minute
+
+// UserService manages the profile of the authenticated user
+type UserService struct {
+	userRepo             repositories.UserRepository
+	transcriptionService *TranscriptionService
+	accountService       *AccountService
+	mfa                  *MFAService
+	sessions             *SessionService
+	outbox               *outboxWriter
+}
+
+func NewUserService(userRepo repositories.UserRepository, transcriptionService *TranscriptionService) *UserService {
+	return &UserService{
+		userRepo:             userRepo,
+		transcriptionService: transcriptionService,
+	}
+}
+
+// WithAccountService emails a verification link when a user changes their
+// email address
+func (s *UserService) WithAccountService(accountService *AccountService) *UserService {
+	s.accountService = accountService
+	return s
+}
+
+// WithMFA accepts a two-factor code in place of the password of accounts
+// that have none
+func (s *UserService) WithMFA(mfa *MFAService) *UserService {
+	s.mfa = mfa
+	return s
+}
+
+// WithSessions accepts a fresh login through an identity provider in place
+// of the password of accounts that have none, and logs out the user's other
+// devices when the password changes
+func (s *UserService) WithSessions(sessions *SessionService) *UserService {
+	s.sessions = sessions
+	return s
+}
+
+// WithOutbox records the domain events emitted by users in the outbox,
+// atomically with the state change that produced them
+func (s *UserService) WithOutbox(transactor repositories.Transactor, outboxRepo repositories.OutboxRepository) *UserService {
+	s.outbox = &outboxWriter{transactor: transactor, outboxRepo: outboxRepo}
+	return s
+}
+
+func (s *UserService) GetProfile(ctx context.Context, userID uuid.UUID) (*entities.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+type UpdateProfileInput struct {
+	UserID uuid.UUID
+	Name   *string
+	Email  *string
+}
+
+// UpdateProfile changes the name and email address. A new address is marked
+// unverified and sent a verification link.
+func (s *UserService) UpdateProfile(ctx context.Context, input UpdateProfileInput) (*entities.User, error) {
+	user, err := s.GetProfile(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Email != nil && *input.Email != user.Email {
+		if existing, err := s.userRepo.FindByEmail(ctx, *input.Email); err == nil && existing.ID != user.ID {
+			return nil, ErrUserAlreadyExists
+		}
+	}
+
+	// The repository hands out the stored user, so the changes are made on a
+	// copy that only replaces it once both fields are valid and saved
+	updated := *user
+	if input.Name != nil {
+		if err := updated.UpdateName(*input.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	emailChanged := false
+	if input.Email != nil && *input.Email != user.Email {
+		if err := updated.ChangeEmail(*input.Email); err != nil {
+			return nil, err
+		}
+		emailChanged = true
+	}
+
+	if err := s.save(ctx, &updated); err != nil {
+		return nil, err
+	}
+	user = &updated
+
+	if emailChanged && s.accountService != nil {
+		if err := s.accountService.RequestEmailVerification(ctx, user.ID); err != nil {
+			log.Printf("failed to send verification email to user %s: %v", user.ID, err)
+		}
+	}
+
+	return user, nil
+}
+
+// ChangePasswordInput confirms a password change with the current password.
+// Accounts without one confirm with MFACode or a login through their
+// identity provider in the last few minutes, made with SessionID.
+type ChangePasswordInput struct {
+	UserID          uuid.UUID
+	SessionID       uuid.UUID
+	CurrentPassword string
+	MFACode         string
+	NewPassword     string
+}
+
+// ChangePassword replaces the password and revokes every session but the
+// one making the change
+func (s *UserService) ChangePassword(ctx context.Context, input ChangePasswordInput) error {
+	user, err := s.GetProfile(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reauthenticate(ctx, user, input.CurrentPassword, input.MFACode, input.SessionID); err != nil {
+		return err
+	}
+
+	if err := user.UpdatePassword(input.NewPassword); err != nil {
+		return err
+	}
+
+	if err := s.save(ctx, user); err != nil {
+		return err
+	}
+
+	// Whoever else knew the old password is logged out
+	if s.sessions != nil {
+		return s.sessions.RevokeOtherSessions(ctx, user.ID, input.SessionID)
+	}
+	return nil
+}
+
+// DeleteAccountInput confirms the deletion like ChangePasswordInput
+type DeleteAccountInput struct {
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+	Password  string
+	MFACode   string
+}
+
+// DeleteAccount removes the user's transcriptions and audio, then the user.
+// Other data held for the user is erased by subscribers of UserDeleted.
+func (s *UserService) DeleteAccount(ctx context.Context, input DeleteAccountInput) error {
+	user, err := s.GetProfile(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reauthenticate(ctx, user, input.Password, input.MFACode, input.SessionID); err != nil {
+		return err
+	}
+
+	if err := s.transcriptionService.DeleteUserTranscriptions(ctx, user.ID); err != nil {
+		return err
+	}
+
+	user.Delete()
+	return s.outbox.save(ctx, user, func(ctx context.Context) error {
+		return s.userRepo.Delete(ctx, user.ID)
+	})
+}
+
+// reauthenticate confirms a sensitive change with the user's password, or
+// for accounts without one, with a two-factor code or a fresh login
+func (s *UserService) reauthenticate(ctx context.Context, user *entities.User, password, mfaCode string, sessionID uuid.UUID) error {
+	if user.HasPassword() {
+		if !user.VerifyPassword(password) {
+			return ErrInvalidCredentials
+		}
+		return nil
+	}
+
+	if mfaCode != "" && s.mfa != nil && s.mfa.IsEnabled(ctx, user.ID) {
+		if err := s.mfa.Verify(ctx, user.ID, mfaCode); err != nil {
+			return ErrInvalidCredentials
+		}
+		return nil
+	}
+
+	if sessionID != uuid.Nil && s.sessions != nil && s.sessions.IsRecent(ctx, sessionID, user.ID, reauthenticationWindow) {
+		return nil
+	}
+
+	return ErrReauthenticationRequired
+}
+
+func (s *UserService) save(ctx context.Context, user *entities.User) error {
+	return s.outbox.save(ctx, user, func(ctx context.Context) error {
+		return s.userRepo.Update(ctx, user)
+	})
+}
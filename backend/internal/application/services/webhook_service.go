@@ -0,0 +1,424 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrUnauthorizedWebhook     = errors.New("unauthorized access to webhook")
+)
+
+const (
+	defaultWebhookMaxAttempts = 6
+	defaultWebhookRetryDelay  = 30 * time.Second
+	maxWebhookRetryDelay      = time.Hour
+	webhookBatchSize          = 50
+)
+
+// IWebhookSender performs the HTTP request for a single delivery attempt
+type IWebhookSender interface {
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
+
+type WebhookService struct {
+	webhookRepo    repositories.WebhookRepository
+	deliveryRepo   repositories.WebhookDeliveryRepository
+	sender         IWebhookSender
+	maxAttempts    int
+	retryBaseDelay time.Duration
+	// allowPrivateNetworks permits webhook URLs on loopback, link-local and
+	// private addresses, for local development
+	allowPrivateNetworks bool
+}
+
+func NewWebhookService(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	sender IWebhookSender,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepo:    webhookRepo,
+		deliveryRepo:   deliveryRepo,
+		sender:         sender,
+		maxAttempts:    defaultWebhookMaxAttempts,
+		retryBaseDelay: defaultWebhookRetryDelay,
+	}
+}
+
+// WithRetryPolicy overrides the number of attempts per delivery and the base
+// delay of the exponential backoff between them
+func (s *WebhookService) WithRetryPolicy(maxAttempts int, baseDelay time.Duration) *WebhookService {
+	s.maxAttempts = maxAttempts
+	s.retryBaseDelay = baseDelay
+	return s
+}
+
+// AllowPrivateNetworks permits webhooks on loopback, link-local and private
+// addresses. Only for local development: such webhooks let users make the
+// server send requests into its own network.
+func (s *WebhookService) AllowPrivateNetworks() *WebhookService {
+	s.allowPrivateNetworks = true
+	return s
+}
+
+type CreateWebhookInput struct {
+	UserID uuid.UUID
+	URL    string
+	Events []string
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, input CreateWebhookInput) (*entities.Webhook, error) {
+	webhook, err := entities.NewWebhook(input.UserID, input.URL, input.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAddress(ctx, webhook.URL); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.Webhook, error) {
+	webhook, err := s.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	if !webhook.BelongsToUser(userID) {
+		return nil, ErrUnauthorizedWebhook
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) GetUserWebhooks(ctx context.Context, userID uuid.UUID) ([]*entities.Webhook, error) {
+	return s.webhookRepo.FindByUserID(ctx, userID)
+}
+
+type UpdateWebhookInput struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	URL    *string
+	Events []string
+	Active *bool
+}
+
+func (s *WebhookService) UpdateWebhook(ctx context.Context, input UpdateWebhookInput) (*entities.Webhook, error) {
+	webhook, err := s.GetWebhook(ctx, input.ID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		if err := s.checkAddress(ctx, *input.URL); err != nil {
+			return nil, err
+		}
+
+		if err := webhook.UpdateURL(*input.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Events != nil {
+		if err := webhook.UpdateEvents(input.Events); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Active != nil {
+		webhook.SetActive(*input.Active)
+	}
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.GetWebhook(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := s.deliveryRepo.DeleteByWebhookID(ctx, id); err != nil {
+		return err
+	}
+
+	return s.webhookRepo.Delete(ctx, id)
+}
+
+// DeleteUserWebhooks removes every webhook of the user and its delivery log
+func (s *WebhookService) DeleteUserWebhooks(ctx context.Context, userID uuid.UUID) error {
+	webhooks, err := s.webhookRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if err := s.deliveryRepo.DeleteByWebhookID(ctx, webhook.ID); err != nil {
+			return err
+		}
+
+		if err := s.webhookRepo.Delete(ctx, webhook.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, webhookID uuid.UUID, userID uuid.UUID) ([]*entities.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(ctx, webhookID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.deliveryRepo.FindByWebhookID(ctx, webhookID)
+}
+
+// Redeliver queues a new delivery of a previously sent event
+func (s *WebhookService) Redeliver(ctx context.Context, webhookID, deliveryID, userID uuid.UUID) (*entities.WebhookDelivery, error) {
+	webhook, err := s.GetWebhook(ctx, webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.deliveryRepo.FindByID(ctx, deliveryID)
+	if err != nil || delivery.WebhookID != webhook.ID {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	redelivery := delivery.Redeliver(webhook)
+	if err := s.deliveryRepo.Create(ctx, redelivery); err != nil {
+		return nil, err
+	}
+
+	return redelivery, nil
+}
+
+// webhookEnvelope is the JSON body POSTed to webhook endpoints
+type webhookEnvelope struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+type webhookTranscriptionData struct {
+	ID       string  `json:"id"`
+	UserID   string  `json:"user_id"`
+	Text     string  `json:"text,omitempty"`
+	Status   string  `json:"status"`
+	Duration float64 `json:"duration"`
+}
+
+// Subscribe queues webhook deliveries for the domain events webhooks can
+// subscribe to, and removes the webhooks of deleted users
+func (s *WebhookService) Subscribe(bus *EventBus) {
+	bus.Subscribe(entities.EventTranscriptionCompleted, func(ctx context.Context, event entities.DomainEvent) error {
+		completed := event.(*entities.TranscriptionCompleted)
+		return s.enqueue(ctx, completed.UserID, event, webhookTranscriptionData{
+			ID:       completed.TranscriptionID.String(),
+			UserID:   completed.UserID.String(),
+			Text:     completed.Text,
+			Status:   string(entities.StatusCompleted),
+			Duration: completed.Duration,
+		})
+	})
+
+	bus.Subscribe(entities.EventTranscriptionFailed, func(ctx context.Context, event entities.DomainEvent) error {
+		failed := event.(*entities.TranscriptionFailed)
+		return s.enqueue(ctx, failed.UserID, event, webhookTranscriptionData{
+			ID:     failed.TranscriptionID.String(),
+			UserID: failed.UserID.String(),
+			Status: string(entities.StatusFailed),
+		})
+	})
+
+	bus.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+		return s.DeleteUserWebhooks(ctx, event.(*entities.UserDeleted).UserID)
+	})
+}
+
+// enqueue queues a delivery of the event to every active webhook of the user
+// subscribed to it. Webhooks that already have a delivery for the event are
+// skipped, so redispatching an event does not duplicate deliveries.
+func (s *WebhookService) enqueue(ctx context.Context, userID uuid.UUID, event entities.DomainEvent, data interface{}) error {
+	webhooks, err := s.webhookRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(webhookEnvelope{
+		ID:        event.EventID().String(),
+		Type:      event.EventName(),
+		CreatedAt: event.OccurredAt(),
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.deliveryRepo.FindByEventID(ctx, event.EventID())
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.IsSubscribedTo(event.EventName()) || hasDeliveryFor(existing, webhook.ID) {
+			continue
+		}
+
+		delivery := entities.NewWebhookDelivery(webhook, event.EventID(), event.EventName(), payload, s.maxAttempts)
+		if err := s.deliveryRepo.Create(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasDeliveryFor(deliveries []*entities.WebhookDelivery, webhookID uuid.UUID) bool {
+	for _, delivery := range deliveries {
+		if delivery.WebhookID == webhookID {
+			return true
+		}
+	}
+	return false
+}
+
+// ProcessDueDeliveries attempts every delivery whose next attempt is due and
+// returns the number of attempts made
+func (s *WebhookService) ProcessDueDeliveries(ctx context.Context) (int, error) {
+	due, err := s.deliveryRepo.FindDue(ctx, time.Now(), webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range due {
+		if err := s.attempt(ctx, delivery); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(due), nil
+}
+
+// Run processes due deliveries every interval until the context is cancelled
+func (s *WebhookService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ProcessDueDeliveries(ctx); err != nil {
+				log.Printf("webhook worker: %v", err)
+			}
+		}
+	}
+}
+
+func (s *WebhookService) attempt(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	webhook, err := s.webhookRepo.FindByID(ctx, delivery.WebhookID)
+	if err != nil {
+		delivery.Abandon("webhook no longer exists")
+		return s.deliveryRepo.Update(ctx, delivery)
+	}
+
+	timestamp := time.Now().Unix()
+	headers := map[string]string{
+		"Content-Type":          "application/json",
+		"User-Agent":            "Voiceline-Webhooks/1.0",
+		"X-Voiceline-Event":     delivery.EventType,
+		"X-Voiceline-Delivery":  delivery.ID.String(),
+		"X-Voiceline-Timestamp": strconv.FormatInt(timestamp, 10),
+		"X-Voiceline-Signature": "sha256=" + webhook.Sign(timestamp, delivery.Payload),
+	}
+
+	status, err := s.sender.Send(ctx, webhook.URL, headers, delivery.Payload)
+	if err == nil && status >= 200 && status < 300 {
+		delivery.RecordSuccess(status)
+	} else {
+		errMsg := fmt.Sprintf("unexpected response status %d", status)
+		if err != nil {
+			errMsg = err.Error()
+		}
+		delivery.RecordFailure(status, errMsg, s.retryDelay(delivery.Attempts+1))
+	}
+
+	return s.deliveryRepo.Update(ctx, delivery)
+}
+
+// checkAddress rejects webhook URLs whose host is, or resolves to, an address
+// that is not public. Hosts that do not resolve yet are accepted; the sender
+// checks the address again on every connection, which also covers DNS
+// records that change after registration.
+func (s *WebhookService) checkAddress(ctx context.Context, rawURL string) error {
+	if s.allowPrivateNetworks {
+		return nil
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return entities.ErrInvalidWebhookURL
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return entities.ErrWebhookAddressNotAllowed
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if !entities.IsPublicIP(ip) {
+			return entities.ErrWebhookAddressNotAllowed
+		}
+		return nil
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, address := range addresses {
+		if !entities.IsPublicIP(address.IP) {
+			return entities.ErrWebhookAddressNotAllowed
+		}
+	}
+	return nil
+}
+
+// retryDelay doubles the base delay with every attempt, capped at one hour
+func (s *WebhookService) retryDelay(attempt int) time.Duration {
+	delay := s.retryBaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxWebhookRetryDelay {
+			return maxWebhookRetryDelay
+		}
+	}
+	return delay
+}
@@ -0,0 +1,163 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// API key scopes
+const (
+	ScopeTranscriptionsRead  = "transcriptions:read"
+	ScopeTranscriptionsWrite = "transcriptions:write"
+	ScopeWebhooksRead        = "webhooks:read"
+	ScopeWebhooksWrite       = "webhooks:write"
+	ScopeUsageRead           = "usage:read"
+)
+
+// APIKeyScopes lists the scopes an API key can be granted
+var APIKeyScopes = []string{
+	ScopeTranscriptionsRead,
+	ScopeTranscriptionsWrite,
+	ScopeWebhooksRead,
+	ScopeWebhooksWrite,
+	ScopeUsageRead,
+}
+
+const (
+	apiKeySecretPrefix  = "vl_"
+	apiKeyPrefixLength  = len(apiKeySecretPrefix) + 8
+	maxAPIKeyNameLength = 100
+)
+
+var (
+	ErrInvalidAPIKeyName   = errors.New("api key name must be between 1 and 100 characters")
+	ErrInvalidAPIKeyScope  = errors.New("unknown api key scope")
+	ErrNoAPIKeyScopes      = errors.New("api key must have at least one scope")
+	ErrInvalidAPIKeyExpiry = errors.New("api key expiry must be in the future")
+)
+
+// APIKey authenticates scripts and integrations on behalf of a user. Only the
+// SHA-256 hash of the key is stored; Prefix is kept to help users tell their
+// keys apart.
+type APIKey struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// NewAPIKey creates a key and returns it with the plaintext secret, which is
+// shown to the user once
+func NewAPIKey(userID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxAPIKeyNameLength {
+		return nil, "", ErrInvalidAPIKeyName
+	}
+
+	if err := validateAPIKeyScopes(scopes); err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", ErrInvalidAPIKeyExpiry
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	secret := apiKeySecretPrefix + hex.EncodeToString(buf)
+
+	return &APIKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    secret[:apiKeyPrefixLength],
+		KeyHash:   HashAPIKey(secret),
+		Scopes:    dedupeStrings(scopes),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}, secret, nil
+}
+
+// HashAPIKey returns the lookup hash of a plaintext API key
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive reports whether the key is neither revoked nor expired at now
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+func (k *APIKey) Revoke() {
+	if k.RevokedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	k.RevokedAt = &now
+}
+
+// MarkUsed records a use of the key. It reports whether LastUsedAt changed,
+// which only happens once per interval to avoid a write on every request.
+func (k *APIKey) MarkUsed(now time.Time, interval time.Duration) bool {
+	if k.LastUsedAt != nil && now.Sub(*k.LastUsedAt) < interval {
+		return false
+	}
+
+	k.LastUsedAt = &now
+	return true
+}
+
+func (k *APIKey) BelongsToUser(userID uuid.UUID) bool {
+	return k.UserID == userID
+}
+
+func validateAPIKeyScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return ErrNoAPIKeyScopes
+	}
+
+	for _, scope := range scopes {
+		if !isKnownAPIKeyScope(scope) {
+			return ErrInvalidAPIKeyScope
+		}
+	}
+	return nil
+}
+
+func isKnownAPIKeyScope(scope string) bool {
+	for _, known := range APIKeyScopes {
+		if known == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit actions recorded for privileged operations
+const (
+	AuditUserViewed           = "user.viewed"
+	AuditUserRoleChanged      = "user.role_changed"
+	AuditUserDisabled         = "user.disabled"
+	AuditUserEnabled          = "user.enabled"
+	AuditQuotaReset           = "usage.quota_reset"
+	AuditTranscriptionViewed  = "transcription.viewed"
+	AuditTranscriptionsListed = "transcriptions.listed"
+)
+
+// Audit target types
+const (
+	AuditTargetUser          = "user"
+	AuditTargetTranscription = "transcription"
+)
+
+// AuditLogEntry records an action a staff member took on someone else's data
+type AuditLogEntry struct {
+	ID         uuid.UUID
+	ActorID    uuid.UUID
+	ActorRole  string
+	Action     string
+	TargetType string
+	TargetID   uuid.UUID
+	Details    map[string]string
+	IP         string
+	CreatedAt  time.Time
+}
+
+func NewAuditLogEntry(actorID uuid.UUID, actorRole, action, targetType string, targetID uuid.UUID) *AuditLogEntry {
+	return &AuditLogEntry{
+		ID:         uuid.New(),
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    map[string]string{},
+		CreatedAt:  time.Now(),
+	}
+}
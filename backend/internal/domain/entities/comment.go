@@ -0,0 +1,255 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxCommentBodyLength   = 5000
+	maxHighlightNoteLength = 1000
+)
+
+// DefaultHighlightColor is used for highlights created without a color
+const DefaultHighlightColor = "yellow"
+
+var highlightColors = map[string]bool{
+	"yellow": true,
+	"green":  true,
+	"blue":   true,
+	"pink":   true,
+	"orange": true,
+}
+
+var (
+	ErrInvalidCommentBody    = errors.New("comment must be between 1 and 5000 characters")
+	ErrInvalidAnchor         = errors.New("anchor must lie within the transcription")
+	ErrAnchorRequired        = errors.New("highlight needs a time range or text span")
+	ErrReplyAnchored         = errors.New("replies cannot be anchored")
+	ErrResolveReply          = errors.New("only top-level comments can be resolved")
+	ErrInvalidHighlightNote  = errors.New("highlight note must be at most 1000 characters")
+	ErrInvalidHighlightColor = errors.New("highlight color must be yellow, green, blue, pink or orange")
+)
+
+// Anchor pins a comment or highlight to part of a transcription: a time
+// range of the audio in seconds, a span of the text in characters, or both.
+// Quote keeps the spanned text so the anchor stays readable after edits.
+type Anchor struct {
+	StartTime *float64
+	EndTime   *float64
+	TextStart *int
+	TextEnd   *int
+	Quote     string
+}
+
+// NewAnchor validates the ranges against the transcription and captures the
+// quoted text. It returns nil when neither range is given.
+func NewAnchor(transcription *Transcription, startTime, endTime *float64, textStart, textEnd *int) (*Anchor, error) {
+	if startTime == nil && endTime == nil && textStart == nil && textEnd == nil {
+		return nil, nil
+	}
+
+	anchor := &Anchor{}
+
+	if startTime != nil || endTime != nil {
+		if startTime == nil || endTime == nil || *startTime < 0 || *endTime <= *startTime {
+			return nil, ErrInvalidAnchor
+		}
+		if transcription.Duration > 0 && *endTime > transcription.Duration {
+			return nil, ErrInvalidAnchor
+		}
+		anchor.StartTime, anchor.EndTime = startTime, endTime
+	}
+
+	if textStart != nil || textEnd != nil {
+		text := []rune(transcription.Text)
+		if textStart == nil || textEnd == nil || *textStart < 0 || *textEnd <= *textStart || *textEnd > len(text) {
+			return nil, ErrInvalidAnchor
+		}
+		anchor.TextStart, anchor.TextEnd = textStart, textEnd
+		anchor.Quote = string(text[*textStart:*textEnd])
+	}
+
+	return anchor, nil
+}
+
+// Comment is a remark on a transcription. Top-level comments start a thread
+// and may be anchored and resolved; replies belong to a thread.
+type Comment struct {
+	ID              uuid.UUID
+	TranscriptionID uuid.UUID
+	// OwnerID is the owner of the transcription, whose account deletion
+	// removes the comments on it
+	OwnerID    uuid.UUID
+	UserID     uuid.UUID
+	ParentID   *uuid.UUID
+	Body       string
+	Anchor     *Anchor
+	ResolvedAt *time.Time
+	ResolvedBy *uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewComment starts a thread on the transcription
+func NewComment(transcription *Transcription, userID uuid.UUID, body string, anchor *Anchor) (*Comment, error) {
+	body, err := validateCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Comment{
+		ID:              uuid.New(),
+		TranscriptionID: transcription.ID,
+		OwnerID:         transcription.UserID,
+		UserID:          userID,
+		Body:            body,
+		Anchor:          anchor,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// NewReply answers the thread started by parent. Replies to replies join
+// the same thread.
+func NewReply(parent *Comment, userID uuid.UUID, body string) (*Comment, error) {
+	body, err := validateCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	threadID := parent.ThreadID()
+	now := time.Now()
+	return &Comment{
+		ID:              uuid.New(),
+		TranscriptionID: parent.TranscriptionID,
+		OwnerID:         parent.OwnerID,
+		UserID:          userID,
+		ParentID:        &threadID,
+		Body:            body,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// ThreadID returns the ID of the top-level comment of the thread
+func (c *Comment) ThreadID() uuid.UUID {
+	if c.ParentID != nil {
+		return *c.ParentID
+	}
+	return c.ID
+}
+
+func (c *Comment) IsReply() bool {
+	return c.ParentID != nil
+}
+
+func (c *Comment) IsResolved() bool {
+	return c.ResolvedAt != nil
+}
+
+func (c *Comment) EditBody(body string) error {
+	body, err := validateCommentBody(body)
+	if err != nil {
+		return err
+	}
+
+	c.Body = body
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// Resolve marks the thread as settled by userID
+func (c *Comment) Resolve(userID uuid.UUID) error {
+	if c.IsReply() {
+		return ErrResolveReply
+	}
+
+	if c.IsResolved() {
+		return nil
+	}
+
+	now := time.Now()
+	c.ResolvedAt = &now
+	c.ResolvedBy = &userID
+	c.UpdatedAt = now
+	return nil
+}
+
+// Unresolve reopens a resolved thread
+func (c *Comment) Unresolve() error {
+	if c.IsReply() {
+		return ErrResolveReply
+	}
+
+	c.ResolvedAt = nil
+	c.ResolvedBy = nil
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// CanDelete reports whether the user wrote the comment or owns the transcription
+func (c *Comment) CanDelete(userID uuid.UUID) bool {
+	return c.UserID == userID || c.OwnerID == userID
+}
+
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > maxCommentBodyLength {
+		return "", ErrInvalidCommentBody
+	}
+	return body, nil
+}
+
+// Highlight marks an anchored part of a transcription for everyone who can
+// read it
+type Highlight struct {
+	ID              uuid.UUID
+	TranscriptionID uuid.UUID
+	OwnerID         uuid.UUID
+	UserID          uuid.UUID
+	Anchor          *Anchor
+	Color           string
+	Note            string
+	CreatedAt       time.Time
+}
+
+func NewHighlight(transcription *Transcription, userID uuid.UUID, anchor *Anchor, color, note string) (*Highlight, error) {
+	if anchor == nil {
+		return nil, ErrAnchorRequired
+	}
+
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxHighlightNoteLength {
+		return nil, ErrInvalidHighlightNote
+	}
+
+	if color == "" {
+		color = DefaultHighlightColor
+	}
+
+	if !highlightColors[color] {
+		return nil, ErrInvalidHighlightColor
+	}
+
+	return &Highlight{
+		ID:              uuid.New(),
+		TranscriptionID: transcription.ID,
+		OwnerID:         transcription.UserID,
+		UserID:          userID,
+		Anchor:          anchor,
+		Color:           color,
+		Note:            note,
+		CreatedAt:       time.Now(),
+	}, nil
+}
+
+// CanDelete reports whether the user created the highlight or owns the transcription
+func (h *Highlight) CanDelete(userID uuid.UUID) bool {
+	return h.UserID == userID || h.OwnerID == userID
+}
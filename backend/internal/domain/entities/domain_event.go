@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventUserRegistered         = "user.registered"
+	EventUserDeleted            = "user.deleted"
+	EventTranscriptionCreated   = "transcription.created"
+	EventTranscriptionCompleted = "transcription.completed"
+	EventTranscriptionFailed    = "transcription.failed"
+	EventTranscriptionEdited    = "transcription.edited"
+)
+
+var ErrUnknownDomainEvent = errors.New("unknown domain event")
+
+// DomainEvent is a fact recorded by an entity when its state changes
+type DomainEvent interface {
+	EventID() uuid.UUID
+	EventName() string
+	OccurredAt() time.Time
+}
+
+// EventMeta carries the identity and timestamp shared by all domain events
+type EventMeta struct {
+	ID uuid.UUID `json:"id"`
+	At time.Time `json:"occurred_at"`
+}
+
+func newEventMeta() EventMeta {
+	return EventMeta{
+		ID: uuid.New(),
+		At: time.Now().UTC(),
+	}
+}
+
+func (m EventMeta) EventID() uuid.UUID {
+	return m.ID
+}
+
+func (m EventMeta) OccurredAt() time.Time {
+	return m.At
+}
+
+type UserRegistered struct {
+	EventMeta
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+	Name   string    `json:"name"`
+}
+
+func (UserRegistered) EventName() string {
+	return EventUserRegistered
+}
+
+type UserDeleted struct {
+	EventMeta
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (UserDeleted) EventName() string {
+	return EventUserDeleted
+}
+
+type TranscriptionCreated struct {
+	EventMeta
+	TranscriptionID uuid.UUID `json:"transcription_id"`
+	UserID          uuid.UUID `json:"user_id"`
+}
+
+func (TranscriptionCreated) EventName() string {
+	return EventTranscriptionCreated
+}
+
+type TranscriptionCompleted struct {
+	EventMeta
+	TranscriptionID uuid.UUID `json:"transcription_id"`
+	UserID          uuid.UUID `json:"user_id"`
+	Text            string    `json:"text"`
+	Duration        float64   `json:"duration"`
+}
+
+func (TranscriptionCompleted) EventName() string {
+	return EventTranscriptionCompleted
+}
+
+type TranscriptionFailed struct {
+	EventMeta
+	TranscriptionID uuid.UUID `json:"transcription_id"`
+	UserID          uuid.UUID `json:"user_id"`
+}
+
+func (TranscriptionFailed) EventName() string {
+	return EventTranscriptionFailed
+}
+
+// TranscriptionEdited is recorded when someone corrects the text of a
+// completed transcription
+type TranscriptionEdited struct {
+	EventMeta
+	TranscriptionID uuid.UUID `json:"transcription_id"`
+	UserID          uuid.UUID `json:"user_id"`
+	EditedBy        uuid.UUID `json:"edited_by"`
+	Text            string    `json:"text"`
+}
+
+func (TranscriptionEdited) EventName() string {
+	return EventTranscriptionEdited
+}
+
+// DecodeDomainEvent restores a typed event from its name and JSON payload
+func DecodeDomainEvent(name string, payload []byte) (DomainEvent, error) {
+	var event DomainEvent
+	switch name {
+	case EventUserRegistered:
+		event = &UserRegistered{}
+	case EventUserDeleted:
+		event = &UserDeleted{}
+	case EventTranscriptionCreated:
+		event = &TranscriptionCreated{}
+	case EventTranscriptionCompleted:
+		event = &TranscriptionCompleted{}
+	case EventTranscriptionFailed:
+		event = &TranscriptionFailed{}
+	case EventTranscriptionEdited:
+		event = &TranscriptionEdited{}
+	default:
+		return nil, ErrUnknownDomainEvent
+	}
+
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// eventRecorder is embedded by entities that emit domain events
+type eventRecorder struct {
+	events []DomainEvent
+}
+
+func (r *eventRecorder) record(event DomainEvent) {
+	r.events = append(r.events, event)
+}
+
+// PullEvents returns the events recorded since the last call and clears them
+func (r *eventRecorder) PullEvents() []DomainEvent {
+	events := r.events
+	r.events = nil
+	return events
+}
@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExportStatus string
+
+const (
+	ExportPending   ExportStatus = "pending"
+	ExportRunning   ExportStatus = "running"
+	ExportCompleted ExportStatus = "completed"
+	ExportFailed    ExportStatus = "failed"
+)
+
+// ExportJob builds a downloadable archive of everything stored for a user
+type ExportJob struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Status      ExportStatus
+	ArchiveKey  string
+	Size        int64
+	Error       string
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time
+}
+
+func NewExportJob(userID uuid.UUID) *ExportJob {
+	return &ExportJob{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    ExportPending,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (j *ExportJob) Start() {
+	now := time.Now()
+	j.Status = ExportRunning
+	j.StartedAt = &now
+}
+
+// Complete records the stored archive, which is kept until retention elapses
+func (j *ExportJob) Complete(archiveKey string, size int64, retention time.Duration) {
+	now := time.Now()
+	expiresAt := now.Add(retention)
+
+	j.Status = ExportCompleted
+	j.ArchiveKey = archiveKey
+	j.Size = size
+	j.CompletedAt = &now
+	j.ExpiresAt = &expiresAt
+}
+
+func (j *ExportJob) Fail(reason string) {
+	now := time.Now()
+	j.Status = ExportFailed
+	j.Error = reason
+	j.CompletedAt = &now
+}
+
+func (j *ExportJob) IsPending() bool {
+	return j.Status == ExportPending
+}
+
+func (j *ExportJob) IsActive() bool {
+	return j.Status == ExportPending || j.Status == ExportRunning
+}
+
+// IsStale reports whether the job has been running for longer than timeout,
+// which happens when the process building it stopped
+func (j *ExportJob) IsStale(now time.Time, timeout time.Duration) bool {
+	return j.Status == ExportRunning && j.StartedAt != nil && now.Sub(*j.StartedAt) > timeout
+}
+
+func (j *ExportJob) IsCompleted() bool {
+	return j.Status == ExportCompleted
+}
+
+// IsExpired reports whether the archive has passed its retention period
+func (j *ExportJob) IsExpired(now time.Time) bool {
+	return j.ExpiresAt != nil && !now.Before(*j.ExpiresAt)
+}
+
+func (j *ExportJob) BelongsToUser(userID uuid.UUID) bool {
+	return j.UserID == userID
+}
+
+// SignDownload returns the hex encoded HMAC-SHA256 of "<id>.<expires>" that
+// authorizes downloading the archive until the unix time expires
+func (j *ExportJob) SignDownload(secret []byte, expires int64) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(j.ID.String()))
+	mac.Write([]byte("."))
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyDownload checks a download signature in constant time
+func (j *ExportJob) VerifyDownload(secret []byte, expires int64, signature string, now time.Time) bool {
+	if now.Unix() >= expires {
+		return false
+	}
+	return hmac.Equal([]byte(j.SignDownload(secret, expires)), []byte(signature))
+}
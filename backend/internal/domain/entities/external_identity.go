@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdentityClaims are the verified claims an external identity provider
+// asserts about the user who signed in
+type IdentityClaims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Nonce         string
+}
+
+// ExternalIdentity links an account at an external identity provider to a
+// local user. The provider's issuer and subject identify the account; the
+// email may change at the provider and is only kept for reference.
+type ExternalIdentity struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Provider    string
+	Issuer      string
+	Subject     string
+	Email       string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+func NewExternalIdentity(userID uuid.UUID, provider string, claims *IdentityClaims) *ExternalIdentity {
+	now := time.Now()
+	return &ExternalIdentity{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Provider:    provider,
+		Issuer:      claims.Issuer,
+		Subject:     claims.Subject,
+		Email:       claims.Email,
+		CreatedAt:   now,
+		LastLoginAt: now,
+	}
+}
+
+// RecordLogin notes a sign-in with the latest claims from the provider
+func (i *ExternalIdentity) RecordLogin(claims *IdentityClaims) {
+	i.Email = claims.Email
+	i.LastLoginAt = time.Now()
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"time"
+)
+
+type IdempotencyStatus string
+
+const (
+	IdempotencyInProgress IdempotencyStatus = "in_progress"
+	IdempotencyCompleted  IdempotencyStatus = "completed"
+)
+
+// IdempotencyRecord stores the first response to a request made with an
+// Idempotency-Key so that retries of the same request can be replayed
+type IdempotencyRecord struct {
+	Key             string
+	RequestHash     string
+	Status          IdempotencyStatus
+	ResponseStatus  int
+	ResponseHeaders map[string]string
+	ResponseBody    []byte
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+}
+
+func NewIdempotencyRecord(key, requestHash string, ttl time.Duration) *IdempotencyRecord {
+	now := time.Now()
+	return &IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		Status:      IdempotencyInProgress,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+func (r *IdempotencyRecord) Complete(status int, headers map[string]string, body []byte) {
+	r.Status = IdempotencyCompleted
+	r.ResponseStatus = status
+	r.ResponseHeaders = headers
+	r.ResponseBody = body
+}
+
+func (r *IdempotencyRecord) Matches(requestHash string) bool {
+	return r.RequestHash == requestHash
+}
+
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.Status == IdempotencyCompleted
+}
+
+func (r *IdempotencyRecord) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"time"
+)
+
+const maxLoginDelayDoublings = 16
+
+// LoginThrottlePolicy configures how failed logins slow down and lock a subject
+type LoginThrottlePolicy struct {
+	// FreeAttempts is the number of failures allowed before delays apply
+	FreeAttempts int
+	// BaseDelay is the wait after the first failure beyond FreeAttempts; it
+	// doubles with every further failure up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// LockoutThreshold failures within FailureWindow lock the subject for
+	// LockoutDuration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	FailureWindow    time.Duration
+}
+
+// LoginThrottle counts the failed logins of one subject, such as an account
+// or a client IP
+type LoginThrottle struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	NextAttemptAt time.Time
+	LockedUntil   time.Time
+	ExpiresAt     time.Time
+}
+
+func NewLoginThrottle(key string) *LoginThrottle {
+	return &LoginThrottle{Key: key}
+}
+
+// RecordFailure counts a failed attempt, forgetting failures older than the
+// policy window, and applies the resulting delay or lockout
+func (t *LoginThrottle) RecordFailure(policy LoginThrottlePolicy, now time.Time) {
+	if t.Failures > 0 && now.Sub(t.LastFailureAt) > policy.FailureWindow {
+		t.Failures = 0
+	}
+
+	t.Failures++
+	t.LastFailureAt = now
+	t.NextAttemptAt = time.Time{}
+
+	if policy.LockoutThreshold > 0 && t.Failures >= policy.LockoutThreshold {
+		t.Failures = 0
+		t.LockedUntil = now.Add(policy.LockoutDuration)
+	} else if t.Failures > policy.FreeAttempts && policy.BaseDelay > 0 {
+		t.NextAttemptAt = now.Add(progressiveDelay(policy, t.Failures-policy.FreeAttempts-1))
+	}
+
+	t.ExpiresAt = now.Add(policy.FailureWindow)
+	if t.LockedUntil.After(t.ExpiresAt) {
+		t.ExpiresAt = t.LockedUntil
+	}
+}
+
+// RetryAfter returns how long the subject must wait before its next attempt,
+// or zero when it may try now
+func (t *LoginThrottle) RetryAfter(now time.Time) time.Duration {
+	blockedUntil := t.NextAttemptAt
+	if t.LockedUntil.After(blockedUntil) {
+		blockedUntil = t.LockedUntil
+	}
+
+	if !blockedUntil.After(now) {
+		return 0
+	}
+	return blockedUntil.Sub(now)
+}
+
+func (t *LoginThrottle) IsLocked(now time.Time) bool {
+	return t.LockedUntil.After(now)
+}
+
+// IsExpired reports whether the throttle no longer affects the subject
+func (t *LoginThrottle) IsExpired(now time.Time) bool {
+	return !t.ExpiresAt.After(now)
+}
+
+func progressiveDelay(policy LoginThrottlePolicy, doublings int) time.Duration {
+	if doublings > maxLoginDelayDoublings {
+		doublings = maxLoginDelayDoublings
+	}
+
+	delay := policy.BaseDelay << doublings
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	return delay
+}
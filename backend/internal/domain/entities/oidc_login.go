@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+)
+
+// OIDCLoginRequest is a pending sign-in with an external identity provider.
+// State binds the provider's callback to this request, Nonce binds the ID
+// token to it and CodeVerifier is the PKCE secret that proves the code is
+// redeemed by whoever started the login.
+type OIDCLoginRequest struct {
+	State        string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+func NewOIDCLoginRequest(provider string, ttl time.Duration) (*OIDCLoginRequest, error) {
+	state, err := randomURLToken()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomURLToken()
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := randomURLToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &OIDCLoginRequest{
+		State:        state,
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    now.Add(ttl),
+		CreatedAt:    now,
+	}, nil
+}
+
+// CodeChallenge returns the S256 PKCE challenge of the code verifier
+func (r *OIDCLoginRequest) CodeChallenge() string {
+	return PKCEChallenge(r.CodeVerifier)
+}
+
+func (r *OIDCLoginRequest) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// PKCEChallenge derives the S256 code challenge of a PKCE code verifier
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomURLToken returns 32 random bytes encoded for use in URLs, which is
+// also a valid PKCE code verifier
+func randomURLToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
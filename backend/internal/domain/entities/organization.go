@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Roles of organization members, from most to least privileged
+const (
+	OrganizationRoleOwner  = "owner"
+	OrganizationRoleAdmin  = "admin"
+	OrganizationRoleMember = "member"
+)
+
+const maxOrganizationNameLength = 100
+
+var (
+	ErrInvalidOrganizationName = errors.New("organization name must be between 1 and 100 characters")
+	ErrInvalidOrganizationRole = errors.New("invalid organization role")
+)
+
+// Organization is a workspace whose members share transcriptions
+type Organization struct {
+	ID   uuid.UUID
+	Name string
+	// RedactionRequired makes the API return only the redacted text of the
+	// organization's transcriptions
+	RedactionRequired bool
+	CreatedBy         uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func NewOrganization(name string, createdBy uuid.UUID) (*Organization, error) {
+	organization := &Organization{
+		ID:        uuid.New(),
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+
+	if err := organization.Rename(name); err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (o *Organization) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxOrganizationNameLength {
+		return ErrInvalidOrganizationName
+	}
+
+	o.Name = name
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// OrganizationMember is a user's membership in an organization. Every member
+// can create and read the organization's transcriptions; admins manage
+// members and invitations, and owners can also manage other owners.
+type OrganizationMember struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Role           string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewOrganizationMember(organizationID, userID uuid.UUID, role string) (*OrganizationMember, error) {
+	if !IsValidOrganizationRole(role) {
+		return nil, ErrInvalidOrganizationRole
+	}
+
+	now := time.Now()
+	return &OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+func (m *OrganizationMember) ChangeRole(role string) error {
+	if !IsValidOrganizationRole(role) {
+		return ErrInvalidOrganizationRole
+	}
+
+	m.Role = role
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *OrganizationMember) IsOwner() bool {
+	return m.Role == OrganizationRoleOwner
+}
+
+// CanManageMembers reports whether the member may invite, remove and change
+// the role of other members
+func (m *OrganizationMember) CanManageMembers() bool {
+	return m.Role == OrganizationRoleOwner || m.Role == OrganizationRoleAdmin
+}
+
+func IsValidOrganizationRole(role string) bool {
+	switch role {
+	case OrganizationRoleOwner, OrganizationRoleAdmin, OrganizationRoleMember:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvitationExpired  = errors.New("invitation has expired")
+	ErrInvitationAccepted = errors.New("invitation has already been accepted")
+)
+
+// OrganizationInvitation invites an email address to join an organization.
+// The token is emailed to the address and only its SHA-256 hash is stored.
+type OrganizationInvitation struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Email          string
+	Role           string
+	TokenHash      string
+	InvitedBy      uuid.UUID
+	ExpiresAt      time.Time
+	AcceptedAt     *time.Time
+	CreatedAt      time.Time
+}
+
+// NewOrganizationInvitation returns the invitation with the plaintext token
+// to send
+func NewOrganizationInvitation(organizationID uuid.UUID, email, role string, invitedBy uuid.UUID, ttl time.Duration) (*OrganizationInvitation, string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if err := validateEmail(email); err != nil {
+		return nil, "", err
+	}
+
+	if !IsValidOrganizationRole(role) {
+		return nil, "", ErrInvalidOrganizationRole
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	now := time.Now()
+	return &OrganizationInvitation{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		Email:          email,
+		Role:           role,
+		TokenHash:      HashUserToken(secret),
+		InvitedBy:      invitedBy,
+		ExpiresAt:      now.Add(ttl),
+		CreatedAt:      now,
+	}, secret, nil
+}
+
+// Accept consumes the invitation, failing if it was already accepted or has
+// expired
+func (i *OrganizationInvitation) Accept(now time.Time) error {
+	if i.AcceptedAt != nil {
+		return ErrInvitationAccepted
+	}
+
+	if i.IsExpired(now) {
+		return ErrInvitationExpired
+	}
+
+	i.AcceptedAt = &now
+	return nil
+}
+
+func (i *OrganizationInvitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// IsPending reports whether the invitation can still be accepted
+func (i *OrganizationInvitation) IsPending(now time.Time) bool {
+	return i.AcceptedAt == nil && !i.IsExpired(now)
+}
+
+// IsFor reports whether the invitation was sent to email
+func (i *OrganizationInvitation) IsFor(email string) bool {
+	return strings.EqualFold(i.Email, strings.TrimSpace(email))
+}
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage is a domain event persisted together with the state change
+// that produced it, waiting to be dispatched to subscribers
+type OutboxMessage struct {
+	ID            uuid.UUID
+	EventName     string
+	Payload       []byte
+	OccurredAt    time.Time
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	DispatchedAt  *time.Time
+	DiscardedAt   *time.Time
+	CreatedAt     time.Time
+}
+
+func NewOutboxMessage(event DomainEvent) (*OutboxMessage, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &OutboxMessage{
+		ID:            event.EventID(),
+		EventName:     event.EventName(),
+		Payload:       payload,
+		OccurredAt:    event.OccurredAt(),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+func NewOutboxMessages(events []DomainEvent) ([]*OutboxMessage, error) {
+	messages := make([]*OutboxMessage, 0, len(events))
+	for _, event := range events {
+		message, err := NewOutboxMessage(event)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func (m *OutboxMessage) Event() (DomainEvent, error) {
+	return DecodeDomainEvent(m.EventName, m.Payload)
+}
+
+func (m *OutboxMessage) MarkDispatched() {
+	now := time.Now()
+	m.Attempts++
+	m.LastError = ""
+	m.DispatchedAt = &now
+}
+
+// RecordFailure reschedules the message after retryIn, or discards it once
+// maxAttempts have been made
+func (m *OutboxMessage) RecordFailure(errMsg string, retryIn time.Duration, maxAttempts int) {
+	now := time.Now()
+	m.Attempts++
+	m.LastError = errMsg
+
+	if m.Attempts >= maxAttempts {
+		m.DiscardedAt = &now
+		return
+	}
+
+	m.NextAttemptAt = now.Add(retryIn)
+}
+
+func (m *OutboxMessage) IsPending() bool {
+	return m.DispatchedAt == nil && m.DiscardedAt == nil
+}
+
+func (m *OutboxMessage) IsDue(now time.Time) bool {
+	return m.IsPending() && !m.NextAttemptAt.After(now)
+}
@@ -0,0 +1,274 @@
+package entities
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PIIKind names a kind of personal data detected in text
+type PIIKind string
+
+const (
+	PIIEmail      PIIKind = "email"
+	PIIPhone      PIIKind = "phone"
+	PIICardNumber PIIKind = "card_number"
+	PIIIBAN       PIIKind = "iban"
+)
+
+// AllPIIKinds lists every supported kind in detection order. Earlier kinds
+// win where matches overlap, so card numbers and IBANs are not also taken
+// for phone numbers.
+var AllPIIKinds = []PIIKind{PIIEmail, PIIIBAN, PIICardNumber, PIIPhone}
+
+var ErrInvalidPIIKind = errors.New("PII kind must be email, phone, card_number or iban")
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	// IBANs, card numbers and phone numbers are assembled from groups of
+	// characters joined by single separators
+	ibanGroupPattern  = regexp.MustCompile(`\b[A-Z0-9]+\b`)
+	cardGroupPattern  = regexp.MustCompile(`\b\d+\b`)
+	phoneGroupPattern = regexp.MustCompile(`\+?\(?\b\d+\b\)?`)
+	ibanStartPattern  = regexp.MustCompile(`^[A-Z]{2}\d{2}`)
+	digitsPattern     = regexp.MustCompile(`\d+`)
+	datePattern       = regexp.MustCompile(`^(?:\d{4}[-./]\d{1,2}[-./]\d{1,2}|\d{1,2}[-./]\d{1,2}[-./]\d{2,4})$`)
+)
+
+// maxChecksumLength bounds the IBAN and card number candidates, without
+// separators
+const maxChecksumLength = 34
+
+// PIIFinding is a match of personal data in text, as byte offsets
+type PIIFinding struct {
+	Kind  PIIKind
+	Start int
+	End   int
+}
+
+// ParsePIIKinds parses a comma separated list of PII kinds. An empty list
+// selects none.
+func ParsePIIKinds(list string) ([]PIIKind, error) {
+	kinds := make([]PIIKind, 0)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		kind := PIIKind(name)
+		if !isPIIKind(kind) {
+			return nil, ErrInvalidPIIKind
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+// DetectPII finds the personal data of the given kinds in text, ordered by
+// position and without overlaps. Card numbers must pass the Luhn check and
+// IBANs the ISO 13616 checksum. Phone numbers need 7 to 15 digits and the
+// structure of one: a leading +, an area code in parentheses or at least
+// three groups of digits.
+func DetectPII(text string, kinds []PIIKind) []PIIFinding {
+	enabled := make(map[PIIKind]bool, len(kinds))
+	for _, kind := range kinds {
+		enabled[kind] = true
+	}
+
+	findings := make([]PIIFinding, 0)
+	for _, kind := range AllPIIKinds {
+		if !enabled[kind] {
+			continue
+		}
+
+		for _, finding := range detect(text, kind) {
+			if !overlaps(findings, finding) {
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Start < findings[j].Start
+	})
+	return findings
+}
+
+// RedactPII replaces each finding in text with a placeholder naming its
+// kind, such as [EMAIL]
+func RedactPII(text string, findings []PIIFinding) string {
+	var redacted strings.Builder
+	last := 0
+	for _, finding := range findings {
+		redacted.WriteString(text[last:finding.Start])
+		redacted.WriteString("[" + strings.ToUpper(string(finding.Kind)) + "]")
+		last = finding.End
+	}
+	redacted.WriteString(text[last:])
+	return redacted.String()
+}
+
+func detect(text string, kind PIIKind) []PIIFinding {
+	switch kind {
+	case PIIEmail:
+		findings := make([]PIIFinding, 0)
+		for _, match := range emailPattern.FindAllStringIndex(text, -1) {
+			findings = append(findings, PIIFinding{Kind: kind, Start: match[0], End: match[1]})
+		}
+		return findings
+	case PIIIBAN:
+		return detectChecksummed(text, kind, groupRuns(text, ibanGroupPattern, " "), func(candidate string) bool {
+			return ibanStartPattern.MatchString(candidate) && validIBAN(candidate)
+		})
+	case PIICardNumber:
+		return detectChecksummed(text, kind, groupRuns(text, cardGroupPattern, " -"), func(candidate string) bool {
+			return validLuhn(digits(candidate))
+		})
+	default:
+		findings := make([]PIIFinding, 0)
+		for _, run := range groupRuns(text, phoneGroupPattern, " ./-") {
+			finding := PIIFinding{Kind: kind, Start: run[0][0], End: run[len(run)-1][1]}
+			if validPhone(text[finding.Start:finding.End]) {
+				findings = append(findings, finding)
+			}
+		}
+		return findings
+	}
+}
+
+// groupRuns splits the groups of text matched by pattern into runs of groups
+// joined by at most one of the separators. A + starts a new run.
+func groupRuns(text string, pattern *regexp.Regexp, separators string) [][][]int {
+	runs := make([][][]int, 0)
+	var run [][]int
+	for _, group := range pattern.FindAllStringIndex(text, -1) {
+		if len(run) > 0 {
+			gap := text[run[len(run)-1][1]:group[0]]
+			joined := gap == "" || (len(gap) == 1 && strings.Contains(separators, gap))
+			if !joined || text[group[0]] == '+' {
+				runs = append(runs, run)
+				run = nil
+			}
+		}
+		run = append(run, group)
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// detectChecksummed finds the longest stretches of whole groups that pass
+// valid. Every starting group is tried, so a number is still found when the
+// groups around it belong to something else, such as an expiry date after
+// a card number.
+func detectChecksummed(text string, kind PIIKind, runs [][][]int, valid func(string) bool) []PIIFinding {
+	findings := make([]PIIFinding, 0)
+	for _, run := range runs {
+		for i := 0; i < len(run); {
+			end := -1
+			length := 0
+			for j := i; j < len(run); j++ {
+				length += run[j][1] - run[j][0]
+				if length > maxChecksumLength {
+					break
+				}
+				if valid(text[run[i][0]:run[j][1]]) {
+					end = j
+				}
+			}
+
+			if end < 0 {
+				i++
+				continue
+			}
+			findings = append(findings, PIIFinding{Kind: kind, Start: run[i][0], End: run[end][1]})
+			i = end + 1
+		}
+	}
+	return findings
+}
+
+// validPhone reports whether a run of digit groups looks like a phone number
+// rather than a plain number or a date
+func validPhone(match string) bool {
+	count := len(digits(match))
+	if count < 7 || count > 15 || datePattern.MatchString(match) {
+		return false
+	}
+
+	return strings.HasPrefix(match, "+") || strings.Contains(match, "(") ||
+		len(digitsPattern.FindAllString(match, -1)) >= 3
+}
+
+// validLuhn checks the Luhn checksum of a 13 to 19 digit card number
+func validLuhn(number string) bool {
+	if len(number) < 13 || len(number) > 19 {
+		return false
+	}
+
+	sum := 0
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if (len(number)-i)%2 == 0 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// validIBAN checks the mod 97 checksum of an IBAN with optional spaces
+func validIBAN(iban string) bool {
+	iban = strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+
+	var numeric strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			numeric.WriteString(strconv.Itoa(int(r-'A') + 10))
+		} else {
+			numeric.WriteRune(r)
+		}
+	}
+
+	value, ok := new(big.Int).SetString(numeric.String(), 10)
+	return ok && new(big.Int).Mod(value, big.NewInt(97)).Int64() == 1
+}
+
+func digits(text string) string {
+	var result strings.Builder
+	for _, r := range text {
+		if r >= '0' && r <= '9' {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
+
+func overlaps(findings []PIIFinding, finding PIIFinding) bool {
+	for _, other := range findings {
+		if finding.Start < other.End && other.Start < finding.End {
+			return true
+		}
+	}
+	return false
+}
+
+func isPIIKind(kind PIIKind) bool {
+	for _, known := range AllPIIKinds {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRateLimitPolicy = errors.New("rate limit must be formatted as <requests>/<window>, e.g. 10/1m")
+)
+
+// RateLimitPolicy allows Limit requests per Window, refilled continuously
+type RateLimitPolicy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// ParseRateLimitPolicy parses specs such as "10/1m" or "300/1h"
+func ParseRateLimitPolicy(name, spec string) (RateLimitPolicy, error) {
+	parts := strings.SplitN(spec, "/", 2)
+	if len(parts) != 2 {
+		return RateLimitPolicy{}, ErrInvalidRateLimitPolicy
+	}
+
+	limit, err := strconv.Atoi(parts[0])
+	if err != nil || limit < 1 {
+		return RateLimitPolicy{}, ErrInvalidRateLimitPolicy
+	}
+
+	window, err := time.ParseDuration(parts[1])
+	if err != nil || window <= 0 {
+		return RateLimitPolicy{}, ErrInvalidRateLimitPolicy
+	}
+
+	return RateLimitPolicy{Name: name, Limit: limit, Window: window}, nil
+}
+
+func (p RateLimitPolicy) tokensPerSecond() float64 {
+	return float64(p.Limit) / p.Window.Seconds()
+}
+
+// RateLimitDecision is the outcome of taking a token from a bucket
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// TokenBucket holds the tokens left for one rate limit key
+type TokenBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+func NewTokenBucket(policy RateLimitPolicy, now time.Time) *TokenBucket {
+	return &TokenBucket{
+		Tokens:    float64(policy.Limit),
+		UpdatedAt: now,
+	}
+}
+
+// Take refills the bucket for the time elapsed since the last call and
+// consumes one token if available
+func (b *TokenBucket) Take(policy RateLimitPolicy, now time.Time) RateLimitDecision {
+	b.refill(policy, now)
+
+	decision := RateLimitDecision{Limit: policy.Limit}
+	rate := policy.tokensPerSecond()
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - b.Tokens) / rate)
+	}
+
+	decision.Remaining = int(math.Floor(b.Tokens))
+	decision.ResetAfter = secondsToDuration((float64(policy.Limit) - b.Tokens) / rate)
+	return decision
+}
+
+// IsFull reports whether the bucket would be full at now, meaning it carries
+// no state worth keeping
+func (b *TokenBucket) IsFull(policy RateLimitPolicy, now time.Time) bool {
+	elapsed := now.Sub(b.UpdatedAt).Seconds()
+	return b.Tokens+elapsed*policy.tokensPerSecond() >= float64(policy.Limit)
+}
+
+func (b *TokenBucket) refill(policy RateLimitPolicy, now time.Time) {
+	elapsed := now.Sub(b.UpdatedAt).Seconds()
+	if elapsed > 0 {
+		b.Tokens = math.Min(float64(policy.Limit), b.Tokens+elapsed*policy.tokensPerSecond())
+		b.UpdatedAt = now
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
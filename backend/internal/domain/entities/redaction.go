@@ -0,0 +1,116 @@
+package entities
+
+import "time"
+
+// Redaction is a version of a transcription's text and segments with the
+// detected personal data replaced by placeholders
+type Redaction struct {
+	Text     string
+	Segments []Segment
+	// Findings locate the personal data in the original text
+	Findings []PIIFinding
+	// SourceHash identifies the original text that was redacted
+	SourceHash string
+	RedactedAt time.Time
+}
+
+// Redact detects personal data of the given kinds in the original text and
+// segments and stores their redacted version. When redaction is required
+// the redacted version replaces them, also in the events recorded since the
+// last pull, so the originals never leave the transcription. Earlier
+// revisions are redacted for good.
+func (t *Transcription) Redact(kinds []PIIKind) {
+	if !t.IsCompleted() {
+		return
+	}
+
+	text, segments := t.unredacted()
+
+	redaction := &Redaction{
+		Findings:   DetectPII(text, kinds),
+		SourceHash: hashText(text),
+		RedactedAt: time.Now(),
+	}
+	redaction.Text = RedactPII(text, redaction.Findings)
+	for _, segment := range segments {
+		segment.Text = RedactPII(segment.Text, DetectPII(segment.Text, kinds))
+		redaction.Segments = append(redaction.Segments, segment)
+	}
+	t.Redaction = redaction
+
+	if t.RedactionRequired {
+		t.OriginalText, t.OriginalSegments = text, segments
+		t.Text, t.Segments = redaction.Text, redaction.Segments
+		for i, revision := range t.Revisions {
+			t.Revisions[i].Text = RedactPII(revision.Text, DetectPII(revision.Text, kinds))
+		}
+		t.syncEventText()
+	}
+}
+
+// RequireRedaction applies workspace policy: from now on only the redacted
+// text is kept in Text. The cached summary was made from the original and
+// is dropped. It reports whether the transcription changed.
+func (t *Transcription) RequireRedaction(kinds []PIIKind) bool {
+	if t.RedactionRequired {
+		return false
+	}
+
+	t.RedactionRequired = true
+	t.Summary = nil
+	t.Redact(kinds)
+	t.UpdatedAt = time.Now()
+	return true
+}
+
+// ReleaseRedaction lifts the policy and restores the original text, unless
+// it was edited meanwhile. It reports whether the transcription changed.
+func (t *Transcription) ReleaseRedaction() bool {
+	if !t.RedactionRequired {
+		return false
+	}
+
+	t.Text, t.Segments = t.unredacted()
+	t.RedactionRequired = false
+	t.OriginalText, t.OriginalSegments = "", nil
+	t.UpdatedAt = time.Now()
+	return true
+}
+
+// PIICounts returns the number of findings of each kind
+func (r *Redaction) PIICounts() map[PIIKind]int {
+	counts := make(map[PIIKind]int)
+	for _, finding := range r.Findings {
+		counts[finding.Kind]++
+	}
+	return counts
+}
+
+// unredacted returns the original text and segments. Once redaction was
+// applied Text holds the redaction, except right after an edit replaced it
+// with new original text.
+func (t *Transcription) unredacted() (string, []Segment) {
+	if !t.RedactionRequired || t.OriginalText == "" {
+		return t.Text, t.Segments
+	}
+
+	text := t.Text
+	if t.Redaction != nil && text == t.Redaction.Text {
+		text = t.OriginalText
+	}
+	return text, t.OriginalSegments
+}
+
+// syncEventText replaces the text carried by pending events with Text
+func (t *Transcription) syncEventText() {
+	for i, event := range t.events {
+		switch recorded := event.(type) {
+		case TranscriptionCompleted:
+			recorded.Text = t.Text
+			t.events[i] = recorded
+		case TranscriptionEdited:
+			recorded.Text = t.Text
+			t.events[i] = recorded
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxSpeakerNameLength = 100
+
+var (
+	ErrInvalidSegments    = errors.New("segments must be ordered by start time, lie within the audio and have a speaker and text")
+	ErrSpeakerNotFound    = errors.New("speaker not found")
+	ErrInvalidSpeakerName = errors.New("speaker name must be at most 100 characters")
+)
+
+// Segment is a stretch of the audio, in seconds, attributed to one speaker.
+// Speaker is the label assigned by the diarizer, such as "SPEAKER_00".
+type Segment struct {
+	Start   float64
+	End     float64
+	Speaker string
+	Text    string
+}
+
+// SetSegments replaces the speaker segments of the transcription with the
+// diarizer's, which are also kept in RawSegments. Names given to speakers
+// that no longer appear are dropped.
+func (t *Transcription) SetSegments(segments []Segment) error {
+	normalized := make([]Segment, len(segments))
+	for i, segment := range segments {
+		segment.Speaker = strings.TrimSpace(segment.Speaker)
+		segment.Text = strings.TrimSpace(segment.Text)
+		if segment.Speaker == "" || segment.Text == "" || segment.Start < 0 || segment.End <= segment.Start {
+			return ErrInvalidSegments
+		}
+		if t.Duration > 0 && segment.End > t.Duration {
+			return ErrInvalidSegments
+		}
+		if i > 0 && segment.Start < normalized[i-1].Start {
+			return ErrInvalidSegments
+		}
+		normalized[i] = segment
+	}
+
+	t.Segments = normalized
+	t.RawSegments = append([]Segment(nil), normalized...)
+	t.SegmentsStale = false
+	for label := range t.SpeakerNames {
+		if !t.hasSpeaker(label) {
+			delete(t.SpeakerNames, label)
+		}
+	}
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SpeakerSegments returns the segments while they match the text, and nil
+// once the text was edited
+func (t *Transcription) SpeakerSegments() []Segment {
+	if t.SegmentsStale {
+		return nil
+	}
+	return t.Segments
+}
+
+// Speakers returns the speaker labels in order of first appearance
+func (t *Transcription) Speakers() []string {
+	seen := make(map[string]bool)
+	labels := make([]string, 0)
+	for _, segment := range t.Segments {
+		if !seen[segment.Speaker] {
+			seen[segment.Speaker] = true
+			labels = append(labels, segment.Speaker)
+		}
+	}
+	return labels
+}
+
+// SpeakerName returns the name given to a speaker, or its label
+func (t *Transcription) SpeakerName(label string) string {
+	if name, ok := t.SpeakerNames[label]; ok {
+		return name
+	}
+	return label
+}
+
+// RenameSpeaker names the speaker with the label. An empty name restores
+// the label.
+func (t *Transcription) RenameSpeaker(label, name string) error {
+	if !t.hasSpeaker(label) {
+		return ErrSpeakerNotFound
+	}
+
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > maxSpeakerNameLength {
+		return ErrInvalidSpeakerName
+	}
+
+	if name == "" {
+		delete(t.SpeakerNames, label)
+	} else {
+		if t.SpeakerNames == nil {
+			t.SpeakerNames = make(map[string]string)
+		}
+		t.SpeakerNames[label] = name
+	}
+
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SpeakerTranscript renders segments as one "Speaker: text" line per turn,
+// joining consecutive segments of the same speaker. Speakers are named by
+// the transcription's speaker names.
+func (t *Transcription) SpeakerTranscript(segments []Segment) string {
+	var transcript strings.Builder
+	for i, segment := range segments {
+		if i > 0 && segment.Speaker == segments[i-1].Speaker {
+			transcript.WriteString(" " + segment.Text)
+			continue
+		}
+		if i > 0 {
+			transcript.WriteString("\n")
+		}
+		transcript.WriteString(t.SpeakerName(segment.Speaker) + ": " + segment.Text)
+	}
+	return transcript.String()
+}
+
+func (t *Transcription) hasSpeaker(label string) bool {
+	for _, segment := range t.Segments {
+		if segment.Speaker == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxDeviceNameLength = 100
+	maxUserAgentLength  = 512
+)
+
+// Session is a login on one device. Access tokens carry the session ID, so
+// revoking the session logs the device out before its token expires.
+type Session struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	DeviceName string
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// NewSession starts a session that lasts as long as the token issued for it
+func NewSession(userID uuid.UUID, deviceName, userAgent, ip string, ttl time.Duration) *Session {
+	now := time.Now()
+	return &Session{
+		ID:         uuid.New(),
+		UserID:     userID,
+		DeviceName: truncate(strings.TrimSpace(deviceName), maxDeviceNameLength),
+		UserAgent:  truncate(userAgent, maxUserAgentLength),
+		IP:         ip,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+}
+
+// IsActive reports whether the session is neither revoked nor expired at now
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+func (s *Session) Revoke() {
+	if s.RevokedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	s.RevokedAt = &now
+}
+
+// Touch records activity from ip. It reports whether the session changed,
+// which only happens once per interval or when the IP changes, to avoid a
+// write on every request.
+func (s *Session) Touch(now time.Time, ip string, interval time.Duration) bool {
+	if ip == s.IP && now.Sub(s.LastSeenAt) < interval {
+		return false
+	}
+
+	s.LastSeenAt = now
+	if ip != "" {
+		s.IP = ip
+	}
+	return true
+}
+
+func (s *Session) BelongsToUser(userID uuid.UUID) bool {
+	return s.UserID == userID
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length]
+}
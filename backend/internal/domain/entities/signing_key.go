@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"time"
+)
+
+// Asymmetric algorithms used to sign access tokens
+const (
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmEdDSA = "EdDSA"
+)
+
+const rsaSigningKeyBits = 2048
+
+var (
+	ErrUnsupportedSigningAlgorithm = errors.New("signing algorithm must be RS256 or EdDSA")
+)
+
+// SigningKey is a key pair that signs access tokens. The active key signs new
+// tokens; once rotated out it is retired and only verifies tokens it already
+// signed until ExpiresAt.
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+	CreatedAt  time.Time
+	RetiredAt  *time.Time
+	ExpiresAt  *time.Time
+}
+
+// NewSigningKey generates a key pair for the algorithm with a random key ID
+func NewSigningKey(algorithm string) (*SigningKey, error) {
+	var (
+		privateKey crypto.Signer
+		err        error
+	)
+
+	switch algorithm {
+	case SigningAlgorithmRS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, rsaSigningKeyBits)
+	case SigningAlgorithmEdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, ErrUnsupportedSigningAlgorithm
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	kid := make([]byte, 16)
+	if _, err := rand.Read(kid); err != nil {
+		return nil, err
+	}
+
+	return &SigningKey{
+		ID:         base64.RawURLEncoding.EncodeToString(kid),
+		Algorithm:  algorithm,
+		PrivateKey: privateKey,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
+
+// IsActive reports whether the key signs new tokens
+func (k *SigningKey) IsActive() bool {
+	return k.RetiredAt == nil
+}
+
+// Retire stops the key from signing; it keeps verifying until now+verifyFor
+func (k *SigningKey) Retire(verifyFor time.Duration) {
+	if k.RetiredAt != nil {
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(verifyFor)
+	k.RetiredAt = &now
+	k.ExpiresAt = &expiresAt
+}
+
+// IsExpired reports whether the key can no longer verify tokens at now
+func (k *SigningKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// IsDue reports whether an active key has signed for longer than interval
+func (k *SigningKey) IsDue(now time.Time, interval time.Duration) bool {
+	return k.IsActive() && now.Sub(k.CreatedAt) >= interval
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrEmptySummary = errors.New("summary cannot be empty")
+
+// Summary is a generated digest of a transcription's text. TextHash
+// identifies the text it was generated from, so edits make it stale.
+type Summary struct {
+	Text        string
+	KeyPoints   []string
+	ActionItems []string
+	// Model names the summarizer that produced the summary
+	Model       string
+	TextHash    string
+	GeneratedAt time.Time
+}
+
+// IsCurrent reports whether the summary was generated from text
+func (s *Summary) IsCurrent(text string) bool {
+	return s.TextHash == hashText(text)
+}
+
+// Summarize caches a summarizer's result as the summary of the current text
+func (t *Transcription) Summarize(summary *Summary) error {
+	if !t.IsCompleted() {
+		return ErrTranscriptionNotCompleted
+	}
+
+	text := strings.TrimSpace(summary.Text)
+	if text == "" {
+		return ErrEmptySummary
+	}
+
+	t.Summary = &Summary{
+		Text:        text,
+		KeyPoints:   nonEmpty(summary.KeyPoints),
+		ActionItems: nonEmpty(summary.ActionItems),
+		Model:       summary.Model,
+		TextHash:    hashText(t.Text),
+		GeneratedAt: time.Now(),
+	}
+	t.UpdatedAt = t.Summary.GeneratedAt
+	return nil
+}
+
+// HasCurrentSummary reports whether the cached summary matches the text
+func (t *Transcription) HasCurrentSummary() bool {
+	return t.Summary != nil && t.Summary.IsCurrent(t.Text)
+}
+
+func hashText(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+func nonEmpty(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,188 @@
+package entities
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// TextStage names a stage of the pipeline that post-processes the provider's
+// text before a transcription completes
+type TextStage string
+
+const (
+	TextStageFillers      TextStage = "fillers"
+	TextStageNumbers      TextStage = "numbers"
+	TextStageNormalize    TextStage = "normalize"
+	TextStageReplacements TextStage = "replacements"
+	TextStageProfanity    TextStage = "profanity"
+)
+
+// TextStages lists the built-in stages in their recommended order: fillers
+// go before normalization recapitalizes sentences, and the user's own
+// replacements and masking see the final wording
+var TextStages = []TextStage{TextStageFillers, TextStageNumbers, TextStageNormalize, TextStageReplacements, TextStageProfanity}
+
+const (
+	maxTextReplacements      = 100
+	maxTextReplacementLength = 100
+)
+
+var (
+	ErrInvalidTextStage        = errors.New("text processing stage must be fillers, numbers, normalize, replacements or profanity")
+	ErrDuplicateTextStage      = errors.New("text processing stages must not repeat")
+	ErrInvalidTextReplacement  = errors.New("replacements need a find text and a replacement of up to 100 characters")
+	ErrTooManyTextReplacements = errors.New("at most 100 replacements are allowed")
+)
+
+// TextReplacement is a user's find and replace rule. Find matches whole
+// words regardless of case.
+type TextReplacement struct {
+	Find    string
+	Replace string
+}
+
+// TextProcessingSettings are a user's choice of post-processing stages, in
+// the order they run, and their replacement rules
+type TextProcessingSettings struct {
+	UserID       uuid.UUID
+	Stages       []TextStage
+	Replacements []TextReplacement
+	UpdatedAt    time.Time
+}
+
+// NewTextProcessingSettings creates settings running the given stages and no
+// replacements
+func NewTextProcessingSettings(userID uuid.UUID, stages []TextStage) *TextProcessingSettings {
+	return &TextProcessingSettings{
+		UserID:       userID,
+		Stages:       append([]TextStage(nil), stages...),
+		Replacements: make([]TextReplacement, 0),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+// Update replaces the stages and replacement rules. Nothing changes when
+// either is invalid.
+func (s *TextProcessingSettings) Update(stages []TextStage, replacements []TextReplacement) error {
+	if err := validateTextStages(stages); err != nil {
+		return err
+	}
+
+	if len(replacements) > maxTextReplacements {
+		return ErrTooManyTextReplacements
+	}
+
+	cleaned := make([]TextReplacement, len(replacements))
+	for i, replacement := range replacements {
+		replacement.Find = strings.TrimSpace(replacement.Find)
+		if replacement.Find == "" ||
+			utf8.RuneCountInString(replacement.Find) > maxTextReplacementLength ||
+			utf8.RuneCountInString(replacement.Replace) > maxTextReplacementLength {
+			return ErrInvalidTextReplacement
+		}
+		cleaned[i] = replacement
+	}
+
+	s.Stages = append([]TextStage(nil), stages...)
+	s.Replacements = cleaned
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Replace applies the replacement rules to text in order
+func (s *TextProcessingSettings) Replace(text string) string {
+	for _, replacement := range s.Replacements {
+		text = replacementPattern(replacement.Find).ReplaceAllLiteralString(text, replacement.Replace)
+	}
+	return text
+}
+
+// ProcessText replaces the text and segment texts of a completed
+// transcription with their post-processed version, also in the events
+// recorded since the last pull. The text is always processed from RawText
+// and the segments from RawSegments; results left empty keep their input.
+func (t *Transcription) ProcessText(process func(string) string) {
+	if !t.IsCompleted() {
+		return
+	}
+
+	if text := process(t.RawText); strings.TrimSpace(text) != "" {
+		t.Text = text
+	}
+	segments := make([]Segment, len(t.RawSegments))
+	for i, segment := range t.RawSegments {
+		if text := process(segment.Text); strings.TrimSpace(text) != "" {
+			segment.Text = text
+		}
+		segments[i] = segment
+	}
+	if len(t.RawSegments) > 0 {
+		t.Segments = segments
+	}
+	t.syncEventText()
+}
+
+// ParseTextStages parses a comma separated list of stages. An empty list
+// selects none.
+func ParseTextStages(list string) ([]TextStage, error) {
+	stages := make([]TextStage, 0)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		stages = append(stages, TextStage(name))
+	}
+
+	if err := validateTextStages(stages); err != nil {
+		return nil, err
+	}
+	return stages, nil
+}
+
+func validateTextStages(stages []TextStage) error {
+	seen := make(map[TextStage]bool, len(stages))
+	for _, stage := range stages {
+		if !isTextStage(stage) {
+			return ErrInvalidTextStage
+		}
+		if seen[stage] {
+			return ErrDuplicateTextStage
+		}
+		seen[stage] = true
+	}
+	return nil
+}
+
+func isTextStage(stage TextStage) bool {
+	for _, known := range TextStages {
+		if stage == known {
+			return true
+		}
+	}
+	return false
+}
+
+// replacementPattern matches find as a whole word. Word boundaries only
+// apply at ends that are ASCII letters or digits, as regexp understands
+// them, so rules such as "c++" or "über" work.
+func replacementPattern(find string) *regexp.Regexp {
+	pattern := regexp.QuoteMeta(find)
+	if first, _ := utf8.DecodeRuneInString(find); isWordRune(first) {
+		pattern = `\b` + pattern
+	}
+	if last, _ := utf8.DecodeLastRuneInString(find); isWordRune(last) {
+		pattern += `\b`
+	}
+	return regexp.MustCompile(`(?i)` + pattern)
+}
+
+func isWordRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	spacePattern           = regexp.MustCompile(`\s+`)
+	spaceBeforePunctuation = regexp.MustCompile(`\s+([,.;:!?])`)
+	sentenceStartPattern   = regexp.MustCompile(`(^|[.!?]\s+)(\p{Ll})`)
+	pronounIPattern        = regexp.MustCompile(`(^|\s)i('m|'ve|'ll|'d)?([\s,;:!?]|$)`)
+	fillerPattern          = regexp.MustCompile(`(?i)^(?:u+m+|u+h+m*|e+r+m+|er|a+h+|h+m+|m+h+m+|m{2,})$`)
+	profanityPattern       = regexp.MustCompile(`(?i)\b(?:fuck\w*|motherfuck\w*|shit\w*|bullshit|bitch\w*|bastards?|assholes?|damn\w*|goddamn\w*|crap|piss\w*|wank\w*)\b`)
+	numberWordsPattern     = regexp.MustCompile(`(?i)\b` + numberWord + `(?:(?:[\s-]+and)?[\s-]+` + numberWord + `)*\b`)
+)
+
+const numberWord = `(?:zero|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve|thirteen|fourteen|fifteen|sixteen|seventeen|eighteen|nineteen|twenty|thirty|forty|fifty|sixty|seventy|eighty|ninety|hundred|thousand|million|billion)`
+
+var numberWordValues = map[string]int{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9,
+	"ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15, "sixteen": 16,
+	"seventeen": 17, "eighteen": 18, "nineteen": 19, "twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+	"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+	"hundred": 100, "thousand": 1000, "million": 1000000, "billion": 1000000000,
+}
+
+// NormalizeText tidies punctuation and casing: whitespace is collapsed, stray
+// spaces before punctuation are dropped, sentences and the pronoun "I" are
+// capitalized and a final full stop is added when missing
+func NormalizeText(text string) string {
+	text = strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+	text = spaceBeforePunctuation.ReplaceAllString(text, "$1")
+	text = sentenceStartPattern.ReplaceAllStringFunc(text, strings.ToUpper)
+	text = pronounIPattern.ReplaceAllString(text, "${1}I${2}${3}")
+
+	if last, _ := utf8.DecodeLastRuneInString(text); unicode.IsLetter(last) || unicode.IsDigit(last) {
+		text += "."
+	}
+	return text
+}
+
+// RemoveFillers drops hesitation words such as "um", "uh" and "hmm". The
+// punctuation that ended a sentence on a filler moves to the word before it.
+func RemoveFillers(text string) string {
+	words := strings.Fields(text)
+	kept := make([]string, 0, len(words))
+	for _, word := range words {
+		bare := strings.TrimRight(word, ",.;:!?")
+		if !fillerPattern.MatchString(bare) {
+			kept = append(kept, word)
+			continue
+		}
+
+		ending := strings.Trim(word[len(bare):], ",;:")
+		if ending != "" && len(kept) > 0 {
+			previous := strings.TrimRight(kept[len(kept)-1], ",;:")
+			if previous != "" && !strings.ContainsAny(previous[len(previous)-1:], ".!?") {
+				kept[len(kept)-1] = previous + ending[:1]
+			}
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// FormatNumbers writes spelled out numbers such as "twenty five" or "three
+// hundred and twelve" as digits. Single words below ten stay spelled out, as
+// do sequences that do not read as one number, such as "one two three".
+func FormatNumbers(text string) string {
+	return numberWordsPattern.ReplaceAllStringFunc(text, func(match string) string {
+		words := make([]string, 0)
+		for _, word := range strings.FieldsFunc(strings.ToLower(match), func(r rune) bool {
+			return r == ' ' || r == '-' || unicode.IsSpace(r)
+		}) {
+			if word != "and" {
+				words = append(words, word)
+			}
+		}
+
+		value, ok := parseNumberWords(words)
+		if !ok || (len(words) == 1 && value < 10) {
+			return match
+		}
+		return strconv.Itoa(value)
+	})
+}
+
+// MaskProfanity replaces all but the first letter of swear words with
+// asterisks
+func MaskProfanity(text string) string {
+	return profanityPattern.ReplaceAllStringFunc(text, func(word string) string {
+		first, size := utf8.DecodeRuneInString(word)
+		return string(first) + strings.Repeat("*", utf8.RuneCountInString(word[size:]))
+	})
+}
+
+// parseNumberWords adds up number words, rejecting orders that are not a
+// single number: units follow nothing, tens, hundreds or scales; tens and
+// teens never follow units, teens or tens; scales only grow smaller
+func parseNumberWords(words []string) (int, bool) {
+	const (
+		none = iota
+		unit
+		teen
+		tens
+		hundred
+		scale
+	)
+
+	total, current, last := 0, 0, none
+	lastScale := 0
+	for _, word := range words {
+		value := numberWordValues[word]
+		switch {
+		case value < 10:
+			if last != none && last != tens && last != hundred && last != scale {
+				return 0, false
+			}
+			if value == 0 && len(words) > 1 {
+				return 0, false
+			}
+			current += value
+			last = unit
+		case value < 20:
+			if last != none && last != hundred && last != scale {
+				return 0, false
+			}
+			current += value
+			last = teen
+		case value < 100:
+			if last != none && last != hundred && last != scale {
+				return 0, false
+			}
+			current += value
+			last = tens
+		case value == 100:
+			if last != unit && last != teen {
+				return 0, false
+			}
+			current *= 100
+			last = hundred
+		default:
+			if last == none || last == scale || (lastScale != 0 && value >= lastScale) {
+				return 0, false
+			}
+			total += current * value
+			current = 0
+			last, lastScale = scale, value
+		}
+	}
+	return total + current, true
+}
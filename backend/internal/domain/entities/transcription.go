@@ -18,27 +18,83 @@ const (
 var (
 	ErrInvalidTranscriptionStatus = errors.New("invalid transcription status")
 	ErrEmptyText                  = errors.New("transcription text cannot be empty")
+	ErrSourceNotCompleted         = errors.New("source transcription is not completed")
+	ErrTranscriptionNotCompleted  = errors.New("transcription is not completed")
 )
 
 type Transcription struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	Text      string
-	Status    TranscriptionStatus
-	Duration  float64
+	ID     uuid.UUID
+	UserID uuid.UUID
+	// OrganizationID is the workspace the transcription was created in, whose
+	// members can read it; nil for personal transcriptions
+	OrganizationID *uuid.UUID
+	Title          string
+	// Folder is a slash separated path such as "meetings/2024"
+	Folder string
+	// Tags are lowercase, unique and sorted
+	Tags     []string
+	Metadata map[string]string
+	Text     string
+	// RawText is the provider's text before post-processing
+	RawText     string
+	Status      TranscriptionStatus
+	Duration    float64
+	AudioHash   string
+	AudioKey    string
+	DuplicateOf *uuid.UUID
+	// Segments attribute stretches of the audio to speakers when the
+	// transcription was diarized
+	Segments []Segment
+	// RawSegments are the diarizer's segments before post-processing
+	RawSegments []Segment
+	// SegmentsStale is set when the text is edited, since the segments then
+	// no longer match it. Stale segments are kept but not shown.
+	SegmentsStale bool
+	// SpeakerNames maps speaker labels to the names users gave them
+	SpeakerNames map[string]string
+	// Redaction is the text with detected personal data replaced
+	Redaction *Redaction
+	// RedactionRequired is set by workspace policy. Text and Segments then
+	// hold the redaction, and the originals are retained in OriginalText and
+	// OriginalSegments, which are never returned.
+	RedactionRequired bool
+	OriginalText      string
+	OriginalSegments  []Segment
+	// Revisions record the text each edit replaced, oldest first
+	Revisions []TextRevision
+	// Summary caches the generated summary of the text
+	Summary   *Summary
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	eventRecorder
+}
+
+// TextRevision is a text of the transcription that EditedBy replaced at
+// EditedAt
+type TextRevision struct {
+	Text     string
+	EditedBy uuid.UUID
+	EditedAt time.Time
 }
 
 func NewTranscription(userID uuid.UUID) *Transcription {
 	now := time.Now()
-	return &Transcription{
+	transcription := &Transcription{
 		ID:        uuid.New(),
 		UserID:    userID,
 		Status:    StatusProcessing,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
+
+	transcription.record(TranscriptionCreated{
+		EventMeta:       newEventMeta(),
+		TranscriptionID: transcription.ID,
+		UserID:          transcription.UserID,
+	})
+
+	return transcription
 }
 
 func (t *Transcription) Complete(text string, duration float64) error {
@@ -47,15 +103,95 @@ func (t *Transcription) Complete(text string, duration float64) error {
 	}
 
 	t.Text = text
+	t.RawText = text
 	t.Duration = duration
 	t.Status = StatusCompleted
 	t.UpdatedAt = time.Now()
+
+	t.record(TranscriptionCompleted{
+		EventMeta:       newEventMeta(),
+		TranscriptionID: t.ID,
+		UserID:          t.UserID,
+		Text:            t.Text,
+		Duration:        t.Duration,
+	})
+	return nil
+}
+
+// CompleteFromDuplicate completes the transcription by reusing the provider's
+// result for an earlier transcription of the same audio and links it to that
+// original. Only the raw text and segments are reused: the source's edits,
+// post-processing and redaction belong to its owner.
+func (t *Transcription) CompleteFromDuplicate(source *Transcription) error {
+	if !source.IsCompleted() {
+		return ErrSourceNotCompleted
+	}
+
+	originalID := source.ID
+	if source.DuplicateOf != nil {
+		originalID = *source.DuplicateOf
+	}
+
+	if err := t.Complete(source.RawText, source.Duration); err != nil {
+		return err
+	}
+
+	t.Segments = append([]Segment(nil), source.RawSegments...)
+	t.RawSegments = append([]Segment(nil), source.RawSegments...)
+	t.SegmentsStale = false
+	t.DuplicateOf = &originalID
+	return nil
+}
+
+// IsDuplicate reports whether the result was reused instead of transcribed
+func (t *Transcription) IsDuplicate() bool {
+	return t.DuplicateOf != nil
+}
+
+// EditText replaces the text of a completed transcription with a correction
+// by editedBy
+func (t *Transcription) EditText(text string, editedBy uuid.UUID) error {
+	if err := t.CheckEdit(text); err != nil {
+		return err
+	}
+
+	t.Revisions = append(t.Revisions, TextRevision{Text: t.Text, EditedBy: editedBy, EditedAt: time.Now()})
+	t.Text = text
+	t.SegmentsStale = len(t.Segments) > 0
+	t.UpdatedAt = time.Now()
+
+	t.record(TranscriptionEdited{
+		EventMeta:       newEventMeta(),
+		TranscriptionID: t.ID,
+		UserID:          t.UserID,
+		EditedBy:        editedBy,
+		Text:            t.Text,
+	})
+	return nil
+}
+
+// CheckEdit returns the error EditText would return for text, without
+// changing the transcription
+func (t *Transcription) CheckEdit(text string) error {
+	if !t.IsCompleted() {
+		return ErrTranscriptionNotCompleted
+	}
+
+	if text == "" {
+		return ErrEmptyText
+	}
 	return nil
 }
 
 func (t *Transcription) Fail() {
 	t.Status = StatusFailed
 	t.UpdatedAt = time.Now()
+
+	t.record(TranscriptionFailed{
+		EventMeta:       newEventMeta(),
+		TranscriptionID: t.ID,
+		UserID:          t.UserID,
+	})
 }
 
 func (t *Transcription) IsCompleted() bool {
@@ -73,3 +209,7 @@ func (t *Transcription) IsProcessing() bool {
 func (t *Transcription) BelongsToUser(userID uuid.UUID) bool {
 	return t.UserID == userID
 }
+
+func (t *Transcription) BelongsToOrganization(organizationID uuid.UUID) bool {
+	return t.OrganizationID != nil && *t.OrganizationID == organizationID
+}
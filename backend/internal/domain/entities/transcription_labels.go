@@ -0,0 +1,164 @@
+package entities
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxTitleLength         = 200
+	maxFolderLength        = 200
+	maxTags                = 20
+	maxTagLength           = 50
+	maxMetadataEntries     = 50
+	maxMetadataKeyLength   = 64
+	maxMetadataValueLength = 1024
+)
+
+var (
+	ErrInvalidTitle    = errors.New("title must be at most 200 characters")
+	ErrInvalidFolder   = errors.New("folder must be at most 200 characters without empty path segments")
+	ErrInvalidTag      = errors.New("tags must be between 1 and 50 characters without commas")
+	ErrTooManyTags     = errors.New("a transcription can have at most 20 tags")
+	ErrInvalidMetadata = errors.New("metadata allows at most 50 entries with keys up to 64 and values up to 1024 characters")
+)
+
+func (t *Transcription) SetTitle(title string) error {
+	title = strings.TrimSpace(title)
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return ErrInvalidTitle
+	}
+
+	t.Title = title
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetFolder moves the transcription into a folder. Surrounding slashes are
+// dropped and an empty folder moves it back to the top level.
+func (t *Transcription) SetFolder(folder string) error {
+	folder, err := NormalizeFolder(folder)
+	if err != nil {
+		return err
+	}
+
+	t.Folder = folder
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// InFolder reports whether the transcription is in folder or one of its
+// subfolders
+func (t *Transcription) InFolder(folder string) bool {
+	return t.Folder == folder || strings.HasPrefix(t.Folder, folder+"/")
+}
+
+// SetTags replaces the tags of the transcription
+func (t *Transcription) SetTags(tags []string) error {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag, err := NormalizeTag(tag)
+		if err != nil {
+			return err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+
+	if len(normalized) > maxTags {
+		return ErrTooManyTags
+	}
+
+	sort.Strings(normalized)
+	t.Tags = normalized
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *Transcription) HasTag(tag string) bool {
+	for _, existing := range t.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplaceTags swaps every tag in from for to, merging them when the
+// transcription already has to. It reports whether any tag changed.
+func (t *Transcription) ReplaceTags(from []string, to string) (bool, error) {
+	replaced := make(map[string]bool, len(from))
+	for _, tag := range from {
+		replaced[tag] = true
+	}
+
+	tags := make([]string, 0, len(t.Tags))
+	changed := false
+	for _, tag := range t.Tags {
+		if replaced[tag] && tag != to {
+			tag = to
+			changed = true
+		}
+		tags = append(tags, tag)
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	return true, t.SetTags(tags)
+}
+
+// SetMetadata replaces the free-form key/value metadata of the transcription
+func (t *Transcription) SetMetadata(metadata map[string]string) error {
+	if len(metadata) > maxMetadataEntries {
+		return ErrInvalidMetadata
+	}
+
+	normalized := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		key = strings.TrimSpace(key)
+		if key == "" || utf8.RuneCountInString(key) > maxMetadataKeyLength || utf8.RuneCountInString(value) > maxMetadataValueLength {
+			return ErrInvalidMetadata
+		}
+		normalized[key] = value
+	}
+
+	t.Metadata = normalized
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// NormalizeTag trims and lowercases a tag
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" || utf8.RuneCountInString(tag) > maxTagLength || strings.Contains(tag, ",") {
+		return "", ErrInvalidTag
+	}
+	return tag, nil
+}
+
+// NormalizeFolder trims a folder path and its surrounding slashes
+func NormalizeFolder(folder string) (string, error) {
+	folder = strings.Trim(strings.TrimSpace(folder), "/")
+	if utf8.RuneCountInString(folder) > maxFolderLength {
+		return "", ErrInvalidFolder
+	}
+
+	if folder == "" {
+		return "", nil
+	}
+
+	for _, segment := range strings.Split(folder, "/") {
+		if strings.TrimSpace(segment) == "" {
+			return "", ErrInvalidFolder
+		}
+	}
+	return folder, nil
+}
@@ -0,0 +1,152 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Permissions a transcription can be shared with
+const (
+	SharePermissionRead = "read"
+	SharePermissionEdit = "edit"
+)
+
+var (
+	ErrInvalidSharePermission = errors.New("share permission must be read or edit")
+	ErrShareWithOwner         = errors.New("cannot share a transcription with its owner")
+	ErrInvalidShareLinkExpiry = errors.New("share link expiry must be in the future")
+)
+
+// TranscriptionShare grants another user access to a transcription. Shares
+// are made out to an email address and stay pending, without a UserID, until
+// an account verifies that address.
+type TranscriptionShare struct {
+	ID              uuid.UUID
+	TranscriptionID uuid.UUID
+	OwnerID         uuid.UUID
+	UserID          uuid.UUID
+	Email           string
+	Permission      string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// NewTranscriptionShare returns a pending share with the address email
+func NewTranscriptionShare(transcription *Transcription, email, permission string) (*TranscriptionShare, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if err := validateEmail(email); err != nil {
+		return nil, err
+	}
+
+	if !IsValidSharePermission(permission) {
+		return nil, ErrInvalidSharePermission
+	}
+
+	now := time.Now()
+	return &TranscriptionShare{
+		ID:              uuid.New(),
+		TranscriptionID: transcription.ID,
+		OwnerID:         transcription.UserID,
+		Email:           email,
+		Permission:      permission,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// Claim grants the share to the account that verified its address
+func (s *TranscriptionShare) Claim(userID uuid.UUID) error {
+	if userID == s.OwnerID {
+		return ErrShareWithOwner
+	}
+
+	s.UserID = userID
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsPending reports whether no account has claimed the share yet
+func (s *TranscriptionShare) IsPending() bool {
+	return s.UserID == uuid.Nil
+}
+
+// IsFor reports whether the share was made out to email
+func (s *TranscriptionShare) IsFor(email string) bool {
+	return strings.EqualFold(s.Email, strings.TrimSpace(email))
+}
+
+func (s *TranscriptionShare) ChangePermission(permission string) error {
+	if !IsValidSharePermission(permission) {
+		return ErrInvalidSharePermission
+	}
+
+	s.Permission = permission
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *TranscriptionShare) CanEdit() bool {
+	return s.Permission == SharePermissionEdit
+}
+
+func IsValidSharePermission(permission string) bool {
+	return permission == SharePermissionRead || permission == SharePermissionEdit
+}
+
+// ShareLink gives anyone with its URL read-only access to a transcription.
+// Only the SHA-256 hash of the token is stored, so the URL is shown once.
+type ShareLink struct {
+	ID              uuid.UUID
+	TranscriptionID uuid.UUID
+	OwnerID         uuid.UUID
+	TokenHash       string
+	ExpiresAt       *time.Time
+	RevokedAt       *time.Time
+	CreatedAt       time.Time
+}
+
+// NewShareLink returns the link with the plaintext token for its URL. A nil
+// expiresAt makes the link valid until revoked.
+func NewShareLink(transcription *Transcription, expiresAt *time.Time) (*ShareLink, string, error) {
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", ErrInvalidShareLinkExpiry
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	return &ShareLink{
+		ID:              uuid.New(),
+		TranscriptionID: transcription.ID,
+		OwnerID:         transcription.UserID,
+		TokenHash:       HashUserToken(secret),
+		ExpiresAt:       expiresAt,
+		CreatedAt:       now,
+	}, secret, nil
+}
+
+// IsActive reports whether the link is neither revoked nor expired at now
+func (l *ShareLink) IsActive(now time.Time) bool {
+	if l.RevokedAt != nil {
+		return false
+	}
+	return l.ExpiresAt == nil || now.Before(*l.ExpiresAt)
+}
+
+func (l *ShareLink) Revoke() {
+	if l.RevokedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	l.RevokedAt = &now
+}
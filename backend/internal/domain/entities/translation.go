@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidLanguage  = errors.New("language must be a language code such as de or pt-BR")
+	ErrEmptyTranslation = errors.New("translation cannot be empty")
+)
+
+var languagePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// NormalizeLanguage canonicalizes a BCP 47 language code: the language is
+// lowercased, two letter regions are uppercased and four letter scripts are
+// title cased, so "PT-br" becomes "pt-BR" and "zh-hant" becomes "zh-Hant".
+func NormalizeLanguage(code string) (string, error) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), "_", "-")
+	if !languagePattern.MatchString(code) {
+		return "", ErrInvalidLanguage
+	}
+
+	subtags := strings.Split(code, "-")
+	subtags[0] = strings.ToLower(subtags[0])
+	for i := 1; i < len(subtags); i++ {
+		switch len(subtags[i]) {
+		case 2:
+			subtags[i] = strings.ToUpper(subtags[i])
+		case 4:
+			subtags[i] = strings.ToUpper(subtags[i][:1]) + strings.ToLower(subtags[i][1:])
+		default:
+			subtags[i] = strings.ToLower(subtags[i])
+		}
+	}
+
+	return strings.Join(subtags, "-"), nil
+}
+
+// Translation is a version of a transcription's text in another language.
+// Each translation of changed text into a language adds a new version, and
+// earlier versions are kept.
+type Translation struct {
+	ID              uuid.UUID
+	TranscriptionID uuid.UUID
+	// OwnerID is the owner of the transcription, whose account deletion
+	// removes its translations
+	OwnerID  uuid.UUID
+	UserID   uuid.UUID
+	Language string
+	// Version counts the translations of the transcription into Language,
+	// starting at 1
+	Version int
+	Text    string
+	// Segments translate the transcription's speaker segments, keeping their
+	// times and speakers
+	Segments []Segment
+	// Redacted is set when the translated text was redacted by policy
+	Redacted bool
+	// SourceHash identifies the text that was translated
+	SourceHash string
+	CreatedAt  time.Time
+}
+
+// NewTranslation records text as a translation of the transcription's
+// current text into language, and segments as the translations of its
+// segments' texts in order
+func NewTranslation(transcription *Transcription, userID uuid.UUID, language string, version int, text string, segments []string) (*Translation, error) {
+	if !transcription.IsCompleted() {
+		return nil, ErrTranscriptionNotCompleted
+	}
+
+	language, err := NormalizeLanguage(language)
+	if err != nil {
+		return nil, err
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyTranslation
+	}
+
+	if len(segments) != len(transcription.SpeakerSegments()) {
+		return nil, ErrInvalidSegments
+	}
+
+	var translated []Segment
+	for i, segment := range transcription.SpeakerSegments() {
+		segment.Text = strings.TrimSpace(segments[i])
+		if segment.Text == "" {
+			return nil, ErrEmptyTranslation
+		}
+		translated = append(translated, segment)
+	}
+
+	return &Translation{
+		ID:              uuid.New(),
+		TranscriptionID: transcription.ID,
+		OwnerID:         transcription.UserID,
+		UserID:          userID,
+		Language:        language,
+		Version:         version,
+		Text:            text,
+		Segments:        translated,
+		Redacted:        transcription.RedactionRequired,
+		SourceHash:      hashText(transcription.Text),
+		CreatedAt:       time.Now(),
+	}, nil
+}
+
+// IsVisible reports whether the translation may be returned for the
+// transcription: while redaction is required only translations of redacted
+// text are
+func (t *Translation) IsVisible(transcription *Transcription) bool {
+	return t.Redacted || !transcription.RedactionRequired
+}
+
+// IsCurrent reports whether the translation was made from text
+func (t *Translation) IsCurrent(text string) bool {
+	return t.SourceHash == hashText(text)
+}
@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidQuota = errors.New("quota must not be negative")
+)
+
+// Provider calls recorded in the usage ledger
+const (
+	UsageOperationTranscription = "transcription"
+	UsageOperationSummary       = "summary"
+	UsageOperationTranslation   = "translation"
+)
+
+// UsageRecord is a ledger entry for a call to a paid provider. Only
+// transcriptions have AudioSeconds, which count against the minutes quota;
+// summaries and translations only add their estimated cost.
+type UsageRecord struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	TranscriptionID uuid.UUID
+	Operation       string
+	AudioSeconds    float64
+	Provider        string
+	EstimatedCost   float64
+	CreatedAt       time.Time
+}
+
+func NewUsageRecord(userID, transcriptionID uuid.UUID, audioSeconds float64, provider string, costPerMinute float64) *UsageRecord {
+	record := NewOperationUsageRecord(userID, transcriptionID, UsageOperationTranscription, audioSeconds, provider, costPerMinute)
+	record.AudioSeconds = audioSeconds
+	return record
+}
+
+// NewOperationUsageRecord records an operation other than the transcription
+// itself, such as a summary or translation of the transcription, priced by
+// the seconds of speech it processed
+func NewOperationUsageRecord(userID, transcriptionID uuid.UUID, operation string, processedSeconds float64, provider string, costPerMinute float64) *UsageRecord {
+	return &UsageRecord{
+		ID:              uuid.New(),
+		UserID:          userID,
+		TranscriptionID: transcriptionID,
+		Operation:       operation,
+		Provider:        provider,
+		EstimatedCost:   processedSeconds / 60 * costPerMinute,
+		CreatedAt:       time.Now(),
+	}
+}
+
+// UsageQuota holds per-user quota settings that override the plan defaults
+type UsageQuota struct {
+	UserID         uuid.UUID
+	MonthlyMinutes *float64
+	ResetAt        *time.Time
+	UpdatedAt      time.Time
+}
+
+func NewUsageQuota(userID uuid.UUID) *UsageQuota {
+	return &UsageQuota{
+		UserID:    userID,
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (q *UsageQuota) SetMonthlyMinutes(minutes float64) error {
+	if minutes < 0 {
+		return ErrInvalidQuota
+	}
+
+	q.MonthlyMinutes = &minutes
+	q.UpdatedAt = time.Now()
+	return nil
+}
+
+func (q *UsageQuota) ClearMonthlyMinutes() {
+	q.MonthlyMinutes = nil
+	q.UpdatedAt = time.Now()
+}
+
+// Reset discards the consumption recorded so far in the current period
+func (q *UsageQuota) Reset() {
+	now := time.Now()
+	q.ResetAt = &now
+	q.UpdatedAt = now
+}
+
+// CountsFrom returns when consumption starts counting for a period beginning
+// at periodStart, taking a reset within the period into account
+func (q *UsageQuota) CountsFrom(periodStart time.Time) time.Time {
+	if q.ResetAt != nil && q.ResetAt.After(periodStart) {
+		return *q.ResetAt
+	}
+	return periodStart
+}
+
+// BillingPeriod returns the calendar month (UTC) containing t
+func BillingPeriod(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
@@ -3,25 +3,47 @@ package entities
 import (
 	"errors"
 	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	PlanFree = "free"
+	PlanPro  = "pro"
+)
+
+const (
+	RoleUser    = "user"
+	RoleSupport = "support"
+	RoleAdmin   = "admin"
+)
+
 var (
 	ErrInvalidEmail    = errors.New("invalid email format")
 	ErrInvalidPassword = errors.New("password must be at least 8 characters")
 	ErrInvalidName     = errors.New("name cannot be empty")
+	ErrInvalidRole     = errors.New("role must be user, support or admin")
 )
 
 type User struct {
-	ID           uuid.UUID
-	Email        string
-	PasswordHash string
-	Name         string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID              uuid.UUID
+	Email           string
+	PasswordHash    string
+	Name            string
+	Plan            string
+	Role            string
+	Disabled        bool
+	DisabledAt      *time.Time
+	EmailVerified   bool
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	eventRecorder
 }
 
 func NewUser(email, password, name string) (*User, error) {
@@ -43,14 +65,64 @@ func NewUser(email, password, name string) (*User, error) {
 	}
 
 	now := time.Now()
-	return &User{
+	user := &User{
 		ID:           uuid.New(),
 		Email:        email,
 		PasswordHash: hashedPassword,
 		Name:         name,
+		Plan:         PlanFree,
+		Role:         RoleUser,
 		CreatedAt:    now,
 		UpdatedAt:    now,
-	}, nil
+	}
+
+	user.record(UserRegistered{
+		EventMeta: newEventMeta(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+	})
+
+	return user, nil
+}
+
+// NewExternalUser creates an account for someone who signs in through an
+// external identity provider. It has no password until the user sets one
+// through a password reset.
+func NewExternalUser(email, name string) (*User, error) {
+	if err := validateEmail(email); err != nil {
+		return nil, err
+	}
+
+	if name = strings.TrimSpace(name); name == "" {
+		name = strings.SplitN(email, "@", 2)[0]
+	}
+
+	now := time.Now()
+	user := &User{
+		ID:        uuid.New(),
+		Email:     email,
+		Name:      name,
+		Plan:      PlanFree,
+		Role:      RoleUser,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	user.record(UserRegistered{
+		EventMeta: newEventMeta(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+	})
+
+	return user, nil
+}
+
+// HasPassword reports whether the user can log in with a password. Accounts
+// created through an identity provider have none until one is set.
+func (u *User) HasPassword() bool {
+	return u.PasswordHash != ""
 }
 
 func (u *User) VerifyPassword(password string) bool {
@@ -58,6 +130,111 @@ func (u *User) VerifyPassword(password string) bool {
 	return err == nil
 }
 
+func (u *User) UpdateName(name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	u.Name = name
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// ChangeEmail replaces the email address, which must then be verified again
+func (u *User) ChangeEmail(email string) error {
+	if err := validateEmail(email); err != nil {
+		return err
+	}
+
+	if email == u.Email {
+		return nil
+	}
+
+	u.Email = email
+	u.EmailVerified = false
+	u.EmailVerifiedAt = nil
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsValidRole reports whether role is one of the known roles
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleUser, RoleSupport, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+func (u *User) SetRole(role string) error {
+	if !IsValidRole(role) {
+		return ErrInvalidRole
+	}
+
+	u.Role = role
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// HasRole reports whether the user holds any of the given roles
+func (u *User) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Disable blocks the account from logging in and from using existing tokens
+func (u *User) Disable() {
+	if u.Disabled {
+		return
+	}
+
+	now := time.Now()
+	u.Disabled = true
+	u.DisabledAt = &now
+	u.UpdatedAt = now
+}
+
+func (u *User) Enable() {
+	u.Disabled = false
+	u.DisabledAt = nil
+	u.UpdatedAt = time.Now()
+}
+
+// Delete records that the account is being removed so other components can
+// erase the data they hold for it
+func (u *User) Delete() {
+	u.record(UserDeleted{
+		EventMeta: newEventMeta(),
+		UserID:    u.ID,
+	})
+}
+
+// VerifyEmail marks the current email address as confirmed by its owner
+func (u *User) VerifyEmail() {
+	now := time.Now()
+	u.EmailVerified = true
+	u.EmailVerifiedAt = &now
+	u.UpdatedAt = now
+}
+
+var (
+	dummyPasswordHash     []byte
+	dummyPasswordHashOnce sync.Once
+)
+
+// VerifyDummyPassword does the bcrypt work of VerifyPassword against a fixed
+// hash, so rejecting an unknown email takes as long as a wrong password
+func VerifyDummyPassword(password string) {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("voiceline-dummy-password"), bcrypt.DefaultCost)
+	})
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+}
+
 func (u *User) UpdatePassword(newPassword string) error {
 	if err := validatePassword(newPassword); err != nil {
 		return err
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserTokenPurpose string
+
+const (
+	TokenPurposeEmailVerification UserTokenPurpose = "email_verification"
+	TokenPurposePasswordReset     UserTokenPurpose = "password_reset"
+)
+
+var (
+	ErrUserTokenUsed    = errors.New("token has already been used")
+	ErrUserTokenExpired = errors.New("token has expired")
+)
+
+// UserToken is a single-use secret emailed to a user. Only the SHA-256 hash
+// of the secret is stored, so a leaked token table cannot be replayed.
+type UserToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Email     string
+	Purpose   UserTokenPurpose
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NewUserToken issues a token for the user's current email address and
+// returns it with the plaintext secret to send
+func NewUserToken(user *User, purpose UserTokenPurpose, ttl time.Duration) (*UserToken, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	now := time.Now()
+	return &UserToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		Purpose:   purpose,
+		TokenHash: HashUserToken(secret),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, secret, nil
+}
+
+// HashUserToken returns the lookup hash of a plaintext token
+func HashUserToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Use consumes the token, failing if it was already used or has expired
+func (t *UserToken) Use(now time.Time) error {
+	if t.IsUsed() {
+		return ErrUserTokenUsed
+	}
+
+	if t.IsExpired(now) {
+		return ErrUserTokenExpired
+	}
+
+	t.UsedAt = &now
+	return nil
+}
+
+func (t *UserToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+func (t *UserToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,215 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookEventTranscriptionCompleted = EventTranscriptionCompleted
+	WebhookEventTranscriptionFailed    = EventTranscriptionFailed
+)
+
+var (
+	ErrInvalidWebhookURL   = errors.New("webhook URL must be an absolute http or https URL")
+	ErrInvalidWebhookEvent = errors.New("unsupported webhook event")
+	ErrNoWebhookEvents     = errors.New("webhook must subscribe to at least one event")
+	// ErrWebhookAddressNotAllowed keeps webhooks from reaching the server's
+	// own network
+	ErrWebhookAddressNotAllowed = errors.New("webhook URL must not point to a loopback, link-local or private address")
+)
+
+// nonPublicNetworks are special purpose ranges not covered by the net.IP
+// predicates: "this network", carrier-grade NAT, IETF protocol assignments,
+// benchmarking and NAT64
+var nonPublicNetworks = parseCIDRs("0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15", "64:ff9b::/96")
+
+// WebhookEvents lists the event types a webhook can subscribe to
+var WebhookEvents = []string{
+	WebhookEventTranscriptionCompleted,
+	WebhookEventTranscriptionFailed,
+}
+
+type Webhook struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	URL       string
+	Events    []string
+	Secret    string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewWebhook(userID uuid.UUID, rawURL string, events []string) (*Webhook, error) {
+	if err := validateWebhookURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhookEvents(events); err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Webhook{
+		ID:        uuid.New(),
+		UserID:    userID,
+		URL:       rawURL,
+		Events:    dedupeStrings(events),
+		Secret:    secret,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (w *Webhook) UpdateURL(rawURL string) error {
+	if err := validateWebhookURL(rawURL); err != nil {
+		return err
+	}
+
+	w.URL = rawURL
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+func (w *Webhook) UpdateEvents(events []string) error {
+	if err := validateWebhookEvents(events); err != nil {
+		return err
+	}
+
+	w.Events = dedupeStrings(events)
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+func (w *Webhook) SetActive(active bool) {
+	w.Active = active
+	w.UpdatedAt = time.Now()
+}
+
+func (w *Webhook) IsSubscribedTo(event string) bool {
+	if !w.Active {
+		return false
+	}
+
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Webhook) BelongsToUser(userID uuid.UUID) bool {
+	return w.UserID == userID
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of "<timestamp>.<payload>" keyed
+// with the webhook secret. Receivers recompute it to authenticate deliveries
+// and reject stale timestamps to prevent replays.
+func (w *Webhook) Sign(timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// IsPublicIP reports whether ip is a globally routable unicast address, as
+// opposed to loopback, link-local (such as cloud metadata endpoints),
+// private or otherwise reserved addresses
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsMulticast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+func validateWebhookEvents(events []string) error {
+	if len(events) == 0 {
+		return ErrNoWebhookEvents
+	}
+
+	for _, event := range events {
+		if !isKnownWebhookEvent(event) {
+			return ErrInvalidWebhookEvent
+		}
+	}
+	return nil
+}
+
+func isKnownWebhookEvent(event string) bool {
+	for _, known := range WebhookEvents {
+		if known == event {
+			return true
+		}
+	}
+	return false
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+func dedupeStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	DeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is a queued attempt to POST one event payload to one webhook.
+// Deliveries are retried with backoff until they succeed or run out of attempts.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	WebhookID      uuid.UUID
+	UserID         uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	MaxAttempts    int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus int
+	LastError      string
+	RedeliveryOf   *uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewWebhookDelivery(webhook *Webhook, eventID uuid.UUID, eventType string, payload []byte, maxAttempts int) *WebhookDelivery {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:            uuid.New(),
+		WebhookID:     webhook.ID,
+		UserID:        webhook.UserID,
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        DeliveryPending,
+		MaxAttempts:   maxAttempts,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Redeliver creates a fresh pending delivery carrying the same event payload
+func (d *WebhookDelivery) Redeliver(webhook *Webhook) *WebhookDelivery {
+	redelivery := NewWebhookDelivery(webhook, d.EventID, d.EventType, d.Payload, d.MaxAttempts)
+	originalID := d.ID
+	redelivery.RedeliveryOf = &originalID
+	return redelivery
+}
+
+func (d *WebhookDelivery) RecordSuccess(responseStatus int) {
+	now := time.Now()
+	d.Attempts++
+	d.Status = DeliverySucceeded
+	d.ResponseStatus = responseStatus
+	d.LastError = ""
+	d.LastAttemptAt = &now
+	d.UpdatedAt = now
+}
+
+// RecordFailure registers a failed attempt. The delivery is rescheduled after
+// retryIn unless it has exhausted its attempts, in which case it is failed.
+func (d *WebhookDelivery) RecordFailure(responseStatus int, errMsg string, retryIn time.Duration) {
+	now := time.Now()
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+	d.LastError = errMsg
+	d.LastAttemptAt = &now
+	d.UpdatedAt = now
+
+	if d.Attempts >= d.MaxAttempts {
+		d.Status = DeliveryFailed
+		return
+	}
+
+	d.NextAttemptAt = now.Add(retryIn)
+}
+
+// Abandon fails the delivery without another attempt
+func (d *WebhookDelivery) Abandon(reason string) {
+	d.Status = DeliveryFailed
+	d.LastError = reason
+	d.UpdatedAt = time.Now()
+}
+
+func (d *WebhookDelivery) IsDue(now time.Time) bool {
+	return d.Status == DeliveryPending && !d.NextAttemptAt.After(now)
+}
+
+func (d *WebhookDelivery) IsPending() bool {
+	return d.Status == DeliveryPending
+}
+
+func (d *WebhookDelivery) IsSucceeded() bool {
+	return d.Status == DeliverySucceeded
+}
+
+func (d *WebhookDelivery) IsFailed() bool {
+	return d.Status == DeliveryFailed
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error)
+	FindByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	// FindByUserID returns the user's keys, newest first
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.APIKey, error)
+	Update(ctx context.Context, key *entities.APIKey) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
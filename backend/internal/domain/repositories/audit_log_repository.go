@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+// AuditLogSearch filters audit log entries; zero values match everything
+type AuditLogSearch struct {
+	ActorID  uuid.UUID
+	TargetID uuid.UUID
+	Limit    int
+}
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *entities.AuditLogEntry) error
+	// Search returns matching entries, newest first
+	Search(ctx context.Context, search AuditLogSearch) ([]*entities.AuditLogEntry, error)
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment *entities.Comment) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.Comment, error)
+	// FindByTranscriptionID returns the transcription's comments and
+	// replies, oldest first
+	FindByTranscriptionID(ctx context.Context, transcriptionID uuid.UUID) ([]*entities.Comment, error)
+	Update(ctx context.Context, comment *entities.Comment) error
+	// Delete removes the comment together with its replies
+	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteByUserID removes the user's comments with the replies to their
+	// threads, and every comment on the user's transcriptions
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type HighlightRepository interface {
+	Create(ctx context.Context, highlight *entities.Highlight) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.Highlight, error)
+	// FindByTranscriptionID returns the transcription's highlights, oldest first
+	FindByTranscriptionID(ctx context.Context, transcriptionID uuid.UUID) ([]*entities.Highlight, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteByUserID removes the user's highlights and those on the user's
+	// transcriptions
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type ExportJobRepository interface {
+	Create(ctx context.Context, job *entities.ExportJob) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.ExportJob, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.ExportJob, error)
+	FindPending(ctx context.Context, limit int) ([]*entities.ExportJob, error)
+	FindExpired(ctx context.Context, now time.Time) ([]*entities.ExportJob, error)
+	Update(ctx context.Context, job *entities.ExportJob) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type ExternalIdentityRepository interface {
+	Create(ctx context.Context, identity *entities.ExternalIdentity) error
+	FindBySubject(ctx context.Context, issuer, subject string) (*entities.ExternalIdentity, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.ExternalIdentity, error)
+	Update(ctx context.Context, identity *entities.ExternalIdentity) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type OIDCLoginRequestRepository interface {
+	Create(ctx context.Context, request *entities.OIDCLoginRequest) error
+	// Consume removes and returns the request with the given state, so a
+	// provider callback can only be redeemed once
+	Consume(ctx context.Context, state string) (*entities.OIDCLoginRequest, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"io"
+)
+
+// FileStore keeps files such as uploaded audio and export archives by key
+type FileStore interface {
+	Save(ctx context.Context, key string, data []byte) error
+	// SaveStream stores everything read from content under key and returns
+	// its size. Nothing is stored when reading fails.
+	SaveStream(ctx context.Context, key string, content io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
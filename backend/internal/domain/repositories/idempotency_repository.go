@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type IdempotencyRepository interface {
+	// CreateIfAbsent stores the record unless an unexpired record with the same
+	// key exists, in which case the existing record is returned instead
+	CreateIfAbsent(ctx context.Context, record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, error)
+	Update(ctx context.Context, record *entities.IdempotencyRecord) error
+	Delete(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type LoginThrottleRepository interface {
+	FindByKey(ctx context.Context, key string) (*entities.LoginThrottle, error)
+	Save(ctx context.Context, throttle *entities.LoginThrottle) error
+	Delete(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
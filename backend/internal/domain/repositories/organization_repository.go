@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *entities.Organization) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.Organization, error)
+	Update(ctx context.Context, organization *entities.Organization) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type OrganizationMemberRepository interface {
+	Create(ctx context.Context, member *entities.OrganizationMember) error
+	Find(ctx context.Context, organizationID, userID uuid.UUID) (*entities.OrganizationMember, error)
+	// FindByOrganizationID returns the organization's members, longest
+	// standing first
+	FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*entities.OrganizationMember, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.OrganizationMember, error)
+	Update(ctx context.Context, member *entities.OrganizationMember) error
+	Delete(ctx context.Context, organizationID, userID uuid.UUID) error
+}
+
+type OrganizationInvitationRepository interface {
+	Create(ctx context.Context, invitation *entities.OrganizationInvitation) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.OrganizationInvitation, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entities.OrganizationInvitation, error)
+	// FindByOrganizationID returns the organization's invitations, newest first
+	FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*entities.OrganizationInvitation, error)
+	Update(ctx context.Context, invitation *entities.OrganizationInvitation) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteByOrganizationID(ctx context.Context, organizationID uuid.UUID) error
+	// DeleteExpired removes invitations that expired before now
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
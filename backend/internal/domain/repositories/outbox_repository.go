@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type OutboxRepository interface {
+	Append(ctx context.Context, messages ...*entities.OutboxMessage) error
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxMessage, error)
+	Update(ctx context.Context, message *entities.OutboxMessage) error
+}
+
+// Transactor runs fn so that every repository write made through ctx is
+// committed atomically, or not at all when fn returns an error
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+// RateLimitStore keeps token buckets. Take must refill and consume atomically
+// so that a store shared between instances enforces a single limit.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, policy entities.RateLimitPolicy) (entities.RateLimitDecision, error)
+}
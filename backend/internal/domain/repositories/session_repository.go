@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *entities.Session) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.Session, error)
+	// FindByUserID returns the user's sessions, most recently seen first
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error)
+	Update(ctx context.Context, session *entities.Session) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+	// DeleteExpired removes sessions whose tokens have expired
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type SigningKeyRepository interface {
+	Create(ctx context.Context, key *entities.SigningKey) error
+	FindByID(ctx context.Context, id string) (*entities.SigningKey, error)
+	// FindAll returns every stored key, newest first
+	FindAll(ctx context.Context) ([]*entities.SigningKey, error)
+	Update(ctx context.Context, key *entities.SigningKey) error
+	Delete(ctx context.Context, id string) error
+}
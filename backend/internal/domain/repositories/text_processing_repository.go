@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type TextProcessingSettingsRepository interface {
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*entities.TextProcessingSettings, error)
+	Save(ctx context.Context, settings *entities.TextProcessingSettings) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
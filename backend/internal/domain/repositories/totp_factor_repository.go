@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+// TOTPFactorRepository stores at most one authenticator app enrollment per user
+type TOTPFactorRepository interface {
+	// Save creates or replaces the user's factor
+	Save(ctx context.Context, factor *entities.TOTPFactor) error
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*entities.TOTPFactor, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -11,6 +11,8 @@ type TranscriptionRepository interface {
 	Create(ctx context.Context, transcription *entities.Transcription) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entities.Transcription, error)
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Transcription, error)
+	FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*entities.Transcription, error)
+	FindByAudioHash(ctx context.Context, audioHash string) ([]*entities.Transcription, error)
 	Update(ctx context.Context, transcription *entities.Transcription) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type TranscriptionShareRepository interface {
+	Create(ctx context.Context, share *entities.TranscriptionShare) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.TranscriptionShare, error)
+	Find(ctx context.Context, transcriptionID, userID uuid.UUID) (*entities.TranscriptionShare, error)
+	// FindByTranscriptionID returns the transcription's shares, oldest first
+	FindByTranscriptionID(ctx context.Context, transcriptionID uuid.UUID) ([]*entities.TranscriptionShare, error)
+	// FindByUserID returns the shares granted to the user, newest first
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.TranscriptionShare, error)
+	// FindPendingByEmail returns the unclaimed shares made out to email
+	FindPendingByEmail(ctx context.Context, email string) ([]*entities.TranscriptionShare, error)
+	Update(ctx context.Context, share *entities.TranscriptionShare) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteByUserID removes the shares granted by and to the user
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type ShareLinkRepository interface {
+	Create(ctx context.Context, link *entities.ShareLink) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.ShareLink, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entities.ShareLink, error)
+	// FindByTranscriptionID returns the transcription's links, newest first
+	FindByTranscriptionID(ctx context.Context, transcriptionID uuid.UUID) ([]*entities.ShareLink, error)
+	Update(ctx context.Context, link *entities.ShareLink) error
+	DeleteByOwnerID(ctx context.Context, ownerID uuid.UUID) error
+}
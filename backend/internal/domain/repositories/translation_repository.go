@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type TranslationRepository interface {
+	Create(ctx context.Context, translation *entities.Translation) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.Translation, error)
+	// FindByTranscriptionID returns the transcription's translations ordered
+	// by language and version
+	FindByTranscriptionID(ctx context.Context, transcriptionID uuid.UUID) ([]*entities.Translation, error)
+	// FindByOwnerID returns the translations of the user's transcriptions
+	FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*entities.Translation, error)
+	// DeleteByOwnerID removes the translations of the user's transcriptions
+	DeleteByOwnerID(ctx context.Context, ownerID uuid.UUID) error
+}
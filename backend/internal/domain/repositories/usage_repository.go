@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type UsageRepository interface {
+	Create(ctx context.Context, record *entities.UsageRecord) error
+	FindByUserIDSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]*entities.UsageRecord, error)
+}
+
+type UsageQuotaRepository interface {
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*entities.UsageQuota, error)
+	Save(ctx context.Context, quota *entities.UsageQuota) error
+}
@@ -7,10 +7,23 @@ import (
 	"github.com/voiceline/backend/internal/domain/entities"
 )
 
+// UserSearch selects users for administration. Query matches email or name
+// case-insensitively; empty fields match every user.
+type UserSearch struct {
+	Query    string
+	Role     string
+	Disabled *bool
+	Offset   int
+	Limit    int
+}
+
 type UserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
 	FindByEmail(ctx context.Context, email string) (*entities.User, error)
 	Update(ctx context.Context, user *entities.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// Search returns a page of matching users, oldest first, and the total
+	// number of matches
+	Search(ctx context.Context, search UserSearch) ([]*entities.User, int, error)
 }
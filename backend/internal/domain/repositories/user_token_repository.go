@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *entities.UserToken) error
+	// Consume atomically marks the token with the given purpose and hash as
+	// used, so concurrent requests cannot both redeem it
+	Consume(ctx context.Context, purpose entities.UserTokenPurpose, tokenHash string, now time.Time) (*entities.UserToken, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose entities.UserTokenPurpose) error
+}
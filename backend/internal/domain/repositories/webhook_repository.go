@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *entities.Webhook) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.Webhook, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Webhook, error)
+	Update(ctx context.Context, webhook *entities.Webhook) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *entities.WebhookDelivery) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error)
+	FindByWebhookID(ctx context.Context, webhookID uuid.UUID) ([]*entities.WebhookDelivery, error)
+	FindByEventID(ctx context.Context, eventID uuid.UUID) ([]*entities.WebhookDelivery, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *entities.WebhookDelivery) error
+	DeleteByWebhookID(ctx context.Context, webhookID uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+package mail
+
+import (
+	"context"
+	"log"
+)
+
+// LogMailer writes email to the server log instead of sending it. It is used
+// in development when no SMTP relay is configured.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, to, subject, body string) error {
+	log.Printf("mail to %s: %s\n%s", to, subject, body)
+	return nil
+}
@@ -0,0 +1,52 @@
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// Message is an email captured by MemoryMailer
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// MemoryMailer keeps sent email in memory so tests can inspect it
+type MemoryMailer struct {
+	messages []Message
+	mu       sync.Mutex
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, Message{To: to, Subject: subject, Body: body})
+	return nil
+}
+
+// Messages returns every message sent so far, oldest first
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.messages...)
+}
+
+// LastTo returns the most recent message sent to the address
+func (m *MemoryMailer) LastTo(to string) (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		if m.messages[i].To == to {
+			return m.messages[i], true
+		}
+	}
+	return Message{}, false
+}
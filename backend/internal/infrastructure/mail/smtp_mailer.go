@@ -0,0 +1,65 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidHeader = errors.New("mail header must not contain line breaks")
+)
+
+// SMTPMailer sends plain text email through an SMTP relay
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+	// envelopeFrom is the bare address of from used in the SMTP envelope
+	envelopeFrom string
+}
+
+// NewSMTPMailer creates a new SMTPMailer. Authentication is skipped when
+// username is empty; net/smtp only sends credentials over TLS or to localhost.
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	envelopeFrom := from
+	if parsed, err := netmail.ParseAddress(from); err == nil {
+		envelopeFrom = parsed.Address
+	}
+
+	return &SMTPMailer{
+		addr:         net.JoinHostPort(host, port),
+		auth:         auth,
+		from:         from,
+		envelopeFrom: envelopeFrom,
+	}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return ErrInvalidHeader
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return smtp.SendMail(m.addr, m.auth, m.envelopeFrom, []string{to}, msg.Bytes())
+}
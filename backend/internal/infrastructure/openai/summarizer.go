@@ -0,0 +1,82 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+// DefaultSummaryModel is the chat model used for summaries
+const DefaultSummaryModel = openai.GPT3Dot5Turbo1106
+
+var ErrSummarizationFailed = errors.New("summarization returned no content")
+
+const summaryPrompt = `You summarize transcribed voice memos and meetings.
+Reply with a JSON object with the keys "summary" (a short paragraph),
+"key_points" (an array of the most important points) and "action_items"
+(an array of tasks someone committed to or was asked to do, empty if none).
+Write in the language of the transcript.`
+
+type Summarizer struct {
+	client *openai.Client
+	model  string
+}
+
+// NewSummarizer creates a Summarizer using the chat completions API
+func NewSummarizer(apiKey, model string) (*Summarizer, error) {
+	if apiKey == "" {
+		return nil, ErrEmptyAPIKey
+	}
+
+	if model == "" {
+		model = DefaultSummaryModel
+	}
+
+	return &Summarizer{
+		client: openai.NewClient(apiKey),
+		model:  model,
+	}, nil
+}
+
+func (s *Summarizer) Summarize(ctx context.Context, text string) (*entities.Summary, error) {
+	resp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model: s.model,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: summaryPrompt},
+			{Role: openai.ChatMessageRoleUser, Content: text},
+		},
+		ResponseFormat: &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, ErrSummarizationFailed
+	}
+
+	var content struct {
+		Summary     string   `json:"summary"`
+		KeyPoints   []string `json:"key_points"`
+		ActionItems []string `json:"action_items"`
+	}
+	if err := json.Unmarshal([]byte(resp.Choices[0].Message.Content), &content); err != nil {
+		return nil, err
+	}
+
+	if content.Summary == "" {
+		return nil, ErrSummarizationFailed
+	}
+
+	return &entities.Summary{
+		Text:        content.Summary,
+		KeyPoints:   content.KeyPoints,
+		ActionItems: content.ActionItems,
+		Model:       s.model,
+	}, nil
+}
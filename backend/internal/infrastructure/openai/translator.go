@@ -0,0 +1,80 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// DefaultTranslationModel is the chat model used for translations
+const DefaultTranslationModel = openai.GPT3Dot5Turbo1106
+
+var ErrTranslationFailed = errors.New("translation returned no content")
+
+const translationPrompt = `You translate transcribed speech.
+You receive a JSON object with a "passages" array. Translate every passage
+into the language with the BCP 47 code %q, keeping names, numbers and the
+speaker's tone. Reply with a JSON object with a "passages" array holding
+exactly one translation per passage, in the same order.`
+
+type Translator struct {
+	client *openai.Client
+	model  string
+}
+
+// NewTranslator creates a Translator using the chat completions API
+func NewTranslator(apiKey, model string) (*Translator, error) {
+	if apiKey == "" {
+		return nil, ErrEmptyAPIKey
+	}
+
+	if model == "" {
+		model = DefaultTranslationModel
+	}
+
+	return &Translator{
+		client: openai.NewClient(apiKey),
+		model:  model,
+	}, nil
+}
+
+func (t *Translator) Translate(ctx context.Context, passages []string, language string) ([]string, error) {
+	request, err := json.Marshal(map[string][]string{"passages": passages})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model: t.model,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: fmt.Sprintf(translationPrompt, language)},
+			{Role: openai.ChatMessageRoleUser, Content: string(request)},
+		},
+		ResponseFormat: &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, ErrTranslationFailed
+	}
+
+	var content struct {
+		Passages []string `json:"passages"`
+	}
+	if err := json.Unmarshal([]byte(resp.Choices[0].Message.Content), &content); err != nil {
+		return nil, err
+	}
+
+	if len(content.Passages) != len(passages) {
+		return nil, ErrTranslationFailed
+	}
+
+	return content.Passages, nil
+}
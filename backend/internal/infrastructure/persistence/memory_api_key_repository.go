@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+)
+
+type MemoryAPIKeyRepository struct {
+	keys      map[uuid.UUID]*entities.APIKey
+	hashIndex map[string]uuid.UUID
+	mu        sync.RWMutex
+}
+
+func NewMemoryAPIKeyRepository() *MemoryAPIKeyRepository {
+	return &MemoryAPIKeyRepository{
+		keys:      make(map[uuid.UUID]*entities.APIKey),
+		hashIndex: make(map[string]uuid.UUID),
+	}
+}
+
+func (r *MemoryAPIKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys[key.ID] = key
+	r.hashIndex[key.KeyHash] = key.ID
+	return nil
+}
+
+func (r *MemoryAPIKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, exists := r.keys[id]
+	if !exists {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (r *MemoryAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, exists := r.hashIndex[keyHash]
+	if !exists {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	return r.keys[id], nil
+}
+
+func (r *MemoryAPIKeyRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.APIKey, 0)
+	for _, key := range r.keys {
+		if key.UserID == userID {
+			result = append(result, key)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *MemoryAPIKeyRepository) Update(ctx context.Context, key *entities.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.keys[key.ID]; !exists {
+		return ErrAPIKeyNotFound
+	}
+
+	r.keys[key.ID] = key
+	return nil
+}
+
+func (r *MemoryAPIKeyRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, key := range r.keys {
+		if key.UserID == userID {
+			delete(r.hashIndex, key.KeyHash)
+			delete(r.keys, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+)
+
+type MemoryAuditLogRepository struct {
+	entries []*entities.AuditLogEntry
+	mu      sync.RWMutex
+}
+
+func NewMemoryAuditLogRepository() *MemoryAuditLogRepository {
+	return &MemoryAuditLogRepository{}
+}
+
+func (r *MemoryAuditLogRepository) Create(ctx context.Context, entry *entities.AuditLogEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *MemoryAuditLogRepository) Search(ctx context.Context, search repositories.AuditLogSearch) ([]*entities.AuditLogEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.AuditLogEntry, 0)
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		entry := r.entries[i]
+		if search.ActorID != uuid.Nil && entry.ActorID != search.ActorID {
+			continue
+		}
+		if search.TargetID != uuid.Nil && entry.TargetID != search.TargetID {
+			continue
+		}
+
+		result = append(result, entry)
+		if search.Limit > 0 && len(result) == search.Limit {
+			break
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,178 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrCommentNotFound   = errors.New("comment not found")
+	ErrHighlightNotFound = errors.New("highlight not found")
+)
+
+type MemoryCommentRepository struct {
+	comments map[uuid.UUID]*entities.Comment
+	mu       sync.RWMutex
+}
+
+func NewMemoryCommentRepository() *MemoryCommentRepository {
+	return &MemoryCommentRepository{
+		comments: make(map[uuid.UUID]*entities.Comment),
+	}
+}
+
+func (r *MemoryCommentRepository) Create(ctx context.Context, comment *entities.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.comments[comment.ID] = comment
+	return nil
+}
+
+func (r *MemoryCommentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comment, exists := r.comments[id]
+	if !exists {
+		return nil, ErrCommentNotFound
+	}
+
+	return comment, nil
+}
+
+func (r *MemoryCommentRepository) FindByTranscriptionID(ctx context.Context, transcriptionID uuid.UUID) ([]*entities.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.Comment, 0)
+	for _, comment := range r.comments {
+		if comment.TranscriptionID == transcriptionID {
+			result = append(result, comment)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *MemoryCommentRepository) Update(ctx context.Context, comment *entities.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.comments[comment.ID]; !exists {
+		return ErrCommentNotFound
+	}
+
+	r.comments[comment.ID] = comment
+	return nil
+}
+
+func (r *MemoryCommentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteThreads(map[uuid.UUID]bool{id: true})
+	return nil
+}
+
+func (r *MemoryCommentRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make(map[uuid.UUID]bool)
+	for id, comment := range r.comments {
+		if comment.UserID == userID || comment.OwnerID == userID {
+			ids[id] = true
+		}
+	}
+
+	r.deleteThreads(ids)
+	return nil
+}
+
+// deleteThreads removes the comments and the replies to them
+func (r *MemoryCommentRepository) deleteThreads(ids map[uuid.UUID]bool) {
+	for id, comment := range r.comments {
+		if ids[id] || (comment.ParentID != nil && ids[*comment.ParentID]) {
+			delete(r.comments, id)
+		}
+	}
+}
+
+type MemoryHighlightRepository struct {
+	highlights map[uuid.UUID]*entities.Highlight
+	mu         sync.RWMutex
+}
+
+func NewMemoryHighlightRepository() *MemoryHighlightRepository {
+	return &MemoryHighlightRepository{
+		highlights: make(map[uuid.UUID]*entities.Highlight),
+	}
+}
+
+func (r *MemoryHighlightRepository) Create(ctx context.Context, highlight *entities.Highlight) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.highlights[highlight.ID] = highlight
+	return nil
+}
+
+func (r *MemoryHighlightRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Highlight, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	highlight, exists := r.highlights[id]
+	if !exists {
+		return nil, ErrHighlightNotFound
+	}
+
+	return highlight, nil
+}
+
+func (r *MemoryHighlightRepository) FindByTranscriptionID(ctx context.Context, transcriptionID uuid.UUID) ([]*entities.Highlight, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.Highlight, 0)
+	for _, highlight := range r.highlights {
+		if highlight.TranscriptionID == transcriptionID {
+			result = append(result, highlight)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *MemoryHighlightRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.highlights, id)
+	return nil
+}
+
+func (r *MemoryHighlightRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, highlight := range r.highlights {
+		if highlight.UserID == userID || highlight.OwnerID == userID {
+			delete(r.highlights, id)
+		}
+	}
+	return nil
+}
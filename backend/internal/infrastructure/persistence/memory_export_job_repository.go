@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrExportJobNotFound = errors.New("export job not found")
+)
+
+type MemoryExportJobRepository struct {
+	jobs map[uuid.UUID]*entities.ExportJob
+	mu   sync.RWMutex
+}
+
+func NewMemoryExportJobRepository() *MemoryExportJobRepository {
+	return &MemoryExportJobRepository{
+		jobs: make(map[uuid.UUID]*entities.ExportJob),
+	}
+}
+
+func (r *MemoryExportJobRepository) Create(ctx context.Context, job *entities.ExportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jobs[job.ID] = job
+	return nil
+}
+
+func (r *MemoryExportJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.ExportJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, exists := r.jobs[id]
+	if !exists {
+		return nil, ErrExportJobNotFound
+	}
+
+	return job, nil
+}
+
+// FindByUserID returns the export jobs of a user, newest first
+func (r *MemoryExportJobRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.ExportJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	jobs := make([]*entities.ExportJob, 0)
+	for _, job := range r.jobs {
+		if job.UserID == userID {
+			jobs = append(jobs, job)
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+
+	return jobs, nil
+}
+
+// FindPending returns jobs waiting to run, oldest first
+func (r *MemoryExportJobRepository) FindPending(ctx context.Context, limit int) ([]*entities.ExportJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pending := make([]*entities.ExportJob, 0)
+	for _, job := range r.jobs {
+		if job.IsPending() {
+			pending = append(pending, job)
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+
+	if limit > 0 && len(pending) > limit {
+		pending = pending[:limit]
+	}
+
+	return pending, nil
+}
+
+func (r *MemoryExportJobRepository) FindExpired(ctx context.Context, now time.Time) ([]*entities.ExportJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	expired := make([]*entities.ExportJob, 0)
+	for _, job := range r.jobs {
+		if job.IsExpired(now) {
+			expired = append(expired, job)
+		}
+	}
+
+	return expired, nil
+}
+
+func (r *MemoryExportJobRepository) Update(ctx context.Context, job *entities.ExportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.jobs[job.ID]; !exists {
+		return ErrExportJobNotFound
+	}
+
+	r.jobs[job.ID] = job
+	return nil
+}
+
+func (r *MemoryExportJobRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.jobs, id)
+	return nil
+}
@@ -0,0 +1,130 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrExternalIdentityNotFound = errors.New("external identity not found")
+	ErrOIDCLoginRequestNotFound = errors.New("login request not found")
+)
+
+type MemoryExternalIdentityRepository struct {
+	identities map[uuid.UUID]*entities.ExternalIdentity
+	mu         sync.RWMutex
+}
+
+func NewMemoryExternalIdentityRepository() *MemoryExternalIdentityRepository {
+	return &MemoryExternalIdentityRepository{
+		identities: make(map[uuid.UUID]*entities.ExternalIdentity),
+	}
+}
+
+func (r *MemoryExternalIdentityRepository) Create(ctx context.Context, identity *entities.ExternalIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.identities[identity.ID] = identity
+	return nil
+}
+
+func (r *MemoryExternalIdentityRepository) FindBySubject(ctx context.Context, issuer, subject string) (*entities.ExternalIdentity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, identity := range r.identities {
+		if identity.Issuer == issuer && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+
+	return nil, ErrExternalIdentityNotFound
+}
+
+func (r *MemoryExternalIdentityRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.ExternalIdentity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var identities []*entities.ExternalIdentity
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			identities = append(identities, identity)
+		}
+	}
+
+	return identities, nil
+}
+
+func (r *MemoryExternalIdentityRepository) Update(ctx context.Context, identity *entities.ExternalIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.identities[identity.ID]; !exists {
+		return ErrExternalIdentityNotFound
+	}
+
+	r.identities[identity.ID] = identity
+	return nil
+}
+
+func (r *MemoryExternalIdentityRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, identity := range r.identities {
+		if identity.UserID == userID {
+			delete(r.identities, id)
+		}
+	}
+	return nil
+}
+
+type MemoryOIDCLoginRequestRepository struct {
+	requests map[string]*entities.OIDCLoginRequest
+	mu       sync.Mutex
+}
+
+func NewMemoryOIDCLoginRequestRepository() *MemoryOIDCLoginRequestRepository {
+	return &MemoryOIDCLoginRequestRepository{
+		requests: make(map[string]*entities.OIDCLoginRequest),
+	}
+}
+
+func (r *MemoryOIDCLoginRequestRepository) Create(ctx context.Context, request *entities.OIDCLoginRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests[request.State] = request
+	return nil
+}
+
+func (r *MemoryOIDCLoginRequestRepository) Consume(ctx context.Context, state string) (*entities.OIDCLoginRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	request, exists := r.requests[state]
+	if !exists {
+		return nil, ErrOIDCLoginRequestNotFound
+	}
+
+	delete(r.requests, state)
+	return request, nil
+}
+
+func (r *MemoryOIDCLoginRequestRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for state, request := range r.requests {
+		if request.IsExpired(now) {
+			delete(r.requests, state)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+var (
+	ErrFileNotFound = errors.New("file not found")
+)
+
+type MemoryFileStore struct {
+	files map[string][]byte
+	mu    sync.RWMutex
+}
+
+func NewMemoryFileStore() *MemoryFileStore {
+	return &MemoryFileStore{
+		files: make(map[string][]byte),
+	}
+}
+
+func (s *MemoryFileStore) Save(ctx context.Context, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (s *MemoryFileStore) SaveStream(ctx context.Context, key string, content io.Reader) (int64, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(data)), s.Save(ctx, key, data)
+}
+
+func (s *MemoryFileStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, exists := s.files[key]
+	if !exists {
+		return nil, ErrFileNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Delete removes the file stored under key; missing keys are ignored
+func (s *MemoryFileStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.files, key)
+	return nil
+}
+
+// Len returns the number of stored files
+func (s *MemoryFileStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.files)
+}
@@ -0,0 +1,69 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrIdempotencyRecordNotFound = errors.New("idempotency record not found")
+)
+
+type MemoryIdempotencyRepository struct {
+	records map[string]*entities.IdempotencyRecord
+	mu      sync.Mutex
+}
+
+func NewMemoryIdempotencyRepository() *MemoryIdempotencyRepository {
+	return &MemoryIdempotencyRepository{
+		records: make(map[string]*entities.IdempotencyRecord),
+	}
+}
+
+func (r *MemoryIdempotencyRepository) CreateIfAbsent(ctx context.Context, record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, exists := r.records[record.Key]; exists && !existing.IsExpired(time.Now()) {
+		return existing, nil
+	}
+
+	r.records[record.Key] = record
+	return nil, nil
+}
+
+func (r *MemoryIdempotencyRepository) Update(ctx context.Context, record *entities.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.records[record.Key]; !exists {
+		return ErrIdempotencyRecordNotFound
+	}
+
+	r.records[record.Key] = record
+	return nil
+}
+
+func (r *MemoryIdempotencyRepository) Delete(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.records, key)
+	return nil
+}
+
+func (r *MemoryIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, record := range r.records {
+		if record.IsExpired(now) {
+			delete(r.records, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrLoginThrottleNotFound = errors.New("login throttle not found")
+)
+
+type MemoryLoginThrottleRepository struct {
+	throttles map[string]*entities.LoginThrottle
+	mu        sync.RWMutex
+}
+
+func NewMemoryLoginThrottleRepository() *MemoryLoginThrottleRepository {
+	return &MemoryLoginThrottleRepository{
+		throttles: make(map[string]*entities.LoginThrottle),
+	}
+}
+
+func (r *MemoryLoginThrottleRepository) FindByKey(ctx context.Context, key string) (*entities.LoginThrottle, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	throttle, exists := r.throttles[key]
+	if !exists || throttle.IsExpired(time.Now()) {
+		return nil, ErrLoginThrottleNotFound
+	}
+
+	copied := *throttle
+	return &copied, nil
+}
+
+func (r *MemoryLoginThrottleRepository) Save(ctx context.Context, throttle *entities.LoginThrottle) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *throttle
+	r.throttles[throttle.Key] = &copied
+	return nil
+}
+
+func (r *MemoryLoginThrottleRepository) Delete(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.throttles, key)
+	return nil
+}
+
+func (r *MemoryLoginThrottleRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, throttle := range r.throttles {
+		if throttle.IsExpired(now) {
+			delete(r.throttles, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrOrganizationNotFound           = errors.New("organization not found")
+	ErrOrganizationMemberNotFound     = errors.New("organization member not found")
+	ErrOrganizationMemberExists       = errors.New("organization member already exists")
+	ErrOrganizationInvitationNotFound = errors.New("organization invitation not found")
+)
+
+type MemoryOrganizationRepository struct {
+	organizations map[uuid.UUID]*entities.Organization
+	mu            sync.RWMutex
+}
+
+func NewMemoryOrganizationRepository() *MemoryOrganizationRepository {
+	return &MemoryOrganizationRepository{
+		organizations: make(map[uuid.UUID]*entities.Organization),
+	}
+}
+
+func (r *MemoryOrganizationRepository) Create(ctx context.Context, organization *entities.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.organizations[organization.ID] = organization
+	return nil
+}
+
+func (r *MemoryOrganizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Organization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	organization, exists := r.organizations[id]
+	if !exists {
+		return nil, ErrOrganizationNotFound
+	}
+
+	return organization, nil
+}
+
+func (r *MemoryOrganizationRepository) Update(ctx context.Context, organization *entities.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.organizations[organization.ID]; !exists {
+		return ErrOrganizationNotFound
+	}
+
+	r.organizations[organization.ID] = organization
+	return nil
+}
+
+func (r *MemoryOrganizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.organizations, id)
+	return nil
+}
+
+type memberKey struct {
+	organizationID uuid.UUID
+	userID         uuid.UUID
+}
+
+type MemoryOrganizationMemberRepository struct {
+	members map[memberKey]*entities.OrganizationMember
+	mu      sync.RWMutex
+}
+
+func NewMemoryOrganizationMemberRepository() *MemoryOrganizationMemberRepository {
+	return &MemoryOrganizationMemberRepository{
+		members: make(map[memberKey]*entities.OrganizationMember),
+	}
+}
+
+func (r *MemoryOrganizationMemberRepository) Create(ctx context.Context, member *entities.OrganizationMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := memberKey{member.OrganizationID, member.UserID}
+	if _, exists := r.members[key]; exists {
+		return ErrOrganizationMemberExists
+	}
+
+	r.members[key] = member
+	return nil
+}
+
+func (r *MemoryOrganizationMemberRepository) Find(ctx context.Context, organizationID, userID uuid.UUID) (*entities.OrganizationMember, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	member, exists := r.members[memberKey{organizationID, userID}]
+	if !exists {
+		return nil, ErrOrganizationMemberNotFound
+	}
+
+	return member, nil
+}
+
+func (r *MemoryOrganizationMemberRepository) FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*entities.OrganizationMember, error) {
+	return r.filter(func(member *entities.OrganizationMember) bool {
+		return member.OrganizationID == organizationID
+	}), nil
+}
+
+func (r *MemoryOrganizationMemberRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.OrganizationMember, error) {
+	return r.filter(func(member *entities.OrganizationMember) bool {
+		return member.UserID == userID
+	}), nil
+}
+
+func (r *MemoryOrganizationMemberRepository) Update(ctx context.Context, member *entities.OrganizationMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := memberKey{member.OrganizationID, member.UserID}
+	if _, exists := r.members[key]; !exists {
+		return ErrOrganizationMemberNotFound
+	}
+
+	r.members[key] = member
+	return nil
+}
+
+func (r *MemoryOrganizationMemberRepository) Delete(ctx context.Context, organizationID, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.members, memberKey{organizationID, userID})
+	return nil
+}
+
+// filter returns the matching members, longest standing first
+func (r *MemoryOrganizationMemberRepository) filter(match func(*entities.OrganizationMember) bool) []*entities.OrganizationMember {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.OrganizationMember, 0)
+	for _, member := range r.members {
+		if match(member) {
+			result = append(result, member)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result
+}
+
+type MemoryOrganizationInvitationRepository struct {
+	invitations map[uuid.UUID]*entities.OrganizationInvitation
+	mu          sync.RWMutex
+}
+
+func NewMemoryOrganizationInvitationRepository() *MemoryOrganizationInvitationRepository {
+	return &MemoryOrganizationInvitationRepository{
+		invitations: make(map[uuid.UUID]*entities.OrganizationInvitation),
+	}
+}
+
+func (r *MemoryOrganizationInvitationRepository) Create(ctx context.Context, invitation *entities.OrganizationInvitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.invitations[invitation.ID] = invitation
+	return nil
+}
+
+func (r *MemoryOrganizationInvitationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.OrganizationInvitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invitation, exists := r.invitations[id]
+	if !exists {
+		return nil, ErrOrganizationInvitationNotFound
+	}
+
+	return invitation, nil
+}
+
+func (r *MemoryOrganizationInvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entities.OrganizationInvitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, invitation := range r.invitations {
+		if invitation.TokenHash == tokenHash {
+			return invitation, nil
+		}
+	}
+
+	return nil, ErrOrganizationInvitationNotFound
+}
+
+func (r *MemoryOrganizationInvitationRepository) FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*entities.OrganizationInvitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.OrganizationInvitation, 0)
+	for _, invitation := range r.invitations {
+		if invitation.OrganizationID == organizationID {
+			result = append(result, invitation)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *MemoryOrganizationInvitationRepository) Update(ctx context.Context, invitation *entities.OrganizationInvitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.invitations[invitation.ID]; !exists {
+		return ErrOrganizationInvitationNotFound
+	}
+
+	r.invitations[invitation.ID] = invitation
+	return nil
+}
+
+func (r *MemoryOrganizationInvitationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.invitations, id)
+	return nil
+}
+
+func (r *MemoryOrganizationInvitationRepository) DeleteByOrganizationID(ctx context.Context, organizationID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, invitation := range r.invitations {
+		if invitation.OrganizationID == organizationID {
+			delete(r.invitations, id)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryOrganizationInvitationRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, invitation := range r.invitations {
+		if invitation.IsExpired(now) {
+			delete(r.invitations, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrOutboxMessageNotFound = errors.New("outbox message not found")
+)
+
+type MemoryOutboxRepository struct {
+	messages map[uuid.UUID]*entities.OutboxMessage
+	order    []uuid.UUID
+	mu       sync.RWMutex
+}
+
+func NewMemoryOutboxRepository() *MemoryOutboxRepository {
+	return &MemoryOutboxRepository{
+		messages: make(map[uuid.UUID]*entities.OutboxMessage),
+	}
+}
+
+func (r *MemoryOutboxRepository) Append(ctx context.Context, messages ...*entities.OutboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, message := range messages {
+		r.messages[message.ID] = message
+		r.order = append(r.order, message.ID)
+	}
+	return nil
+}
+
+// FindDue returns pending messages whose next attempt is due, in append order
+func (r *MemoryOutboxRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	due := make([]*entities.OutboxMessage, 0)
+	for _, id := range r.order {
+		message := r.messages[id]
+		if !message.IsDue(now) {
+			continue
+		}
+
+		due = append(due, message)
+		if limit > 0 && len(due) == limit {
+			break
+		}
+	}
+
+	return due, nil
+}
+
+func (r *MemoryOutboxRepository) Update(ctx context.Context, message *entities.OutboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.messages[message.ID]; !exists {
+		return ErrOutboxMessageNotFound
+	}
+
+	r.messages[message.ID] = message
+	return nil
+}
+
+// MemoryTransactor serializes transactions against the in-memory repositories.
+// The in-memory store cannot roll back, so callers must perform the write that
+// is most likely to fail first; a database-backed Transactor would wrap fn in
+// a real transaction instead.
+type MemoryTransactor struct {
+	mu sync.Mutex
+}
+
+func NewMemoryTransactor() *MemoryTransactor {
+	return &MemoryTransactor{}
+}
+
+func (t *MemoryTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return fn(ctx)
+}
@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+const rateLimitSweepInterval = time.Minute
+
+// MemoryRateLimitStore keeps token buckets in process memory. Limits are per
+// instance; use a shared store when running several replicas.
+type MemoryRateLimitStore struct {
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+type memoryBucket struct {
+	bucket *entities.TokenBucket
+	policy entities.RateLimitPolicy
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets:   make(map[string]*memoryBucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// WithClock replaces the clock buckets are refilled by, for tests
+func (s *MemoryRateLimitStore) WithClock(now func() time.Time) *MemoryRateLimitStore {
+	s.now = now
+	s.lastSweep = now()
+	return s
+}
+
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, policy entities.RateLimitPolicy) (entities.RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	entry, exists := s.buckets[key]
+	if !exists {
+		entry = &memoryBucket{bucket: entities.NewTokenBucket(policy, now), policy: policy}
+		s.buckets[key] = entry
+	}
+
+	return entry.bucket.Take(policy, now), nil
+}
+
+// sweep drops buckets that have refilled completely so idle keys do not
+// accumulate
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+
+	for key, entry := range s.buckets {
+		if entry.bucket.IsFull(entry.policy, now) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
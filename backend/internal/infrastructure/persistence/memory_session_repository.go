@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+type MemorySessionRepository struct {
+	sessions map[uuid.UUID]*entities.Session
+	mu       sync.RWMutex
+}
+
+func NewMemorySessionRepository() *MemorySessionRepository {
+	return &MemorySessionRepository{
+		sessions: make(map[uuid.UUID]*entities.Session),
+	}
+}
+
+func (r *MemorySessionRepository) Create(ctx context.Context, session *entities.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[session.ID] = session
+	return nil
+}
+
+func (r *MemorySessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, exists := r.sessions[id]
+	if !exists {
+		return nil, ErrSessionNotFound
+	}
+
+	copied := *session
+	return &copied, nil
+}
+
+func (r *MemorySessionRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.Session, 0)
+	for _, session := range r.sessions {
+		if session.UserID == userID {
+			copied := *session
+			result = append(result, &copied)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LastSeenAt.After(result[j].LastSeenAt)
+	})
+
+	return result, nil
+}
+
+func (r *MemorySessionRepository) Update(ctx context.Context, session *entities.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.sessions[session.ID]; !exists {
+		return ErrSessionNotFound
+	}
+
+	copied := *session
+	r.sessions[session.ID] = &copied
+	return nil
+}
+
+func (r *MemorySessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, session := range r.sessions {
+		if session.UserID == userID {
+			delete(r.sessions, id)
+		}
+	}
+	return nil
+}
+
+func (r *MemorySessionRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, session := range r.sessions {
+		if !now.Before(session.ExpiresAt) {
+			delete(r.sessions, id)
+		}
+	}
+	return nil
+}
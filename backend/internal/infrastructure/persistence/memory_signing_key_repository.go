@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrSigningKeyNotFound = errors.New("signing key not found")
+)
+
+type MemorySigningKeyRepository struct {
+	keys map[string]*entities.SigningKey
+	mu   sync.RWMutex
+}
+
+func NewMemorySigningKeyRepository() *MemorySigningKeyRepository {
+	return &MemorySigningKeyRepository{
+		keys: make(map[string]*entities.SigningKey),
+	}
+}
+
+func (r *MemorySigningKeyRepository) Create(ctx context.Context, key *entities.SigningKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys[key.ID] = key
+	return nil
+}
+
+func (r *MemorySigningKeyRepository) FindByID(ctx context.Context, id string) (*entities.SigningKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, exists := r.keys[id]
+	if !exists {
+		return nil, ErrSigningKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (r *MemorySigningKeyRepository) FindAll(ctx context.Context) ([]*entities.SigningKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.SigningKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		result = append(result, key)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *MemorySigningKeyRepository) Update(ctx context.Context, key *entities.SigningKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.keys[key.ID]; !exists {
+		return ErrSigningKeyNotFound
+	}
+
+	r.keys[key.ID] = key
+	return nil
+}
+
+func (r *MemorySigningKeyRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.keys, id)
+	return nil
+}
@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var ErrTextProcessingSettingsNotFound = errors.New("text processing settings not found")
+
+type MemoryTextProcessingSettingsRepository struct {
+	settings map[uuid.UUID]*entities.TextProcessingSettings
+	mu       sync.RWMutex
+}
+
+func NewMemoryTextProcessingSettingsRepository() *MemoryTextProcessingSettingsRepository {
+	return &MemoryTextProcessingSettingsRepository{
+		settings: make(map[uuid.UUID]*entities.TextProcessingSettings),
+	}
+}
+
+func (r *MemoryTextProcessingSettingsRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*entities.TextProcessingSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, exists := r.settings[userID]
+	if !exists {
+		return nil, ErrTextProcessingSettingsNotFound
+	}
+
+	copied := *settings
+	return &copied, nil
+}
+
+func (r *MemoryTextProcessingSettingsRepository) Save(ctx context.Context, settings *entities.TextProcessingSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *settings
+	r.settings[settings.UserID] = &copied
+	return nil
+}
+
+func (r *MemoryTextProcessingSettingsRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.settings, userID)
+	return nil
+}
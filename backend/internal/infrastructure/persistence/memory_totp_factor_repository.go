@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrTOTPFactorNotFound = errors.New("totp factor not found")
+)
+
+type MemoryTOTPFactorRepository struct {
+	factors map[uuid.UUID]*entities.TOTPFactor
+	mu      sync.RWMutex
+}
+
+func NewMemoryTOTPFactorRepository() *MemoryTOTPFactorRepository {
+	return &MemoryTOTPFactorRepository{
+		factors: make(map[uuid.UUID]*entities.TOTPFactor),
+	}
+}
+
+func (r *MemoryTOTPFactorRepository) Save(ctx context.Context, factor *entities.TOTPFactor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.factors[factor.UserID] = factor
+	return nil
+}
+
+func (r *MemoryTOTPFactorRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*entities.TOTPFactor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	factor, exists := r.factors[userID]
+	if !exists {
+		return nil, ErrTOTPFactorNotFound
+	}
+
+	return factor, nil
+}
+
+func (r *MemoryTOTPFactorRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.factors, userID)
+	return nil
+}
@@ -3,6 +3,7 @@ package persistence
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
 
 	"github.com/google/uuid"
@@ -16,6 +17,7 @@ var (
 type MemoryTranscriptionRepository struct {
 	transcriptions map[uuid.UUID]*entities.Transcription
 	userIndex      map[uuid.UUID][]uuid.UUID
+	hashIndex      map[string][]uuid.UUID
 	mu             sync.RWMutex
 }
 
@@ -23,6 +25,7 @@ func NewMemoryTranscriptionRepository() *MemoryTranscriptionRepository {
 	return &MemoryTranscriptionRepository{
 		transcriptions: make(map[uuid.UUID]*entities.Transcription),
 		userIndex:      make(map[uuid.UUID][]uuid.UUID),
+		hashIndex:      make(map[string][]uuid.UUID),
 	}
 }
 
@@ -32,6 +35,9 @@ func (r *MemoryTranscriptionRepository) Create(ctx context.Context, transcriptio
 
 	r.transcriptions[transcription.ID] = transcription
 	r.userIndex[transcription.UserID] = append(r.userIndex[transcription.UserID], transcription.ID)
+	if transcription.AudioHash != "" {
+		r.hashIndex[transcription.AudioHash] = append(r.hashIndex[transcription.AudioHash], transcription.ID)
+	}
 	return nil
 }
 
@@ -66,6 +72,39 @@ func (r *MemoryTranscriptionRepository) FindByUserID(ctx context.Context, userID
 	return transcriptions, nil
 }
 
+func (r *MemoryTranscriptionRepository) FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*entities.Transcription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transcriptions := make([]*entities.Transcription, 0)
+	for _, transcription := range r.transcriptions {
+		if transcription.BelongsToOrganization(organizationID) {
+			transcriptions = append(transcriptions, transcription)
+		}
+	}
+
+	sort.Slice(transcriptions, func(i, j int) bool {
+		return transcriptions[i].CreatedAt.Before(transcriptions[j].CreatedAt)
+	})
+
+	return transcriptions, nil
+}
+
+func (r *MemoryTranscriptionRepository) FindByAudioHash(ctx context.Context, audioHash string) ([]*entities.Transcription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.hashIndex[audioHash]
+	transcriptions := make([]*entities.Transcription, 0, len(ids))
+	for _, id := range ids {
+		if transcription, exists := r.transcriptions[id]; exists {
+			transcriptions = append(transcriptions, transcription)
+		}
+	}
+
+	return transcriptions, nil
+}
+
 func (r *MemoryTranscriptionRepository) Update(ctx context.Context, transcription *entities.Transcription) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -95,6 +134,14 @@ func (r *MemoryTranscriptionRepository) Delete(ctx context.Context, id uuid.UUID
 		}
 	}
 
+	hashIDs := r.hashIndex[transcription.AudioHash]
+	for i, tID := range hashIDs {
+		if tID == id {
+			r.hashIndex[transcription.AudioHash] = append(hashIDs[:i], hashIDs[i+1:]...)
+			break
+		}
+	}
+
 	delete(r.transcriptions, id)
 	return nil
 }
@@ -0,0 +1,230 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrTranscriptionShareNotFound = errors.New("transcription share not found")
+	ErrShareLinkNotFound          = errors.New("share link not found")
+)
+
+type MemoryTranscriptionShareRepository struct {
+	shares map[uuid.UUID]*entities.TranscriptionShare
+	mu     sync.RWMutex
+}
+
+func NewMemoryTranscriptionShareRepository() *MemoryTranscriptionShareRepository {
+	return &MemoryTranscriptionShareRepository{
+		shares: make(map[uuid.UUID]*entities.TranscriptionShare),
+	}
+}
+
+func (r *MemoryTranscriptionShareRepository) Create(ctx context.Context, share *entities.TranscriptionShare) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.shares[share.ID] = share
+	return nil
+}
+
+func (r *MemoryTranscriptionShareRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.TranscriptionShare, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	share, exists := r.shares[id]
+	if !exists {
+		return nil, ErrTranscriptionShareNotFound
+	}
+
+	return share, nil
+}
+
+func (r *MemoryTranscriptionShareRepository) Find(ctx context.Context, transcriptionID, userID uuid.UUID) (*entities.TranscriptionShare, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, share := range r.shares {
+		if share.TranscriptionID == transcriptionID && share.UserID == userID {
+			return share, nil
+		}
+	}
+
+	return nil, ErrTranscriptionShareNotFound
+}
+
+func (r *MemoryTranscriptionShareRepository) FindByTranscriptionID(ctx context.Context, transcriptionID uuid.UUID) ([]*entities.TranscriptionShare, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.TranscriptionShare, 0)
+	for _, share := range r.shares {
+		if share.TranscriptionID == transcriptionID {
+			result = append(result, share)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *MemoryTranscriptionShareRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.TranscriptionShare, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.TranscriptionShare, 0)
+	for _, share := range r.shares {
+		if share.UserID == userID {
+			result = append(result, share)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *MemoryTranscriptionShareRepository) FindPendingByEmail(ctx context.Context, email string) ([]*entities.TranscriptionShare, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.TranscriptionShare, 0)
+	for _, share := range r.shares {
+		if share.IsPending() && share.IsFor(email) {
+			result = append(result, share)
+		}
+	}
+	return result, nil
+}
+
+func (r *MemoryTranscriptionShareRepository) Update(ctx context.Context, share *entities.TranscriptionShare) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.shares[share.ID]; !exists {
+		return ErrTranscriptionShareNotFound
+	}
+
+	r.shares[share.ID] = share
+	return nil
+}
+
+func (r *MemoryTranscriptionShareRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.shares, id)
+	return nil
+}
+
+func (r *MemoryTranscriptionShareRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, share := range r.shares {
+		if share.OwnerID == userID || share.UserID == userID {
+			delete(r.shares, id)
+		}
+	}
+	return nil
+}
+
+type MemoryShareLinkRepository struct {
+	links     map[uuid.UUID]*entities.ShareLink
+	hashIndex map[string]uuid.UUID
+	mu        sync.RWMutex
+}
+
+func NewMemoryShareLinkRepository() *MemoryShareLinkRepository {
+	return &MemoryShareLinkRepository{
+		links:     make(map[uuid.UUID]*entities.ShareLink),
+		hashIndex: make(map[string]uuid.UUID),
+	}
+}
+
+func (r *MemoryShareLinkRepository) Create(ctx context.Context, link *entities.ShareLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.links[link.ID] = link
+	r.hashIndex[link.TokenHash] = link.ID
+	return nil
+}
+
+func (r *MemoryShareLinkRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.ShareLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	link, exists := r.links[id]
+	if !exists {
+		return nil, ErrShareLinkNotFound
+	}
+
+	return link, nil
+}
+
+func (r *MemoryShareLinkRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entities.ShareLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, exists := r.hashIndex[tokenHash]
+	if !exists {
+		return nil, ErrShareLinkNotFound
+	}
+
+	return r.links[id], nil
+}
+
+func (r *MemoryShareLinkRepository) FindByTranscriptionID(ctx context.Context, transcriptionID uuid.UUID) ([]*entities.ShareLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.ShareLink, 0)
+	for _, link := range r.links {
+		if link.TranscriptionID == transcriptionID {
+			result = append(result, link)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *MemoryShareLinkRepository) Update(ctx context.Context, link *entities.ShareLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.links[link.ID]; !exists {
+		return ErrShareLinkNotFound
+	}
+
+	r.links[link.ID] = link
+	return nil
+}
+
+func (r *MemoryShareLinkRepository) DeleteByOwnerID(ctx context.Context, ownerID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, link := range r.links {
+		if link.OwnerID == ownerID {
+			delete(r.hashIndex, link.TokenHash)
+			delete(r.links, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var ErrTranslationNotFound = errors.New("translation not found")
+
+type MemoryTranslationRepository struct {
+	translations map[uuid.UUID]*entities.Translation
+	mu           sync.RWMutex
+}
+
+func NewMemoryTranslationRepository() *MemoryTranslationRepository {
+	return &MemoryTranslationRepository{
+		translations: make(map[uuid.UUID]*entities.Translation),
+	}
+}
+
+func (r *MemoryTranslationRepository) Create(ctx context.Context, translation *entities.Translation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.translations[translation.ID] = translation
+	return nil
+}
+
+func (r *MemoryTranslationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Translation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	translation, exists := r.translations[id]
+	if !exists {
+		return nil, ErrTranslationNotFound
+	}
+
+	return translation, nil
+}
+
+func (r *MemoryTranslationRepository) FindByTranscriptionID(ctx context.Context, transcriptionID uuid.UUID) ([]*entities.Translation, error) {
+	return r.find(func(translation *entities.Translation) bool {
+		return translation.TranscriptionID == transcriptionID
+	}), nil
+}
+
+func (r *MemoryTranslationRepository) FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*entities.Translation, error) {
+	return r.find(func(translation *entities.Translation) bool {
+		return translation.OwnerID == ownerID
+	}), nil
+}
+
+func (r *MemoryTranslationRepository) DeleteByOwnerID(ctx context.Context, ownerID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, translation := range r.translations {
+		if translation.OwnerID == ownerID {
+			delete(r.translations, id)
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryTranslationRepository) find(match func(*entities.Translation) bool) []*entities.Translation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.Translation, 0)
+	for _, translation := range r.translations {
+		if match(translation) {
+			result = append(result, translation)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TranscriptionID != result[j].TranscriptionID {
+			return result[i].TranscriptionID.String() < result[j].TranscriptionID.String()
+		}
+		if result[i].Language != result[j].Language {
+			return result[i].Language < result[j].Language
+		}
+		return result[i].Version < result[j].Version
+	})
+
+	return result
+}
@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrUsageQuotaNotFound = errors.New("usage quota not found")
+)
+
+type MemoryUsageRepository struct {
+	records   map[uuid.UUID]*entities.UsageRecord
+	userIndex map[uuid.UUID][]uuid.UUID
+	mu        sync.RWMutex
+}
+
+func NewMemoryUsageRepository() *MemoryUsageRepository {
+	return &MemoryUsageRepository{
+		records:   make(map[uuid.UUID]*entities.UsageRecord),
+		userIndex: make(map[uuid.UUID][]uuid.UUID),
+	}
+}
+
+func (r *MemoryUsageRepository) Create(ctx context.Context, record *entities.UsageRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records[record.ID] = record
+	r.userIndex[record.UserID] = append(r.userIndex[record.UserID], record.ID)
+	return nil
+}
+
+func (r *MemoryUsageRepository) FindByUserIDSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]*entities.UsageRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make([]*entities.UsageRecord, 0)
+	for _, id := range r.userIndex[userID] {
+		if record, exists := r.records[id]; exists && !record.CreatedAt.Before(since) {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+type MemoryUsageQuotaRepository struct {
+	quotas map[uuid.UUID]*entities.UsageQuota
+	mu     sync.RWMutex
+}
+
+func NewMemoryUsageQuotaRepository() *MemoryUsageQuotaRepository {
+	return &MemoryUsageQuotaRepository{
+		quotas: make(map[uuid.UUID]*entities.UsageQuota),
+	}
+}
+
+func (r *MemoryUsageQuotaRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*entities.UsageQuota, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	quota, exists := r.quotas[userID]
+	if !exists {
+		return nil, ErrUsageQuotaNotFound
+	}
+
+	return quota, nil
+}
+
+func (r *MemoryUsageQuotaRepository) Save(ctx context.Context, quota *entities.UsageQuota) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.quotas[quota.UserID] = quota
+	return nil
+}
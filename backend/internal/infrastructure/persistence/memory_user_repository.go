@@ -3,14 +3,18 @@ package persistence
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
 	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
 )
 
 var (
 	ErrUserNotFound = errors.New("user not found")
+	ErrEmailTaken   = errors.New("email already in use")
 )
 
 type MemoryUserRepository struct {
@@ -72,6 +76,18 @@ func (r *MemoryUserRepository) Update(ctx context.Context, user *entities.User)
 		return ErrUserNotFound
 	}
 
+	if id, taken := r.emailIndex[user.Email]; taken && id != user.ID {
+		return ErrEmailTaken
+	}
+
+	// The stored user may share the caller's pointer, so find the previous
+	// address through the index rather than the stored entity
+	for email, id := range r.emailIndex {
+		if id == user.ID {
+			delete(r.emailIndex, email)
+		}
+	}
+	r.emailIndex[user.Email] = user.ID
 	r.users[user.ID] = user
 	return nil
 }
@@ -89,3 +105,40 @@ func (r *MemoryUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	delete(r.users, id)
 	return nil
 }
+
+func (r *MemoryUserRepository) Search(ctx context.Context, search repositories.UserSearch) ([]*entities.User, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	query := strings.ToLower(search.Query)
+	var matches []*entities.User
+	for _, user := range r.users {
+		if query != "" &&
+			!strings.Contains(strings.ToLower(user.Email), query) &&
+			!strings.Contains(strings.ToLower(user.Name), query) {
+			continue
+		}
+		if search.Role != "" && user.Role != search.Role {
+			continue
+		}
+		if search.Disabled != nil && user.Disabled != *search.Disabled {
+			continue
+		}
+		matches = append(matches, user)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt.Before(matches[j].CreatedAt)
+	})
+
+	total := len(matches)
+	if search.Offset >= total {
+		return []*entities.User{}, total, nil
+	}
+	matches = matches[search.Offset:]
+	if search.Limit > 0 && len(matches) > search.Limit {
+		matches = matches[:search.Limit]
+	}
+
+	return matches, total, nil
+}
@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrUserTokenNotFound = errors.New("user token not found")
+)
+
+type MemoryUserTokenRepository struct {
+	tokens map[string]*entities.UserToken
+	mu     sync.RWMutex
+}
+
+func NewMemoryUserTokenRepository() *MemoryUserTokenRepository {
+	return &MemoryUserTokenRepository{
+		tokens: make(map[string]*entities.UserToken),
+	}
+}
+
+func (r *MemoryUserTokenRepository) Create(ctx context.Context, token *entities.UserToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (r *MemoryUserTokenRepository) Consume(ctx context.Context, purpose entities.UserTokenPurpose, tokenHash string, now time.Time) (*entities.UserToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, exists := r.tokens[tokenHash]
+	if !exists || token.Purpose != purpose {
+		return nil, ErrUserTokenNotFound
+	}
+
+	if err := token.Use(now); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *MemoryUserTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose entities.UserTokenPurpose) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash, token := range r.tokens {
+		if token.UserID == userID && token.Purpose == purpose {
+			delete(r.tokens, hash)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+type MemoryWebhookRepository struct {
+	webhooks  map[uuid.UUID]*entities.Webhook
+	userIndex map[uuid.UUID][]uuid.UUID
+	mu        sync.RWMutex
+}
+
+func NewMemoryWebhookRepository() *MemoryWebhookRepository {
+	return &MemoryWebhookRepository{
+		webhooks:  make(map[uuid.UUID]*entities.Webhook),
+		userIndex: make(map[uuid.UUID][]uuid.UUID),
+	}
+}
+
+func (r *MemoryWebhookRepository) Create(ctx context.Context, webhook *entities.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.webhooks[webhook.ID] = webhook
+	r.userIndex[webhook.UserID] = append(r.userIndex[webhook.UserID], webhook.ID)
+	return nil
+}
+
+func (r *MemoryWebhookRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, exists := r.webhooks[id]
+	if !exists {
+		return nil, ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+func (r *MemoryWebhookRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.userIndex[userID]
+	webhooks := make([]*entities.Webhook, 0, len(ids))
+	for _, id := range ids {
+		if webhook, exists := r.webhooks[id]; exists {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks, nil
+}
+
+func (r *MemoryWebhookRepository) Update(ctx context.Context, webhook *entities.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.webhooks[webhook.ID]; !exists {
+		return ErrWebhookNotFound
+	}
+
+	r.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (r *MemoryWebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhook, exists := r.webhooks[id]
+	if !exists {
+		return ErrWebhookNotFound
+	}
+
+	ids := r.userIndex[webhook.UserID]
+	for i, wID := range ids {
+		if wID == id {
+			r.userIndex[webhook.UserID] = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+
+	delete(r.webhooks, id)
+	return nil
+}
+
+type MemoryWebhookDeliveryRepository struct {
+	deliveries   map[uuid.UUID]*entities.WebhookDelivery
+	webhookIndex map[uuid.UUID][]uuid.UUID
+	mu           sync.RWMutex
+}
+
+func NewMemoryWebhookDeliveryRepository() *MemoryWebhookDeliveryRepository {
+	return &MemoryWebhookDeliveryRepository{
+		deliveries:   make(map[uuid.UUID]*entities.WebhookDelivery),
+		webhookIndex: make(map[uuid.UUID][]uuid.UUID),
+	}
+}
+
+func (r *MemoryWebhookDeliveryRepository) Create(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries[delivery.ID] = delivery
+	r.webhookIndex[delivery.WebhookID] = append(r.webhookIndex[delivery.WebhookID], delivery.ID)
+	return nil
+}
+
+func (r *MemoryWebhookDeliveryRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delivery, exists := r.deliveries[id]
+	if !exists {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	return delivery, nil
+}
+
+// FindByWebhookID returns the deliveries of a webhook, newest first
+func (r *MemoryWebhookDeliveryRepository) FindByWebhookID(ctx context.Context, webhookID uuid.UUID) ([]*entities.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.webhookIndex[webhookID]
+	deliveries := make([]*entities.WebhookDelivery, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		if delivery, exists := r.deliveries[ids[i]]; exists {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	return deliveries, nil
+}
+
+func (r *MemoryWebhookDeliveryRepository) FindByEventID(ctx context.Context, eventID uuid.UUID) ([]*entities.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := make([]*entities.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.EventID == eventID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	return deliveries, nil
+}
+
+// FindDue returns pending deliveries whose next attempt is due, oldest first
+func (r *MemoryWebhookDeliveryRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	due := make([]*entities.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.IsDue(now) {
+			due = append(due, delivery)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (r *MemoryWebhookDeliveryRepository) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.deliveries[delivery.ID]; !exists {
+		return ErrWebhookDeliveryNotFound
+	}
+
+	r.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (r *MemoryWebhookDeliveryRepository) DeleteByWebhookID(ctx context.Context, webhookID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range r.webhookIndex[webhookID] {
+		delete(r.deliveries, id)
+	}
+	delete(r.webhookIndex, webhookID)
+	return nil
+}
@@ -0,0 +1,165 @@
+package summarizer
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+// LocalModel names the summaries produced by LocalSummarizer
+const LocalModel = "local-extractive"
+
+const (
+	summarySentences = 2
+	maxKeyPoints     = 5
+)
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"but": true, "by": true, "for": true, "from": true, "has": true, "have": true, "i": true,
+	"in": true, "is": true, "it": true, "its": true, "of": true, "on": true, "or": true,
+	"so": true, "that": true, "the": true, "this": true, "to": true, "was": true, "we": true,
+	"were": true, "will": true, "with": true, "you": true,
+}
+
+var actionCues = []string{
+	"need to", "needs to", "have to", "has to", "must ", "should ", "will ", "todo", "to-do",
+	"action item", "follow up", "let's", "please ", "remember to", "don't forget",
+}
+
+// LocalSummarizer is a deterministic extractive summarizer that needs no
+// external service. It picks the sentences whose words recur most across
+// the text and the sentences phrased as tasks.
+type LocalSummarizer struct{}
+
+func NewLocalSummarizer() *LocalSummarizer {
+	return &LocalSummarizer{}
+}
+
+func (s *LocalSummarizer) Summarize(ctx context.Context, text string) (*entities.Summary, error) {
+	sentences := splitSentences(text)
+	ranked := rankSentences(sentences)
+
+	summary := make([]int, 0, summarySentences)
+	for _, index := range ranked {
+		if len(summary) == summarySentences {
+			break
+		}
+		summary = append(summary, index)
+	}
+	sort.Ints(summary)
+
+	parts := make([]string, len(summary))
+	for i, index := range summary {
+		parts[i] = sentences[index]
+	}
+
+	keyPoints := make([]string, 0, maxKeyPoints)
+	for _, index := range ranked {
+		if len(keyPoints) == maxKeyPoints {
+			break
+		}
+		keyPoints = append(keyPoints, sentences[index])
+	}
+
+	actionItems := make([]string, 0)
+	for _, sentence := range sentences {
+		if isActionItem(sentence) {
+			actionItems = append(actionItems, sentence)
+		}
+	}
+
+	return &entities.Summary{
+		Text:        strings.Join(parts, " "),
+		KeyPoints:   keyPoints,
+		ActionItems: actionItems,
+		Model:       LocalModel,
+	}, nil
+}
+
+// splitSentences splits text after sentence ending punctuation
+func splitSentences(text string) []string {
+	sentences := make([]string, 0)
+	var current strings.Builder
+
+	runes := []rune(text)
+	for i, r := range runes {
+		current.WriteRune(r)
+		end := r == '.' || r == '!' || r == '?'
+		if end && (i == len(runes)-1 || unicode.IsSpace(runes[i+1])) {
+			if sentence := strings.TrimSpace(current.String()); sentence != "" {
+				sentences = append(sentences, sentence)
+			}
+			current.Reset()
+		}
+	}
+
+	if sentence := strings.TrimSpace(current.String()); sentence != "" {
+		sentences = append(sentences, sentence)
+	}
+
+	return sentences
+}
+
+// rankSentences returns sentence indexes by descending average frequency of
+// their content words, earlier sentences first on ties
+func rankSentences(sentences []string) []int {
+	frequencies := make(map[string]int)
+	words := make([][]string, len(sentences))
+	for i, sentence := range sentences {
+		words[i] = contentWords(sentence)
+		for _, word := range words[i] {
+			frequencies[word]++
+		}
+	}
+
+	scores := make([]float64, len(sentences))
+	for i := range sentences {
+		if len(words[i]) == 0 {
+			continue
+		}
+		total := 0
+		for _, word := range words[i] {
+			total += frequencies[word]
+		}
+		scores[i] = float64(total) / float64(len(words[i]))
+	}
+
+	ranked := make([]int, len(sentences))
+	for i := range ranked {
+		ranked[i] = i
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i]] > scores[ranked[j]]
+	})
+
+	return ranked
+}
+
+func contentWords(sentence string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(sentence), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !stopWords[field] {
+			words = append(words, field)
+		}
+	}
+	return words
+}
+
+func isActionItem(sentence string) bool {
+	lower := strings.ToLower(sentence) + " "
+	for _, cue := range actionCues {
+		if strings.Contains(lower, cue) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+// ErrAddressNotAllowed is returned for deliveries to addresses that are not
+// public, including host names that resolve to one after registration
+var ErrAddressNotAllowed = errors.New("webhook address is not public")
+
+// HTTPSender delivers webhook payloads over HTTP
+type HTTPSender struct {
+	client               *http.Client
+	allowPrivateNetworks bool
+}
+
+// NewHTTPSender creates a new HTTPSender whose requests time out after timeout.
+// It only connects to public addresses, which is checked on the resolved IP
+// of every connection, redirects included.
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	sender := &HTTPSender{}
+
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, conn syscall.RawConn) error {
+			if sender.allowPrivateNetworks {
+				return nil
+			}
+
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !entities.IsPublicIP(ip) {
+				return ErrAddressNotAllowed
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would make the dialer check the proxy instead of the receiver
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	sender.client = &http.Client{Timeout: timeout, Transport: transport}
+	return sender
+}
+
+// AllowPrivateNetworks lets deliveries reach loopback, link-local and private
+// addresses. Only for local development.
+func (s *HTTPSender) AllowPrivateNetworks() *HTTPSender {
+	s.allowPrivateNetworks = true
+	return s
+}
+
+func (s *HTTPSender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return resp.StatusCode, nil
+}
@@ -7,20 +7,64 @@ type RegisterRequestDTO struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=8"`
 	Name     string `json:"name" binding:"required"`
+	// DeviceName labels the session in the list of the user's logins
+	DeviceName string `json:"device_name" binding:"max=100"`
 }
 
 // LoginRequestDTO represents login request
 type LoginRequestDTO struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email      string `json:"email" binding:"required,email"`
+	Password   string `json:"password" binding:"required"`
+	DeviceName string `json:"device_name" binding:"max=100"`
+}
+
+// ConfirmEmailRequestDTO represents an email verification request
+type ConfirmEmailRequestDTO struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// PasswordResetRequestDTO represents a request for a password reset email
+type PasswordResetRequestDTO struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ConfirmPasswordResetRequestDTO represents a password reset with an emailed token
+type ConfirmPasswordResetRequestDTO struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// UpdateProfileRequestDTO represents a partial profile update request
+type UpdateProfileRequestDTO struct {
+	Name  *string `json:"name" binding:"omitempty,min=1"`
+	Email *string `json:"email" binding:"omitempty,email"`
+}
+
+// ChangePasswordRequestDTO represents a password change request
+// Accounts without a password confirm with a two-factor code or a fresh
+// login through their identity provider instead.
+type ChangePasswordRequestDTO struct {
+	CurrentPassword string `json:"current_password"`
+	MFACode         string `json:"mfa_code"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
+// DeleteAccountRequestDTO represents an account deletion request, confirmed
+// like a password change
+type DeleteAccountRequestDTO struct {
+	Password string `json:"password"`
+	MFACode  string `json:"mfa_code"`
 }
 
 // UserDTO represents user data transfer object
 type UserDTO struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"emailVerified"`
+	Name          string    `json:"name"`
+	Plan          string    `json:"plan"`
+	Role          string    `json:"role"`
+	CreatedAt     time.Time `json:"createdAt"`
 }
 
 // AuthResponseDTO represents authentication response
@@ -31,12 +75,44 @@ type AuthResponseDTO struct {
 
 // TranscriptionDTO represents transcription data transfer object
 type TranscriptionDTO struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Text      string    `json:"text"`
-	Status    string    `json:"status"`
-	Duration  float64   `json:"duration"`
-	CreatedAt time.Time `json:"created_at"`
+	ID             string            `json:"id"`
+	UserID         string            `json:"user_id"`
+	Text           string            `json:"text"`
+	RawText        string            `json:"raw_text,omitempty"`
+	Status         string            `json:"status"`
+	Duration       float64           `json:"duration"`
+	DuplicateOf    string            `json:"duplicate_of,omitempty"`
+	OrganizationID string            `json:"organization_id,omitempty"`
+	Title          string            `json:"title,omitempty"`
+	Folder         string            `json:"folder,omitempty"`
+	Tags           []string          `json:"tags,omitempty"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+	Segments       []SegmentDTO      `json:"segments,omitempty"`
+	Redacted       bool              `json:"redacted,omitempty"`
+	RedactedText   string            `json:"redacted_text,omitempty"`
+	PII            map[string]int    `json:"pii,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+// SegmentDTO is a stretch of the audio in seconds attributed to a speaker.
+// Speaker is the diarizer's label and SpeakerName the name users gave it.
+type SegmentDTO struct {
+	Start       float64 `json:"start"`
+	End         float64 `json:"end"`
+	Speaker     string  `json:"speaker"`
+	SpeakerName string  `json:"speaker_name,omitempty"`
+	Text        string  `json:"text"`
+}
+
+// SpeakerDTO represents a speaker of a diarized transcription
+type SpeakerDTO struct {
+	Label string `json:"label"`
+	Name  string `json:"name"`
+}
+
+// RenameSpeakerRequestDTO names a speaker. An empty name restores the label.
+type RenameSpeakerRequestDTO struct {
+	Name string `json:"name" binding:"max=100"`
 }
 
 // ErrorDTO represents error response
@@ -50,3 +126,458 @@ type HealthResponseDTO struct {
 	Status  string `json:"status"`
 	Version string `json:"version"`
 }
+
+// CreateWebhookRequestDTO represents webhook registration request
+type CreateWebhookRequestDTO struct {
+	URL    string   `json:"url" binding:"required,url"`
+	Events []string `json:"events" binding:"required,min=1"`
+}
+
+// UpdateWebhookRequestDTO represents a partial webhook update request
+type UpdateWebhookRequestDTO struct {
+	URL    *string  `json:"url" binding:"omitempty,url"`
+	Events []string `json:"events" binding:"omitempty,min=1"`
+	Active *bool    `json:"active"`
+}
+
+// WebhookDTO represents webhook data transfer object
+type WebhookDTO struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDeliveryDTO represents a webhook delivery log entry
+type WebhookDeliveryDTO struct {
+	ID             string     `json:"id"`
+	WebhookID      string     `json:"webhook_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	RedeliveryOf   string     `json:"redelivery_of,omitempty"`
+	Payload        string     `json:"payload"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// UsageDTO represents a user's consumption in the current billing period
+type UsageDTO struct {
+	Plan             string    `json:"plan"`
+	PeriodStart      time.Time `json:"period_start"`
+	PeriodEnd        time.Time `json:"period_end"`
+	QuotaMinutes     float64   `json:"quota_minutes"`
+	UsedMinutes      float64   `json:"used_minutes"`
+	RemainingMinutes float64   `json:"remaining_minutes"`
+	AudioSeconds     float64   `json:"audio_seconds"`
+	EstimatedCost    float64   `json:"estimated_cost"`
+	Transcriptions   int       `json:"transcriptions"`
+	Summaries        int       `json:"summaries"`
+	Translations     int       `json:"translations"`
+}
+
+// ExportJobDTO represents a data export job
+type ExportJobDTO struct {
+	ID                string     `json:"id"`
+	Status            string     `json:"status"`
+	Size              int64      `json:"size,omitempty"`
+	Error             string     `json:"error,omitempty"`
+	DownloadURL       string     `json:"download_url,omitempty"`
+	DownloadExpiresAt *time.Time `json:"download_expires_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+}
+
+// SetRoleRequestDTO represents an admin request to change a user's role
+type SetRoleRequestDTO struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// AdminActionRequestDTO carries the optional reason recorded in the audit log
+type AdminActionRequestDTO struct {
+	Reason string `json:"reason"`
+}
+
+// AdminUserDTO represents a user as seen by staff
+type AdminUserDTO struct {
+	ID            string     `json:"id"`
+	Email         string     `json:"email"`
+	EmailVerified bool       `json:"email_verified"`
+	Name          string     `json:"name"`
+	Plan          string     `json:"plan"`
+	Role          string     `json:"role"`
+	Disabled      bool       `json:"disabled"`
+	DisabledAt    *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// AdminUserListDTO represents a page of users
+type AdminUserListDTO struct {
+	Users  []*AdminUserDTO `json:"users"`
+	Total  int             `json:"total"`
+	Offset int             `json:"offset"`
+	Limit  int             `json:"limit"`
+}
+
+// AuditLogEntryDTO represents an audit log entry
+type AuditLogEntryDTO struct {
+	ID         string            `json:"id"`
+	ActorID    string            `json:"actor_id"`
+	ActorRole  string            `json:"actor_role"`
+	Action     string            `json:"action"`
+	TargetType string            `json:"target_type"`
+	TargetID   string            `json:"target_id"`
+	Details    map[string]string `json:"details,omitempty"`
+	IP         string            `json:"ip,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// CreateAPIKeyRequestDTO represents an API key creation request
+type CreateAPIKeyRequestDTO struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyDTO represents a personal API key. Key is only set in the response
+// that creates it.
+type APIKeyDTO struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Key        string     `json:"key,omitempty"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// JWKDTO represents a public key in JSON Web Key format (RFC 7517)
+type JWKDTO struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKSetDTO represents the keys that verify access tokens
+type JWKSetDTO struct {
+	Keys []*JWKDTO `json:"keys"`
+}
+
+// OIDCProviderListDTO represents the identity providers users can log in with
+type OIDCProviderListDTO struct {
+	Providers []string `json:"providers"`
+}
+
+// OIDCAuthorizationDTO represents where to send the user to log in at an
+// identity provider
+type OIDCAuthorizationDTO struct {
+	AuthorizationURL string    `json:"authorization_url"`
+	State            string    `json:"state"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// OIDCCallbackRequestDTO represents the parameters an identity provider
+// returned to the redirect URL
+type OIDCCallbackRequestDTO struct {
+	Code       string `json:"code" binding:"required"`
+	State      string `json:"state" binding:"required"`
+	DeviceName string `json:"device_name" binding:"max=100"`
+}
+
+// MFAChallengeDTO is the login response of users with two-factor
+// authentication, to be exchanged for a token at /auth/mfa/verify
+type MFAChallengeDTO struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+}
+
+// VerifyMFARequestDTO represents the second step of a two-factor login
+type VerifyMFARequestDTO struct {
+	MFAToken   string `json:"mfa_token" binding:"required"`
+	Code       string `json:"code" binding:"required"`
+	DeviceName string `json:"device_name" binding:"max=100"`
+}
+
+// MFACodeRequestDTO carries a code from the authenticator app or a recovery code
+type MFACodeRequestDTO struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MFAStatusDTO represents a user's two-factor authentication settings
+type MFAStatusDTO struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// MFAEnrollmentDTO represents a new authenticator app secret. ProvisioningURI
+// is usually shown as a QR code.
+type MFAEnrollmentDTO struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// RecoveryCodesDTO represents freshly generated recovery codes, shown once
+type RecoveryCodesDTO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// SessionDTO represents a device the user is logged in on. Current marks the
+// session of the token making the request.
+type SessionDTO struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// OrganizationDTO represents an organization with the requesting user's role
+type OrganizationDTO struct {
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	Role              string    `json:"role"`
+	RedactionRequired bool      `json:"redaction_required"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// OrganizationRequestDTO represents an organization create or update request
+type OrganizationRequestDTO struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// RedactionPolicyRequestDTO turns an organization's redaction policy on or off
+type RedactionPolicyRequestDTO struct {
+	Required *bool `json:"required" binding:"required"`
+}
+
+// OrganizationMemberDTO represents a member of an organization
+type OrganizationMemberDTO struct {
+	UserID   string    `json:"user_id"`
+	Email    string    `json:"email"`
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// UpdateMemberRoleRequestDTO represents a change of a member's role
+type UpdateMemberRoleRequestDTO struct {
+	Role string `json:"role" binding:"required,oneof=owner admin member"`
+}
+
+// CreateInvitationRequestDTO represents an invitation to join an
+// organization. Role defaults to member.
+type CreateInvitationRequestDTO struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"omitempty,oneof=owner admin member"`
+}
+
+// OrganizationInvitationDTO represents a pending invitation
+type OrganizationInvitationDTO struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AcceptInvitationRequestDTO carries the token of an emailed invitation
+type AcceptInvitationRequestDTO struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UpdateTranscriptionRequestDTO represents a correction of a transcription's
+// text and changes to its labels. Omitted fields are left unchanged and
+// metadata replaces all entries.
+type UpdateTranscriptionRequestDTO struct {
+	Text     *string           `json:"text"`
+	Title    *string           `json:"title"`
+	Folder   *string           `json:"folder"`
+	Tags     *[]string         `json:"tags"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// TagDTO represents a tag with the number of transcriptions carrying it
+type TagDTO struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// RenameTagRequestDTO renames a tag, merging it when the new name exists
+type RenameTagRequestDTO struct {
+	Name string `json:"name" binding:"required,max=50"`
+}
+
+// MergeTagsRequestDTO replaces Tags with Into on every transcription
+type MergeTagsRequestDTO struct {
+	Tags []string `json:"tags" binding:"required,min=1"`
+	Into string   `json:"into" binding:"required,max=50"`
+}
+
+// TagUpdateDTO reports how many transcriptions a tag operation changed
+type TagUpdateDTO struct {
+	Updated int `json:"updated"`
+}
+
+// ShareTranscriptionRequestDTO grants the account with the address Email
+// access to a transcription. Permission defaults to read.
+type ShareTranscriptionRequestDTO struct {
+	Email      string `json:"email" binding:"required,email"`
+	Permission string `json:"permission" binding:"omitempty,oneof=read edit"`
+}
+
+// TranscriptionShareDTO represents an email address a transcription is
+// shared with. It does not tell whether the address belongs to an account.
+type TranscriptionShareDTO struct {
+	ID         string    `json:"id"`
+	Email      string    `json:"email"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CreateShareLinkRequestDTO represents a public link request. Links without
+// an expiry stay valid until revoked.
+type CreateShareLinkRequestDTO struct {
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ShareLinkDTO represents a public read-only link. URL is only returned when
+// the link is created.
+type ShareLinkDTO struct {
+	ID        string     `json:"id"`
+	URL       string     `json:"url,omitempty"`
+	Active    bool       `json:"active"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// SharedTranscriptionDTO represents a transcription opened through a public
+// link, without its owner
+type SharedTranscriptionDTO struct {
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	Status    string    `json:"status"`
+	Duration  float64   `json:"duration"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AnchorDTO locates a comment or highlight by audio time range in seconds
+// and/or text span in characters. Quote is the spanned text at the time the
+// annotation was made.
+type AnchorDTO struct {
+	StartTime *float64 `json:"start_time,omitempty"`
+	EndTime   *float64 `json:"end_time,omitempty"`
+	TextStart *int     `json:"text_start,omitempty"`
+	TextEnd   *int     `json:"text_end,omitempty"`
+	Quote     string   `json:"quote,omitempty"`
+}
+
+// CreateCommentRequestDTO starts a thread, optionally anchored, or replies
+// to the thread given by ParentID
+type CreateCommentRequestDTO struct {
+	Body     string     `json:"body" binding:"required,max=5000"`
+	ParentID string     `json:"parent_id" binding:"omitempty,uuid"`
+	Anchor   *AnchorDTO `json:"anchor"`
+}
+
+// UpdateCommentRequestDTO represents an edit of a comment
+type UpdateCommentRequestDTO struct {
+	Body string `json:"body" binding:"required,max=5000"`
+}
+
+// CommentDTO represents a comment. Top-level comments carry their replies.
+type CommentDTO struct {
+	ID         string        `json:"id"`
+	ParentID   string        `json:"parent_id,omitempty"`
+	UserID     string        `json:"user_id"`
+	Body       string        `json:"body"`
+	Anchor     *AnchorDTO    `json:"anchor,omitempty"`
+	Resolved   bool          `json:"resolved"`
+	ResolvedAt *time.Time    `json:"resolved_at,omitempty"`
+	ResolvedBy string        `json:"resolved_by,omitempty"`
+	Replies    []*CommentDTO `json:"replies,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+// CreateHighlightRequestDTO represents a highlight of an anchored part of a
+// transcription. Color defaults to yellow.
+type CreateHighlightRequestDTO struct {
+	Anchor AnchorDTO `json:"anchor"`
+	Color  string    `json:"color" binding:"omitempty,oneof=yellow green blue pink orange"`
+	Note   string    `json:"note" binding:"max=1000"`
+}
+
+// HighlightDTO represents a highlight
+type HighlightDTO struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Anchor    AnchorDTO `json:"anchor"`
+	Color     string    `json:"color"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SummaryDTO represents the generated summary of a transcription. Stale
+// summaries were generated before the latest text edit.
+type SummaryDTO struct {
+	Summary     string    `json:"summary"`
+	KeyPoints   []string  `json:"key_points"`
+	ActionItems []string  `json:"action_items"`
+	Model       string    `json:"model"`
+	Stale       bool      `json:"stale"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// TranslationDTO represents a version of a transcription's text in another
+// language
+type TranslationDTO struct {
+	ID              string       `json:"id"`
+	TranscriptionID string       `json:"transcription_id"`
+	Language        string       `json:"language"`
+	Version         int          `json:"version"`
+	Text            string       `json:"text"`
+	Segments        []SegmentDTO `json:"segments,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
+// TextReplacementDTO is a find and replace rule for transcript text
+type TextReplacementDTO struct {
+	Find    string `json:"find"`
+	Replace string `json:"replace"`
+}
+
+// TextProcessingDTO represents a user's text processing stages, in the order
+// they run, and replacement rules
+type TextProcessingDTO struct {
+	Stages          []string             `json:"stages"`
+	Replacements    []TextReplacementDTO `json:"replacements"`
+	AvailableStages []string             `json:"available_stages"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+}
+
+// TextProcessingRequestDTO replaces a user's text processing stages and
+// replacement rules
+type TextProcessingRequestDTO struct {
+	Stages       []string             `json:"stages"`
+	Replacements []TextReplacementDTO `json:"replacements"`
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// AccountHandler handles email verification and password reset requests
+type AccountHandler struct {
+	accountService *services.AccountService
+	userMapper     *mappers.UserMapper
+}
+
+// NewAccountHandler creates a new AccountHandler
+func NewAccountHandler(accountService *services.AccountService, userMapper *mappers.UserMapper) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+		userMapper:     userMapper,
+	}
+}
+
+// RequestEmailVerification sends the authenticated user a new verification link
+func (h *AccountHandler) RequestEmailVerification(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	if err := h.accountService.RequestEmailVerification(c.Request.Context(), userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ConfirmEmail verifies the email address a token was sent to
+func (h *AccountHandler) ConfirmEmail(c *gin.Context) {
+	var req dto.ConfirmEmailRequestDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	user, err := h.accountService.ConfirmEmail(c.Request.Context(), req.Token)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.userMapper.ToDTO(user))
+}
+
+// RequestPasswordReset emails a reset link if the address belongs to an account
+func (h *AccountHandler) RequestPasswordReset(c *gin.Context) {
+	var req dto.PasswordResetRequestDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	if err := h.accountService.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ConfirmPasswordReset sets a new password using an emailed token
+func (h *AccountHandler) ConfirmPasswordReset(c *gin.Context) {
+	var req dto.ConfirmPasswordResetRequestDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	err := h.accountService.ResetPassword(c.Request.Context(), services.ResetPasswordInput{
+		Token:    req.Token,
+		Password: req.Password,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AccountHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrInvalidToken:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_TOKEN"
+	case services.ErrEmailAlreadyVerified:
+		statusCode = http.StatusConflict
+		code = "EMAIL_ALREADY_VERIFIED"
+	case services.ErrUserNotFound:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case entities.ErrInvalidPassword:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_REQUEST"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
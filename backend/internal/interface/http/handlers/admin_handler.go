@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// AdminHandler handles staff requests on other users' accounts and data
+type AdminHandler struct {
+	adminService        *services.AdminService
+	adminMapper         *mappers.AdminMapper
+	transcriptionMapper *mappers.TranscriptionMapper
+	usageMapper         *mappers.UsageMapper
+}
+
+// NewAdminHandler creates a new AdminHandler
+func NewAdminHandler(
+	adminService *services.AdminService,
+	adminMapper *mappers.AdminMapper,
+	transcriptionMapper *mappers.TranscriptionMapper,
+	usageMapper *mappers.UsageMapper,
+) *AdminHandler {
+	return &AdminHandler{
+		adminService:        adminService,
+		adminMapper:         adminMapper,
+		transcriptionMapper: transcriptionMapper,
+		usageMapper:         usageMapper,
+	}
+}
+
+// SearchUsers lists users matching the q, role and disabled query parameters
+func (h *AdminHandler) SearchUsers(c *gin.Context) {
+	input := services.SearchUsersInput{
+		Query: c.Query("q"),
+		Role:  c.Query("role"),
+	}
+
+	if value := c.Query("disabled"); value != "" {
+		disabled, err := strconv.ParseBool(value)
+		if err != nil {
+			respondInvalidQuery(c, "disabled")
+			return
+		}
+		input.Disabled = &disabled
+	}
+
+	var ok bool
+	if input.Offset, ok = queryInt(c, "offset"); !ok {
+		return
+	}
+	if input.Limit, ok = queryInt(c, "limit"); !ok {
+		return
+	}
+
+	page, err := h.adminService.SearchUsers(c.Request.Context(), input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.adminMapper.ToUserListDTO(page))
+}
+
+// GetUser returns any user's account
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	actor, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminService.GetUser(c.Request.Context(), actor, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.adminMapper.ToUserDTO(user))
+}
+
+// SetRole changes a user's role
+func (h *AdminHandler) SetRole(c *gin.Context) {
+	actor, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SetRoleRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	user, err := h.adminService.SetRole(c.Request.Context(), actor, id, req.Role)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.adminMapper.ToUserDTO(user))
+}
+
+// DisableUser blocks a user's account
+func (h *AdminHandler) DisableUser(c *gin.Context) {
+	actor, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindAdminAction(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminService.DisableUser(c.Request.Context(), actor, id, req.Reason)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.adminMapper.ToUserDTO(user))
+}
+
+// EnableUser unblocks a disabled account
+func (h *AdminHandler) EnableUser(c *gin.Context) {
+	actor, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminService.EnableUser(c.Request.Context(), actor, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.adminMapper.ToUserDTO(user))
+}
+
+// ResetQuota discards a user's consumption in the current billing period
+func (h *AdminHandler) ResetQuota(c *gin.Context) {
+	actor, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindAdminAction(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.adminService.ResetQuota(c.Request.Context(), actor, id, req.Reason)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.usageMapper.ToDTO(summary))
+}
+
+// GetUserTranscriptions lists any user's transcriptions
+func (h *AdminHandler) GetUserTranscriptions(c *gin.Context) {
+	actor, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	transcriptions, err := h.adminService.GetUserTranscriptions(c.Request.Context(), actor, id, c.Query("reason"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.transcriptionMapper.ToDTOs(transcriptions))
+}
+
+// GetTranscription returns any user's transcription
+func (h *AdminHandler) GetTranscription(c *gin.Context) {
+	actor, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	transcription, err := h.adminService.GetTranscription(c.Request.Context(), actor, id, c.Query("reason"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.transcriptionMapper.ToDTO(transcription))
+}
+
+// GetAuditLog lists audit log entries, filtered by actor_id and target_id
+func (h *AdminHandler) GetAuditLog(c *gin.Context) {
+	var input services.SearchAuditLogInput
+
+	for param, target := range map[string]*uuid.UUID{"actor_id": &input.ActorID, "target_id": &input.TargetID} {
+		if value := c.Query(param); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				respondInvalidQuery(c, param)
+				return
+			}
+			*target = id
+		}
+	}
+
+	var ok bool
+	if input.Limit, ok = queryInt(c, "limit"); !ok {
+		return
+	}
+
+	entries, err := h.adminService.SearchAuditLog(c.Request.Context(), input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.adminMapper.ToAuditLogDTOs(entries))
+}
+
+// parseRequest reads the acting staff member and the :id path parameter
+func (h *AdminHandler) parseRequest(c *gin.Context) (services.Actor, uuid.UUID, bool) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return services.Actor{}, uuid.Nil, false
+	}
+
+	role, _ := middleware.GetUserRoleFromContext(c)
+	actor := services.Actor{ID: userID, Role: role, IP: c.ClientIP()}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return services.Actor{}, uuid.Nil, false
+	}
+
+	return actor, id, true
+}
+
+// bindAdminAction reads the optional request body of an admin action
+func bindAdminAction(c *gin.Context) (dto.AdminActionRequestDTO, bool) {
+	var req dto.AdminActionRequestDTO
+	if c.Request.ContentLength == 0 {
+		return req, true
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return req, false
+	}
+	return req, true
+}
+
+func queryInt(c *gin.Context, name string) (int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, true
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		respondInvalidQuery(c, name)
+		return 0, false
+	}
+	return n, true
+}
+
+func respondInvalidQuery(c *gin.Context, name string) {
+	c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+		Message: "Invalid query parameter: " + name,
+		Code:    "INVALID_REQUEST",
+	})
+}
+
+func (h *AdminHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrUserNotFound, services.ErrTranscriptionNotFound:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case entities.ErrInvalidRole:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_REQUEST"
+	case services.ErrCannotModifySelf:
+		statusCode = http.StatusConflict
+		code = "CANNOT_MODIFY_SELF"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
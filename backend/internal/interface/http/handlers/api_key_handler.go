@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// APIKeyHandler handles personal API key management requests
+type APIKeyHandler struct {
+	apiKeyService *services.APIKeyService
+	apiKeyMapper  *mappers.APIKeyMapper
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler
+func NewAPIKeyHandler(apiKeyService *services.APIKeyService, apiKeyMapper *mappers.APIKeyMapper) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		apiKeyMapper:  apiKeyMapper,
+	}
+}
+
+// CreateAPIKey issues a new API key for the authenticated user
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	var req dto.CreateAPIKeyRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	key, secret, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), services.CreateAPIKeyInput{
+		UserID:    userID,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.apiKeyMapper.ToCreatedDTO(key, secret))
+}
+
+// GetAPIKeys lists the authenticated user's API keys
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	keys, err := h.apiKeyService.GetUserAPIKeys(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.apiKeyMapper.ToDTOs(keys))
+}
+
+// RevokeAPIKey revokes one of the authenticated user's API keys
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid API key ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	if _, err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), id, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *APIKeyHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrAPIKeyNotFound:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case services.ErrUnauthorizedAPIKey:
+		statusCode = http.StatusForbidden
+		code = "FORBIDDEN"
+	case entities.ErrInvalidAPIKeyName, entities.ErrInvalidAPIKeyScope, entities.ErrNoAPIKeyScopes, entities.ErrInvalidAPIKeyExpiry:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_REQUEST"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
 	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
 	"github.com/voiceline/backend/internal/interface/mappers"
 )
 
@@ -36,9 +41,12 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	output, err := h.authService.Register(c.Request.Context(), services.RegisterInput{
-		Email:    req.Email,
-		Password: req.Password,
-		Name:     req.Name,
+		Email:      req.Email,
+		Password:   req.Password,
+		Name:       req.Name,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		DeviceName: req.DeviceName,
 	})
 
 	if err != nil {
@@ -74,17 +82,86 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	output, err := h.authService.Login(c.Request.Context(), services.LoginInput{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:      req.Email,
+		Password:   req.Password,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		DeviceName: req.DeviceName,
 	})
 
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		code := "INTERNAL_ERROR"
 
-		if err == services.ErrInvalidCredentials {
+		var throttled *services.LoginThrottledError
+		switch {
+		case err == services.ErrInvalidCredentials:
 			statusCode = http.StatusUnauthorized
 			code = "INVALID_CREDENTIALS"
+		case err == services.ErrAccountDisabled:
+			statusCode = http.StatusForbidden
+			code = "ACCOUNT_DISABLED"
+		case errors.As(err, &throttled):
+			statusCode = http.StatusTooManyRequests
+			code = "TOO_MANY_LOGIN_ATTEMPTS"
+			c.Header(middleware.RetryAfterHeader, strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		}
+
+		c.JSON(statusCode, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    code,
+		})
+		return
+	}
+
+	if output.MFAToken != "" {
+		c.JSON(http.StatusOK, dto.MFAChallengeDTO{MFARequired: true, MFAToken: output.MFAToken})
+		return
+	}
+
+	response := h.userMapper.ToAuthResponseDTO(output.Token, output.User)
+	c.JSON(http.StatusOK, response)
+}
+
+// VerifyMFA completes a two-factor login
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req dto.VerifyMFARequestDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	output, err := h.authService.VerifyMFA(c.Request.Context(), services.VerifyMFAInput{
+		MFAToken:   req.MFAToken,
+		Code:       req.Code,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		DeviceName: req.DeviceName,
+	})
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		code := "INTERNAL_ERROR"
+
+		var throttled *services.LoginThrottledError
+		switch {
+		case err == services.ErrInvalidMFAChallenge:
+			statusCode = http.StatusUnauthorized
+			code = "INVALID_MFA_CHALLENGE"
+		case err == entities.ErrInvalidMFACode:
+			statusCode = http.StatusUnauthorized
+			code = "INVALID_MFA_CODE"
+		case err == services.ErrAccountDisabled:
+			statusCode = http.StatusForbidden
+			code = "ACCOUNT_DISABLED"
+		case errors.As(err, &throttled):
+			statusCode = http.StatusTooManyRequests
+			code = "TOO_MANY_LOGIN_ATTEMPTS"
+			c.Header(middleware.RetryAfterHeader, strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
 		}
 
 		c.JSON(statusCode, dto.ErrorDTO{
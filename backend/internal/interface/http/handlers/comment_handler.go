@@ -0,0 +1,350 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// CommentHandler handles comment and highlight requests
+type CommentHandler struct {
+	commentService *services.CommentService
+	commentMapper  *mappers.CommentMapper
+}
+
+// NewCommentHandler creates a new CommentHandler
+func NewCommentHandler(commentService *services.CommentService, commentMapper *mappers.CommentMapper) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+		commentMapper:  commentMapper,
+	}
+}
+
+// CreateComment starts a comment thread on a transcription or replies to one
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateCommentRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	input := services.AddCommentInput{
+		TranscriptionID: id,
+		UserID:          userID,
+		Body:            req.Body,
+		Anchor:          toAnchorInput(req.Anchor),
+	}
+	if req.ParentID != "" {
+		parentID := uuid.MustParse(req.ParentID)
+		input.ParentID = &parentID
+	}
+
+	comment, err := h.commentService.AddComment(c.Request.Context(), input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.commentMapper.ToDTO(comment))
+}
+
+// GetComments lists the comment threads of a transcription. The resolved
+// query parameter filters threads by state.
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	var resolved *bool
+	if value := c.Query("resolved"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+				Message: "resolved must be true or false",
+				Code:    "INVALID_REQUEST",
+			})
+			return
+		}
+		resolved = &parsed
+	}
+
+	threads, err := h.commentService.GetThreads(c.Request.Context(), id, userID, resolved)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.commentMapper.ToThreadDTOs(threads))
+}
+
+// UpdateComment edits the body of the authenticated user's comment
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, commentID, ok := h.parseCommentIDs(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateCommentRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	comment, err := h.commentService.EditComment(c.Request.Context(), id, commentID, userID, req.Body)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.commentMapper.ToDTO(comment))
+}
+
+// DeleteComment removes a comment, or a whole thread
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, commentID, ok := h.parseCommentIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.commentService.DeleteComment(c.Request.Context(), id, commentID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResolveComment marks a thread as resolved
+func (h *CommentHandler) ResolveComment(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, commentID, ok := h.parseCommentIDs(c)
+	if !ok {
+		return
+	}
+
+	comment, err := h.commentService.ResolveComment(c.Request.Context(), id, commentID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.commentMapper.ToDTO(comment))
+}
+
+// UnresolveComment reopens a resolved thread
+func (h *CommentHandler) UnresolveComment(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, commentID, ok := h.parseCommentIDs(c)
+	if !ok {
+		return
+	}
+
+	comment, err := h.commentService.UnresolveComment(c.Request.Context(), id, commentID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.commentMapper.ToDTO(comment))
+}
+
+// CreateHighlight highlights part of a transcription
+func (h *CommentHandler) CreateHighlight(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateHighlightRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	highlight, err := h.commentService.AddHighlight(c.Request.Context(), services.AddHighlightInput{
+		TranscriptionID: id,
+		UserID:          userID,
+		Anchor:          toAnchorInput(&req.Anchor),
+		Color:           req.Color,
+		Note:            req.Note,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.commentMapper.ToHighlightDTO(highlight))
+}
+
+// GetHighlights lists the highlights of a transcription
+func (h *CommentHandler) GetHighlights(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	highlights, err := h.commentService.GetHighlights(c.Request.Context(), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.commentMapper.ToHighlightDTOs(highlights))
+}
+
+// DeleteHighlight removes a highlight
+func (h *CommentHandler) DeleteHighlight(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	highlightID, ok := h.parseID(c, "highlightId", "Invalid highlight ID")
+	if !ok {
+		return
+	}
+
+	if err := h.commentService.DeleteHighlight(c.Request.Context(), id, highlightID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func toAnchorInput(anchor *dto.AnchorDTO) services.AnchorInput {
+	if anchor == nil {
+		return services.AnchorInput{}
+	}
+
+	return services.AnchorInput{
+		StartTime: anchor.StartTime,
+		EndTime:   anchor.EndTime,
+		TextStart: anchor.TextStart,
+		TextEnd:   anchor.TextEnd,
+	}
+}
+
+func (h *CommentHandler) parseCommentIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	commentID, ok := h.parseID(c, "commentId", "Invalid comment ID")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, commentID, true
+}
+
+func (h *CommentHandler) parseID(c *gin.Context, param, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: message,
+			Code:    "INVALID_REQUEST",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *CommentHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrTranscriptionNotFound, services.ErrCommentNotFound, services.ErrHighlightNotFound:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case services.ErrUnauthorizedAccess, services.ErrCommentForbidden:
+		statusCode = http.StatusForbidden
+		code = "FORBIDDEN"
+	case entities.ErrInvalidCommentBody, entities.ErrInvalidAnchor, entities.ErrAnchorRequired,
+		entities.ErrReplyAnchored, entities.ErrResolveReply, entities.ErrInvalidHighlightNote,
+		entities.ErrInvalidHighlightColor:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_REQUEST"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
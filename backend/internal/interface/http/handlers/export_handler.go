@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// ExportHandler handles personal data export requests
+type ExportHandler struct {
+	exportService *services.ExportService
+	exportMapper  *mappers.ExportMapper
+}
+
+// NewExportHandler creates a new ExportHandler
+func NewExportHandler(exportService *services.ExportService, exportMapper *mappers.ExportMapper) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		exportMapper:  exportMapper,
+	}
+}
+
+// RequestExport queues an export of the authenticated user's data
+func (h *ExportHandler) RequestExport(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	job, err := h.exportService.RequestExport(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, h.exportMapper.ToDTO(job, nil))
+}
+
+// GetExports lists the authenticated user's exports
+func (h *ExportHandler) GetExports(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	jobs, err := h.exportService.GetUserExports(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.exportMapper.ToDTOs(jobs))
+}
+
+// GetExport returns an export with a fresh signed download link once ready
+func (h *ExportHandler) GetExport(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.exportService.GetExport(c.Request.Context(), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	link, err := h.exportService.SignDownload(job)
+	if err != nil && err != services.ErrExportNotReady {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.exportMapper.ToDTO(job, link))
+}
+
+// Download streams an export archive to the holder of a valid signed link
+func (h *ExportHandler) Download(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		h.respondError(c, services.ErrInvalidDownloadLink)
+		return
+	}
+
+	job, archive, err := h.exportService.OpenDownload(c.Request.Context(), id, expires, c.Query("signature"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	defer archive.Close()
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="voiceline-export-%s.zip"`, job.ID))
+	c.Header("Cache-Control", "no-store")
+	c.DataFromReader(http.StatusOK, job.Size, "application/zip", archive, nil)
+}
+
+func (h *ExportHandler) parseID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid export ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *ExportHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrExportNotFound, services.ErrUserNotFound:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case services.ErrUnauthorizedExport, services.ErrInvalidDownloadLink:
+		statusCode = http.StatusForbidden
+		code = "FORBIDDEN"
+	case services.ErrExportInProgress:
+		statusCode = http.StatusConflict
+		code = "EXPORT_IN_PROGRESS"
+	case services.ErrExportNotReady:
+		statusCode = http.StatusGone
+		code = "EXPORT_NOT_AVAILABLE"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// JWKSHandler publishes the public keys that verify access tokens
+type JWKSHandler struct {
+	keyService *services.SigningKeyService
+	jwkMapper  *mappers.JWKMapper
+}
+
+// NewJWKSHandler creates a new JWKSHandler
+func NewJWKSHandler(keyService *services.SigningKeyService, jwkMapper *mappers.JWKMapper) *JWKSHandler {
+	return &JWKSHandler{
+		keyService: keyService,
+		jwkMapper:  jwkMapper,
+	}
+}
+
+// GetJWKS returns the active and retired keys that may still verify tokens.
+// Clients may cache the set briefly and refetch it on an unknown kid.
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	keys, err := h.keyService.VerificationKeys(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwkMapper.ToSetDTO(keys))
+}
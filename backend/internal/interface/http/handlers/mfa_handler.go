@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+)
+
+// MFAHandler handles two-factor authentication settings
+type MFAHandler struct {
+	mfaService *services.MFAService
+}
+
+// NewMFAHandler creates a new MFAHandler
+func NewMFAHandler(mfaService *services.MFAService) *MFAHandler {
+	return &MFAHandler{
+		mfaService: mfaService,
+	}
+}
+
+// GetStatus reports whether the authenticated user has two-factor
+// authentication enabled
+func (h *MFAHandler) GetStatus(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	status, err := h.mfaService.GetStatus(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MFAStatusDTO{
+		Enabled:                status.Enabled,
+		RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+	})
+}
+
+// BeginEnrollment creates a secret for the user's authenticator app
+func (h *MFAHandler) BeginEnrollment(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	enrollment, err := h.mfaService.BeginEnrollment(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.MFAEnrollmentDTO{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
+
+// ConfirmEnrollment enables two-factor authentication with a code from the
+// authenticator app and returns the recovery codes
+func (h *MFAHandler) ConfirmEnrollment(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	var req dto.MFACodeRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	codes, err := h.mfaService.ConfirmEnrollment(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RecoveryCodesDTO{RecoveryCodes: codes})
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	var req dto.MFACodeRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RecoveryCodesDTO{RecoveryCodes: codes})
+}
+
+// Disable turns two-factor authentication off
+func (h *MFAHandler) Disable(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	var req dto.MFACodeRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	if err := h.mfaService.Disable(c.Request.Context(), userID, req.Code); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *MFAHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrUserNotFound:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case services.ErrMFAAlreadyEnabled:
+		statusCode = http.StatusConflict
+		code = "MFA_ALREADY_ENABLED"
+	case services.ErrMFANotEnabled, services.ErrMFANotEnrolled:
+		statusCode = http.StatusConflict
+		code = "MFA_NOT_ENABLED"
+	case entities.ErrInvalidMFACode:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_MFA_CODE"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// OIDCHandler handles logins through external identity providers
+type OIDCHandler struct {
+	oidcService *services.OIDCService
+	userMapper  *mappers.UserMapper
+}
+
+// NewOIDCHandler creates a new OIDCHandler
+func NewOIDCHandler(oidcService *services.OIDCService, userMapper *mappers.UserMapper) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+		userMapper:  userMapper,
+	}
+}
+
+// GetProviders lists the identity providers users can log in with
+func (h *OIDCHandler) GetProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.OIDCProviderListDTO{Providers: h.oidcService.Providers()})
+}
+
+// Authorize starts a login and returns the provider URL to send the user to
+func (h *OIDCHandler) Authorize(c *gin.Context) {
+	authorization, err := h.oidcService.StartLogin(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.OIDCAuthorizationDTO{
+		AuthorizationURL: authorization.URL,
+		State:            authorization.State,
+		ExpiresAt:        authorization.ExpiresAt,
+	})
+}
+
+// Callback completes a login with the code and state the provider returned
+// to the app's redirect URL
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	var req dto.OIDCCallbackRequestDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	output, err := h.oidcService.CompleteLogin(c.Request.Context(), services.CompleteOIDCLoginInput{
+		Provider:   c.Param("provider"),
+		Code:       req.Code,
+		State:      req.State,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		DeviceName: req.DeviceName,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	if output.MFAToken != "" {
+		c.JSON(http.StatusOK, dto.MFAChallengeDTO{MFARequired: true, MFAToken: output.MFAToken})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.userMapper.ToAuthResponseDTO(output.Token, output.User))
+}
+
+func (h *OIDCHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrUnknownOIDCProvider:
+		statusCode = http.StatusNotFound
+		code = "PROVIDER_NOT_FOUND"
+	case services.ErrOIDCProviderUnavailable:
+		statusCode = http.StatusBadGateway
+		code = "PROVIDER_UNAVAILABLE"
+	case services.ErrInvalidOIDCState:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_STATE"
+	case services.ErrOIDCLoginFailed:
+		statusCode = http.StatusUnauthorized
+		code = "OIDC_LOGIN_FAILED"
+	case services.ErrOIDCAccountExists:
+		statusCode = http.StatusConflict
+		code = "USER_EXISTS"
+	case services.ErrOIDCAccountUnverified:
+		statusCode = http.StatusConflict
+		code = "ACCOUNT_UNVERIFIED"
+	case services.ErrAccountDisabled:
+		statusCode = http.StatusForbidden
+		code = "ACCOUNT_DISABLED"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
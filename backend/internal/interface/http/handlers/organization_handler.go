@@ -0,0 +1,382 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// OrganizationHandler handles organization, membership and invitation requests
+type OrganizationHandler struct {
+	organizationService *services.OrganizationService
+	organizationMapper  *mappers.OrganizationMapper
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler
+func NewOrganizationHandler(organizationService *services.OrganizationService, organizationMapper *mappers.OrganizationMapper) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+		organizationMapper:  organizationMapper,
+	}
+}
+
+// CreateOrganization creates an organization owned by the authenticated user
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	var req dto.OrganizationRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	membership, err := h.organizationService.CreateOrganization(c.Request.Context(), services.CreateOrganizationInput{
+		UserID: userID,
+		Name:   req.Name,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.organizationMapper.ToDTO(membership))
+}
+
+// GetOrganizations lists the organizations the authenticated user belongs to
+func (h *OrganizationHandler) GetOrganizations(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	memberships, err := h.organizationService.GetUserOrganizations(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.organizationMapper.ToDTOs(memberships))
+}
+
+// GetOrganization returns one of the authenticated user's organizations
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+
+	membership, err := h.organizationService.GetOrganization(c.Request.Context(), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.organizationMapper.ToDTO(membership))
+}
+
+// UpdateOrganization renames an organization
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+
+	var req dto.OrganizationRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	membership, err := h.organizationService.UpdateOrganization(c.Request.Context(), services.UpdateOrganizationInput{
+		OrganizationID: id,
+		UserID:         userID,
+		Name:           req.Name,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.organizationMapper.ToDTO(membership))
+}
+
+// GetMembers lists the members of an organization
+func (h *OrganizationHandler) GetMembers(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+
+	members, err := h.organizationService.GetMembers(c.Request.Context(), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.organizationMapper.ToMemberDTOs(members))
+}
+
+// UpdateMemberRole changes the role of a member
+func (h *OrganizationHandler) UpdateMemberRole(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+
+	memberID, ok := h.parseID(c, "userId", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateMemberRoleRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	member, err := h.organizationService.ChangeMemberRole(c.Request.Context(), services.ChangeMemberRoleInput{
+		OrganizationID: id,
+		ActorID:        userID,
+		UserID:         memberID,
+		Role:           req.Role,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.organizationMapper.ToMemberDTO(member))
+}
+
+// RemoveMember removes a member from an organization, or lets the
+// authenticated user leave it
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+
+	memberID, ok := h.parseID(c, "userId", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	if err := h.organizationService.RemoveMember(c.Request.Context(), id, userID, memberID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateInvitation emails an invitation to join an organization
+func (h *OrganizationHandler) CreateInvitation(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateInvitationRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	role := req.Role
+	if role == "" {
+		role = entities.OrganizationRoleMember
+	}
+
+	invitation, err := h.organizationService.InviteMember(c.Request.Context(), services.InviteMemberInput{
+		OrganizationID: id,
+		ActorID:        userID,
+		Email:          req.Email,
+		Role:           role,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.organizationMapper.ToInvitationDTO(invitation))
+}
+
+// GetInvitations lists the pending invitations of an organization
+func (h *OrganizationHandler) GetInvitations(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+
+	invitations, err := h.organizationService.GetInvitations(c.Request.Context(), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.organizationMapper.ToInvitationDTOs(invitations))
+}
+
+// RevokeInvitation withdraws a pending invitation
+func (h *OrganizationHandler) RevokeInvitation(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+
+	invitationID, ok := h.parseID(c, "invitationId", "Invalid invitation ID")
+	if !ok {
+		return
+	}
+
+	if err := h.organizationService.RevokeInvitation(c.Request.Context(), id, invitationID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AcceptInvitation adds the authenticated user to the organization of an
+// emailed invitation
+func (h *OrganizationHandler) AcceptInvitation(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	var req dto.AcceptInvitationRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	membership, err := h.organizationService.AcceptInvitation(c.Request.Context(), req.Token, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.organizationMapper.ToDTO(membership))
+}
+
+func (h *OrganizationHandler) parseID(c *gin.Context, param, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: message,
+			Code:    "INVALID_REQUEST",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *OrganizationHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrOrganizationNotFound, services.ErrMemberNotFound, services.ErrInvitationNotFound:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case services.ErrNotOrganizationMember, services.ErrOrganizationForbidden:
+		statusCode = http.StatusForbidden
+		code = "FORBIDDEN"
+	case services.ErrInvitationEmailMismatch:
+		statusCode = http.StatusForbidden
+		code = "INVITATION_EMAIL_MISMATCH"
+	case services.ErrOrganizationMemberExists:
+		statusCode = http.StatusConflict
+		code = "ALREADY_MEMBER"
+	case services.ErrLastOrganizationOwner:
+		statusCode = http.StatusConflict
+		code = "LAST_OWNER"
+	case services.ErrInvalidInvitation:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_INVITATION"
+	case entities.ErrInvalidOrganizationName, entities.ErrInvalidOrganizationRole, entities.ErrInvalidEmail:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_REQUEST"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// RedactionHandler handles organization redaction policy requests
+type RedactionHandler struct {
+	transcriptionService *services.TranscriptionService
+	organizationMapper   *mappers.OrganizationMapper
+}
+
+// NewRedactionHandler creates a new RedactionHandler
+func NewRedactionHandler(transcriptionService *services.TranscriptionService, organizationMapper *mappers.OrganizationMapper) *RedactionHandler {
+	return &RedactionHandler{
+		transcriptionService: transcriptionService,
+		organizationMapper:   organizationMapper,
+	}
+}
+
+// SetRedactionPolicy turns redaction of personal data on or off for an
+// organization and applies it to its existing transcriptions
+func (h *RedactionHandler) SetRedactionPolicy(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid organization ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	var req dto.RedactionPolicyRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	membership, err := h.transcriptionService.SetRedactionPolicy(c.Request.Context(), id, userID, *req.Required)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.organizationMapper.ToDTO(membership))
+}
+
+func (h *RedactionHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch {
+	case errors.Is(err, services.ErrOrganizationNotFound):
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case errors.Is(err, services.ErrNotOrganizationMember), errors.Is(err, services.ErrOrganizationForbidden):
+		statusCode = http.StatusForbidden
+		code = "FORBIDDEN"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// SessionHandler handles requests about the devices a user is logged in on
+type SessionHandler struct {
+	sessionService *services.SessionService
+	sessionMapper  *mappers.SessionMapper
+}
+
+// NewSessionHandler creates a new SessionHandler
+func NewSessionHandler(sessionService *services.SessionService, sessionMapper *mappers.SessionMapper) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+		sessionMapper:  sessionMapper,
+	}
+}
+
+// GetSessions lists the authenticated user's active sessions
+func (h *SessionHandler) GetSessions(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	sessions, err := h.sessionService.GetUserSessions(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	currentID, _ := middleware.GetSessionIDFromContext(c)
+	c.JSON(http.StatusOK, h.sessionMapper.ToDTOs(sessions, currentID))
+}
+
+// RevokeSession logs one of the authenticated user's devices out
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid session ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), id, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SessionHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrSessionNotFound:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case services.ErrUnauthorizedSession:
+		statusCode = http.StatusForbidden
+		code = "FORBIDDEN"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
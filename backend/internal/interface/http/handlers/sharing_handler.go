@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// SharingHandler handles transcription share and public link requests
+type SharingHandler struct {
+	sharingService      *services.SharingService
+	sharingMapper       *mappers.SharingMapper
+	transcriptionMapper *mappers.TranscriptionMapper
+}
+
+// NewSharingHandler creates a new SharingHandler
+func NewSharingHandler(
+	sharingService *services.SharingService,
+	sharingMapper *mappers.SharingMapper,
+	transcriptionMapper *mappers.TranscriptionMapper,
+) *SharingHandler {
+	return &SharingHandler{
+		sharingService:      sharingService,
+		sharingMapper:       sharingMapper,
+		transcriptionMapper: transcriptionMapper,
+	}
+}
+
+// ShareTranscription shares one of the authenticated user's transcriptions
+// with another user
+func (h *SharingHandler) ShareTranscription(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	var req dto.ShareTranscriptionRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	permission := req.Permission
+	if permission == "" {
+		permission = entities.SharePermissionRead
+	}
+
+	share, err := h.sharingService.ShareWithUser(c.Request.Context(), services.ShareTranscriptionInput{
+		TranscriptionID: id,
+		OwnerID:         userID,
+		Email:           req.Email,
+		Permission:      permission,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.sharingMapper.ToShareDTO(share))
+}
+
+// GetShares lists the users a transcription is shared with
+func (h *SharingHandler) GetShares(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	shares, err := h.sharingService.GetShares(c.Request.Context(), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.sharingMapper.ToShareDTOs(shares))
+}
+
+// RevokeShare withdraws a user's access to a transcription
+func (h *SharingHandler) RevokeShare(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	shareID, ok := h.parseID(c, "shareId", "Invalid share ID")
+	if !ok {
+		return
+	}
+
+	if err := h.sharingService.RevokeShare(c.Request.Context(), id, shareID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSharedTranscriptions lists the transcriptions other users shared with
+// the authenticated user
+func (h *SharingHandler) GetSharedTranscriptions(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	transcriptions, err := h.sharingService.GetSharedWithUser(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.transcriptionMapper.ToDTOs(transcriptions))
+}
+
+// CreateLink creates a public read-only link to a transcription. The URL is
+// only returned in this response.
+func (h *SharingHandler) CreateLink(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateShareLinkRequestDTO
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+				Message: err.Error(),
+				Code:    "INVALID_REQUEST",
+			})
+			return
+		}
+	}
+
+	created, err := h.sharingService.CreateLink(c.Request.Context(), id, userID, req.ExpiresAt)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.sharingMapper.ToCreatedLinkDTO(created))
+}
+
+// GetLinks lists the public links of a transcription
+func (h *SharingHandler) GetLinks(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	links, err := h.sharingService.GetLinks(c.Request.Context(), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.sharingMapper.ToLinkDTOs(links))
+}
+
+// RevokeLink disables a public link
+func (h *SharingHandler) RevokeLink(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	linkID, ok := h.parseID(c, "linkId", "Invalid link ID")
+	if !ok {
+		return
+	}
+
+	if err := h.sharingService.RevokeLink(c.Request.Context(), id, linkID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetPublicTranscription returns the transcription behind a public link
+// without authentication
+func (h *SharingHandler) GetPublicTranscription(c *gin.Context) {
+	transcription, err := h.sharingService.GetSharedTranscription(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.sharingMapper.ToSharedTranscriptionDTO(transcription))
+}
+
+func (h *SharingHandler) parseID(c *gin.Context, param, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: message,
+			Code:    "INVALID_REQUEST",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *SharingHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrTranscriptionNotFound, services.ErrShareNotFound, services.ErrShareLinkNotFound,
+		services.ErrInvalidShareLink:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case services.ErrUnauthorizedAccess:
+		statusCode = http.StatusForbidden
+		code = "FORBIDDEN"
+	case entities.ErrInvalidSharePermission, entities.ErrShareWithOwner, entities.ErrInvalidShareLinkExpiry,
+		entities.ErrInvalidEmail:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_REQUEST"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
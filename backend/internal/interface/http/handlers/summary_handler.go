@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// SummaryHandler handles transcription summary requests
+type SummaryHandler struct {
+	summaryService *services.SummaryService
+	summaryMapper  *mappers.SummaryMapper
+}
+
+// NewSummaryHandler creates a new SummaryHandler
+func NewSummaryHandler(summaryService *services.SummaryService, summaryMapper *mappers.SummaryMapper) *SummaryHandler {
+	return &SummaryHandler{
+		summaryService: summaryService,
+		summaryMapper:  summaryMapper,
+	}
+}
+
+// CreateSummary summarizes a transcription, reusing the cached summary while
+// the text is unchanged
+func (h *SummaryHandler) CreateSummary(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	transcription, err := h.summaryService.Summarize(c.Request.Context(), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.summaryMapper.ToDTO(transcription))
+}
+
+// GetSummary returns the cached summary of a transcription
+func (h *SummaryHandler) GetSummary(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	transcription, err := h.summaryService.GetSummary(c.Request.Context(), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.summaryMapper.ToDTO(transcription))
+}
+
+func (h *SummaryHandler) parseID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid transcription ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *SummaryHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch {
+	case errors.Is(err, services.ErrTranscriptionNotFound), errors.Is(err, services.ErrSummaryNotFound):
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case errors.Is(err, services.ErrUnauthorizedAccess):
+		statusCode = http.StatusForbidden
+		code = "FORBIDDEN"
+	case errors.Is(err, entities.ErrTranscriptionNotCompleted):
+		statusCode = http.StatusConflict
+		code = "NOT_COMPLETED"
+	case errors.Is(err, services.ErrQuotaExceeded):
+		statusCode = http.StatusTooManyRequests
+		code = "QUOTA_EXCEEDED"
+	case errors.Is(err, services.ErrSummarizationFailed):
+		statusCode = http.StatusBadGateway
+		code = "SUMMARIZATION_FAILED"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// TextProcessingHandler handles text processing settings requests
+type TextProcessingHandler struct {
+	textProcessingService *services.TextProcessingService
+	textProcessingMapper  *mappers.TextProcessingMapper
+}
+
+// NewTextProcessingHandler creates a new TextProcessingHandler
+func NewTextProcessingHandler(textProcessingService *services.TextProcessingService, textProcessingMapper *mappers.TextProcessingMapper) *TextProcessingHandler {
+	return &TextProcessingHandler{
+		textProcessingService: textProcessingService,
+		textProcessingMapper:  textProcessingMapper,
+	}
+}
+
+// GetSettings returns the authenticated user's text processing settings
+func (h *TextProcessingHandler) GetSettings(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	settings, err := h.textProcessingService.GetSettings(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.textProcessingMapper.ToDTO(settings))
+}
+
+// UpdateSettings replaces the authenticated user's stages and replacement rules
+func (h *TextProcessingHandler) UpdateSettings(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	var req dto.TextProcessingRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	input := services.UpdateTextProcessingInput{
+		UserID:       userID,
+		Stages:       make([]entities.TextStage, len(req.Stages)),
+		Replacements: make([]entities.TextReplacement, len(req.Replacements)),
+	}
+	for i, stage := range req.Stages {
+		input.Stages[i] = entities.TextStage(stage)
+	}
+	for i, replacement := range req.Replacements {
+		input.Replacements[i] = entities.TextReplacement{Find: replacement.Find, Replace: replacement.Replace}
+	}
+
+	settings, err := h.textProcessingService.UpdateSettings(c.Request.Context(), input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.textProcessingMapper.ToDTO(settings))
+}
+
+func (h *TextProcessingHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch {
+	case errors.Is(err, entities.ErrInvalidTextStage), errors.Is(err, entities.ErrDuplicateTextStage),
+		errors.Is(err, entities.ErrInvalidTextReplacement), errors.Is(err, entities.ErrTooManyTextReplacements):
+		statusCode = http.StatusBadRequest
+		code = "INVALID_REQUEST"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
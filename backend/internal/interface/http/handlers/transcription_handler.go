@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
 	"github.com/voiceline/backend/internal/interface/dto"
 	"github.com/voiceline/backend/internal/interface/http/middleware"
 	"github.com/voiceline/backend/internal/interface/mappers"
@@ -48,6 +50,11 @@ func (h *TranscriptionHandler) TranscribeAudio(c *gin.Context) {
 		return
 	}
 
+	organizationID, ok := parseOrganizationID(c, c.PostForm("organization_id"))
+	if !ok {
+		return
+	}
+
 	// Open the uploaded file
 	audioFile, err := file.Open()
 	if err != nil {
@@ -61,8 +68,10 @@ func (h *TranscriptionHandler) TranscribeAudio(c *gin.Context) {
 
 	// Transcribe audio
 	transcription, err := h.transcriptionService.Transcribe(c.Request.Context(), services.TranscribeAudioInput{
-		UserID: userID,
-		Audio:  audioFile,
+		UserID:         userID,
+		OrganizationID: organizationID,
+		Details:        parseDetailsForm(c),
+		Audio:          audioFile,
 	})
 
 	if err != nil {
@@ -73,6 +82,15 @@ func (h *TranscriptionHandler) TranscribeAudio(c *gin.Context) {
 		if err.Error() == "OpenAI service is not configured. Please set OPENAI_API_KEY environment variable" {
 			statusCode = http.StatusServiceUnavailable
 			code = "SERVICE_NOT_CONFIGURED"
+		} else if err == services.ErrQuotaExceeded {
+			statusCode = http.StatusTooManyRequests
+			code = "QUOTA_EXCEEDED"
+		} else if err == services.ErrNotOrganizationMember {
+			statusCode = http.StatusForbidden
+			code = "FORBIDDEN"
+		} else if isLabelError(err) {
+			statusCode = http.StatusBadRequest
+			code = "INVALID_REQUEST"
 		}
 
 		c.JSON(statusCode, dto.ErrorDTO{
@@ -86,7 +104,9 @@ func (h *TranscriptionHandler) TranscribeAudio(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// GetTranscriptions gets all transcriptions for the authenticated user
+// GetTranscriptions gets all transcriptions for the authenticated user, or
+// those of an organization given by the organization_id query parameter,
+// optionally filtered by the tag and folder query parameters
 func (h *TranscriptionHandler) GetTranscriptions(c *gin.Context) {
 	userID, ok := middleware.GetUserIDFromContext(c)
 	if !ok {
@@ -97,11 +117,38 @@ func (h *TranscriptionHandler) GetTranscriptions(c *gin.Context) {
 		return
 	}
 
-	transcriptions, err := h.transcriptionService.GetUserTranscriptions(c.Request.Context(), userID)
+	organizationID, ok := parseOrganizationID(c, c.Query("organization_id"))
+	if !ok {
+		return
+	}
+
+	filter := services.TranscriptionFilter{
+		Tag:    strings.ToLower(strings.TrimSpace(c.Query("tag"))),
+		Folder: strings.Trim(strings.TrimSpace(c.Query("folder")), "/"),
+	}
+
+	var (
+		transcriptions []*entities.Transcription
+		err            error
+	)
+	if organizationID != nil {
+		transcriptions, err = h.transcriptionService.GetOrganizationTranscriptions(c.Request.Context(), *organizationID, userID, filter)
+	} else {
+		transcriptions, err = h.transcriptionService.GetUserTranscriptions(c.Request.Context(), userID, filter)
+	}
+
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorDTO{
+		statusCode := http.StatusInternalServerError
+		code := "INTERNAL_ERROR"
+
+		if err == services.ErrNotOrganizationMember {
+			statusCode = http.StatusForbidden
+			code = "FORBIDDEN"
+		}
+
+		c.JSON(statusCode, dto.ErrorDTO{
 			Message: err.Error(),
-			Code:    "INTERNAL_ERROR",
+			Code:    code,
 		})
 		return
 	}
@@ -154,3 +201,290 @@ func (h *TranscriptionHandler) GetTranscription(c *gin.Context) {
 	response := h.transcriptionMapper.ToDTO(transcription)
 	c.JSON(http.StatusOK, response)
 }
+
+// UpdateTranscription corrects the text and changes the title, folder, tags
+// and metadata of a transcription
+func (h *TranscriptionHandler) UpdateTranscription(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid transcription ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	var req dto.UpdateTranscriptionRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	transcription, err := h.transcriptionService.UpdateTranscription(c.Request.Context(), services.UpdateTranscriptionInput{
+		ID:     id,
+		UserID: userID,
+		Text:   req.Text,
+		Details: services.TranscriptionDetails{
+			Title:    req.Title,
+			Folder:   req.Folder,
+			Tags:     req.Tags,
+			Metadata: req.Metadata,
+		},
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		code := "INTERNAL_ERROR"
+
+		switch {
+		case err == services.ErrTranscriptionNotFound:
+			statusCode = http.StatusNotFound
+			code = "NOT_FOUND"
+		case err == services.ErrUnauthorizedAccess:
+			statusCode = http.StatusForbidden
+			code = "FORBIDDEN"
+		case err == entities.ErrTranscriptionNotCompleted:
+			statusCode = http.StatusConflict
+			code = "NOT_COMPLETED"
+		case err == entities.ErrEmptyText, isLabelError(err):
+			statusCode = http.StatusBadRequest
+			code = "INVALID_REQUEST"
+		}
+
+		c.JSON(statusCode, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    code,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.transcriptionMapper.ToDTO(transcription))
+}
+
+// GetSpeakers lists the speakers of a diarized transcription
+func (h *TranscriptionHandler) GetSpeakers(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid transcription ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	transcription, err := h.transcriptionService.GetTranscription(c.Request.Context(), id, userID)
+	if err != nil {
+		respondSpeakerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.transcriptionMapper.ToSpeakerDTOs(transcription))
+}
+
+// RenameSpeaker names a speaker of a diarized transcription
+func (h *TranscriptionHandler) RenameSpeaker(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid transcription ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	var req dto.RenameSpeakerRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	transcription, err := h.transcriptionService.RenameSpeaker(c.Request.Context(), id, userID, c.Param("label"), req.Name)
+	if err != nil {
+		respondSpeakerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.transcriptionMapper.ToSpeakerDTOs(transcription))
+}
+
+func respondSpeakerError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrTranscriptionNotFound, entities.ErrSpeakerNotFound:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case services.ErrUnauthorizedAccess:
+		statusCode = http.StatusForbidden
+		code = "FORBIDDEN"
+	case entities.ErrInvalidSpeakerName:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_REQUEST"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
+
+// GetTags lists the tags on the authenticated user's transcriptions
+func (h *TranscriptionHandler) GetTags(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	tags, err := h.transcriptionService.GetUserTags(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.transcriptionMapper.ToTagDTOs(tags))
+}
+
+// RenameTag renames a tag on all of the authenticated user's transcriptions
+func (h *TranscriptionHandler) RenameTag(c *gin.Context) {
+	var req dto.RenameTagRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	h.mergeTags(c, []string{c.Param("tag")}, req.Name)
+}
+
+// MergeTags combines several tags into one on all of the authenticated
+// user's transcriptions
+func (h *TranscriptionHandler) MergeTags(c *gin.Context) {
+	var req dto.MergeTagsRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	h.mergeTags(c, req.Tags, req.Into)
+}
+
+func (h *TranscriptionHandler) mergeTags(c *gin.Context, from []string, into string) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	updated, err := h.transcriptionService.MergeTags(c.Request.Context(), userID, from, into)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		code := "INTERNAL_ERROR"
+
+		if isLabelError(err) {
+			statusCode = http.StatusBadRequest
+			code = "INVALID_REQUEST"
+		}
+
+		c.JSON(statusCode, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    code,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TagUpdateDTO{Updated: updated})
+}
+
+// parseDetailsForm reads the optional title, folder, tags and metadata
+// fields of a multipart upload. Tags may be repeated or comma separated and
+// metadata entries are sent as metadata[key]=value.
+func parseDetailsForm(c *gin.Context) services.TranscriptionDetails {
+	var details services.TranscriptionDetails
+
+	if title, exists := c.GetPostForm("title"); exists {
+		details.Title = &title
+	}
+
+	if folder, exists := c.GetPostForm("folder"); exists {
+		details.Folder = &folder
+	}
+
+	if values, exists := c.GetPostFormArray("tags"); exists {
+		tags := make([]string, 0, len(values))
+		for _, value := range values {
+			for _, tag := range strings.Split(value, ",") {
+				if strings.TrimSpace(tag) != "" {
+					tags = append(tags, tag)
+				}
+			}
+		}
+		details.Tags = &tags
+	}
+
+	if metadata, exists := c.GetPostFormMap("metadata"); exists {
+		details.Metadata = metadata
+	}
+
+	return details
+}
+
+// isLabelError reports whether err rejects a title, folder, tag or metadata
+func isLabelError(err error) bool {
+	switch err {
+	case entities.ErrInvalidTitle, entities.ErrInvalidFolder, entities.ErrInvalidTag,
+		entities.ErrTooManyTags, entities.ErrInvalidMetadata:
+		return true
+	}
+	return false
+}
+
+// parseOrganizationID parses an optional organization ID, responding with
+// 400 when it is malformed
+func parseOrganizationID(c *gin.Context, value string) (*uuid.UUID, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid organization ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return nil, false
+	}
+	return &id, true
+}
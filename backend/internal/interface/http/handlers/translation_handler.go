@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// TranslationHandler handles transcription translation requests
+type TranslationHandler struct {
+	translationService *services.TranslationService
+	translationMapper  *mappers.TranslationMapper
+}
+
+// NewTranslationHandler creates a new TranslationHandler
+func NewTranslationHandler(translationService *services.TranslationService, translationMapper *mappers.TranslationMapper) *TranslationHandler {
+	return &TranslationHandler{
+		translationService: translationService,
+		translationMapper:  translationMapper,
+	}
+}
+
+// CreateTranslation translates a transcription into the target query
+// parameter's language. An up to date translation is returned with 200,
+// a new version with 201.
+func (h *TranslationHandler) CreateTranslation(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	target := c.Query("target")
+	if target == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "target language is required",
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	translation, created, err := h.translationService.Translate(c.Request.Context(), id, userID, target)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	statusCode := http.StatusOK
+	if created {
+		statusCode = http.StatusCreated
+	}
+
+	c.JSON(statusCode, h.translationMapper.ToDTO(translation))
+}
+
+// GetTranslations lists the translations of a transcription, optionally
+// only those into the target query parameter's language
+func (h *TranslationHandler) GetTranslations(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return
+	}
+
+	translations, err := h.translationService.GetTranslations(c.Request.Context(), id, userID, c.Query("target"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.translationMapper.ToDTOs(translations))
+}
+
+// GetTranslation returns a translation
+func (h *TranslationHandler) GetTranslation(c *gin.Context) {
+	userID, id, translationID, ok := h.parseTranslationRequest(c)
+	if !ok {
+		return
+	}
+
+	translation, err := h.translationService.GetTranslation(c.Request.Context(), id, translationID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.translationMapper.ToDTO(translation))
+}
+
+// ExportTranslation downloads a translation as a text file, with speaker
+// names when the transcription was diarized
+func (h *TranslationHandler) ExportTranslation(c *gin.Context) {
+	userID, id, translationID, ok := h.parseTranslationRequest(c)
+	if !ok {
+		return
+	}
+
+	translation, text, err := h.translationService.ExportTranslation(c.Request.Context(), id, translationID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s.v%d.txt"`,
+		translation.TranscriptionID, translation.Language, translation.Version))
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(text))
+}
+
+func (h *TranslationHandler) parseTranslationRequest(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	id, ok := h.parseID(c, "id", "Invalid transcription ID")
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	translationID, ok := h.parseID(c, "translationId", "Invalid translation ID")
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, id, translationID, true
+}
+
+func (h *TranslationHandler) parseID(c *gin.Context, param, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: message,
+			Code:    "INVALID_REQUEST",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *TranslationHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch {
+	case errors.Is(err, services.ErrTranscriptionNotFound), errors.Is(err, services.ErrTranslationNotFound):
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case errors.Is(err, services.ErrUnauthorizedAccess):
+		statusCode = http.StatusForbidden
+		code = "FORBIDDEN"
+	case errors.Is(err, entities.ErrInvalidLanguage):
+		statusCode = http.StatusBadRequest
+		code = "INVALID_REQUEST"
+	case errors.Is(err, entities.ErrTranscriptionNotCompleted):
+		statusCode = http.StatusConflict
+		code = "NOT_COMPLETED"
+	case errors.Is(err, services.ErrQuotaExceeded):
+		statusCode = http.StatusTooManyRequests
+		code = "QUOTA_EXCEEDED"
+	case errors.Is(err, services.ErrTranslationFailed):
+		statusCode = http.StatusBadGateway
+		code = "TRANSLATION_FAILED"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
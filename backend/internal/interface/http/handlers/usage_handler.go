@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// UsageHandler handles usage and quota requests
+type UsageHandler struct {
+	usageService *services.UsageService
+	usageMapper  *mappers.UsageMapper
+}
+
+// NewUsageHandler creates a new UsageHandler
+func NewUsageHandler(usageService *services.UsageService, usageMapper *mappers.UsageMapper) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+		usageMapper:  usageMapper,
+	}
+}
+
+// GetUsage returns the authenticated user's consumption in the current period
+func (h *UsageHandler) GetUsage(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	summary, err := h.usageService.GetCurrentUsage(c.Request.Context(), userID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		code := "INTERNAL_ERROR"
+
+		if err == services.ErrUserNotFound {
+			statusCode = http.StatusNotFound
+			code = "NOT_FOUND"
+		}
+
+		c.JSON(statusCode, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    code,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.usageMapper.ToDTO(summary))
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// UserHandler handles profile requests of the authenticated user
+type UserHandler struct {
+	userService *services.UserService
+	userMapper  *mappers.UserMapper
+}
+
+// NewUserHandler creates a new UserHandler
+func NewUserHandler(userService *services.UserService, userMapper *mappers.UserMapper) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		userMapper:  userMapper,
+	}
+}
+
+// GetMe returns the authenticated user's profile
+func (h *UserHandler) GetMe(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	user, err := h.userService.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.userMapper.ToDTO(user))
+}
+
+// UpdateMe updates the authenticated user's name or email address
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	var req dto.UpdateProfileRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(c.Request.Context(), services.UpdateProfileInput{
+		UserID: userID,
+		Name:   req.Name,
+		Email:  req.Email,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.userMapper.ToDTO(user))
+}
+
+// ChangePassword replaces the password after checking the current one
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	var req dto.ChangePasswordRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	sessionID, _ := middleware.GetSessionIDFromContext(c)
+	err := h.userService.ChangePassword(c.Request.Context(), services.ChangePasswordInput{
+		UserID:          userID,
+		SessionID:       sessionID,
+		CurrentPassword: req.CurrentPassword,
+		MFACode:         req.MFACode,
+		NewPassword:     req.NewPassword,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteMe deletes the authenticated user's account and all their data
+func (h *UserHandler) DeleteMe(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	var req dto.DeleteAccountRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	sessionID, _ := middleware.GetSessionIDFromContext(c)
+	err := h.userService.DeleteAccount(c.Request.Context(), services.DeleteAccountInput{
+		UserID:    userID,
+		SessionID: sessionID,
+		Password:  req.Password,
+		MFACode:   req.MFACode,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrUserNotFound:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case services.ErrUserAlreadyExists:
+		statusCode = http.StatusConflict
+		code = "USER_EXISTS"
+	case services.ErrInvalidCredentials:
+		statusCode = http.StatusForbidden
+		code = "INVALID_CREDENTIALS"
+	case services.ErrReauthenticationRequired:
+		statusCode = http.StatusForbidden
+		code = "REAUTHENTICATION_REQUIRED"
+	case entities.ErrInvalidEmail, entities.ErrInvalidName, entities.ErrInvalidPassword:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_REQUEST"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
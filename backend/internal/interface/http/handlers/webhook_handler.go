@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+	"github.com/voiceline/backend/internal/interface/http/middleware"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+// WebhookHandler handles webhook management requests
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+	webhookMapper  *mappers.WebhookMapper
+}
+
+// NewWebhookHandler creates a new WebhookHandler
+func NewWebhookHandler(webhookService *services.WebhookService, webhookMapper *mappers.WebhookMapper) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		webhookMapper:  webhookMapper,
+	}
+}
+
+// CreateWebhook registers a new webhook endpoint
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	var req dto.CreateWebhookRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(c.Request.Context(), services.CreateWebhookInput{
+		UserID: userID,
+		URL:    req.URL,
+		Events: req.Events,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.webhookMapper.ToCreatedDTO(webhook))
+}
+
+// GetWebhooks lists the authenticated user's webhooks
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return
+	}
+
+	webhooks, err := h.webhookService.GetUserWebhooks(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.webhookMapper.ToDTOs(webhooks))
+}
+
+// GetWebhook gets a specific webhook by ID
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	userID, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(c.Request.Context(), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.webhookMapper.ToDTO(webhook))
+}
+
+// UpdateWebhook partially updates a webhook
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	userID, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: err.Error(),
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(c.Request.Context(), services.UpdateWebhookInput{
+		ID:     id,
+		UserID: userID,
+		URL:    req.URL,
+		Events: req.Events,
+		Active: req.Active,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.webhookMapper.ToDTO(webhook))
+}
+
+// DeleteWebhook removes a webhook and its delivery log
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userID, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), id, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetDeliveries returns the delivery log of a webhook
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	userID, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(c.Request.Context(), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.webhookMapper.ToDeliveryDTOs(deliveries))
+}
+
+// Redeliver queues a manual redelivery of a past delivery
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	userID, id, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("deliveryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid delivery ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(c.Request.Context(), id, deliveryID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, h.webhookMapper.ToDeliveryDTO(delivery))
+}
+
+func (h *WebhookHandler) parseRequest(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		respondUnauthorized(c)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+			Message: "Invalid webhook ID",
+			Code:    "INVALID_REQUEST",
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, id, true
+}
+
+func (h *WebhookHandler) respondError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	code := "INTERNAL_ERROR"
+
+	switch err {
+	case services.ErrWebhookNotFound, services.ErrWebhookDeliveryNotFound:
+		statusCode = http.StatusNotFound
+		code = "NOT_FOUND"
+	case services.ErrUnauthorizedWebhook:
+		statusCode = http.StatusForbidden
+		code = "FORBIDDEN"
+	case entities.ErrInvalidWebhookURL, entities.ErrInvalidWebhookEvent, entities.ErrNoWebhookEvents,
+		entities.ErrWebhookAddressNotAllowed:
+		statusCode = http.StatusBadRequest
+		code = "INVALID_REQUEST"
+	}
+
+	c.JSON(statusCode, dto.ErrorDTO{
+		Message: err.Error(),
+		Code:    code,
+	})
+}
+
+func respondUnauthorized(c *gin.Context) {
+	c.JSON(http.StatusUnauthorized, dto.ErrorDTO{
+		Message: "Unauthorized",
+		Code:    "UNAUTHORIZED",
+	})
+}
@@ -13,49 +13,94 @@ import (
 const (
 	// UserIDKey is the key used to store user ID in the context
 	UserIDKey = "userID"
+	// UserRoleKey is the key used to store the user's role in the context
+	UserRoleKey = "userRole"
+	// ScopesKey is the key used to store the scopes of an API key in the context
+	ScopesKey = "scopes"
+	// SessionIDKey is the key used to store the session of a login token in the context
+	SessionIDKey = "sessionID"
+
+	// APIKeyHeader carries a personal API key instead of a bearer token
+	APIKeyHeader = "X-API-Key"
 )
 
 // AuthMiddleware creates a middleware for JWT authentication
 func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, dto.ErrorDTO{
-				Message: "Authorization header is required",
-				Code:    "UNAUTHORIZED",
-			})
-			c.Abort()
+		authenticate(c, authService, false)
+	}
+}
+
+// AuthOrAPIKeyMiddleware also accepts personal API keys in the X-API-Key
+// header. Routes behind it must declare the scope they need with RequireScope.
+func AuthOrAPIKeyMiddleware(authService *services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authenticate(c, authService, true)
+	}
+}
+
+func authenticate(c *gin.Context, authService *services.AuthService, allowAPIKeys bool) {
+	var (
+		claims *services.TokenClaims
+		err    error
+	)
+
+	authHeader := c.GetHeader("Authorization")
+	apiKey := c.GetHeader(APIKeyHeader)
+
+	switch {
+	case allowAPIKeys && apiKey != "" && authHeader == "":
+		claims, err = authService.AuthenticateAPIKey(c.Request.Context(), apiKey)
+		if err != nil && err != services.ErrAccountDisabled {
+			abortUnauthorized(c, "Invalid, expired or revoked API key")
 			return
 		}
-
+	case authHeader == "":
+		abortUnauthorized(c, "Authorization header is required")
+		return
+	default:
 		// Extract token from "Bearer <token>"
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, dto.ErrorDTO{
-				Message: "Invalid authorization header format",
-				Code:    "UNAUTHORIZED",
-			})
-			c.Abort()
+			abortUnauthorized(c, "Invalid authorization header format")
 			return
 		}
 
-		token := parts[1]
-
 		// Validate token
-		userID, err := authService.ValidateToken(token)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, dto.ErrorDTO{
-				Message: "Invalid or expired token",
-				Code:    "UNAUTHORIZED",
-			})
-			c.Abort()
+		claims, err = authService.Authenticate(c.Request.Context(), parts[1], c.ClientIP())
+		if err != nil && err != services.ErrAccountDisabled {
+			abortUnauthorized(c, "Invalid or expired token")
 			return
 		}
+	}
+
+	if err == services.ErrAccountDisabled {
+		c.JSON(http.StatusForbidden, dto.ErrorDTO{
+			Message: "Account is disabled",
+			Code:    "ACCOUNT_DISABLED",
+		})
+		c.Abort()
+		return
+	}
 
-		// Store user ID in context
-		c.Set(UserIDKey, userID)
-		c.Next()
+	// Store user ID, role, API key scopes and session in context
+	c.Set(UserIDKey, claims.UserID)
+	c.Set(UserRoleKey, claims.Role)
+	if claims.Scopes != nil {
+		c.Set(ScopesKey, claims.Scopes)
 	}
+	if claims.SessionID != uuid.Nil {
+		c.Set(SessionIDKey, claims.SessionID)
+	}
+	c.Next()
+}
+
+func abortUnauthorized(c *gin.Context, message string) {
+	c.JSON(http.StatusUnauthorized, dto.ErrorDTO{
+		Message: message,
+		Code:    "UNAUTHORIZED",
+	})
+	c.Abort()
 }
 
 // GetUserIDFromContext extracts user ID from the Gin context
@@ -69,3 +114,25 @@ func GetUserIDFromContext(c *gin.Context) (uuid.UUID, bool) {
 	return id, ok
 }
 
+// GetUserRoleFromContext extracts the user's role from the Gin context
+func GetUserRoleFromContext(c *gin.Context) (string, bool) {
+	role, exists := c.Get(UserRoleKey)
+	if !exists {
+		return "", false
+	}
+
+	value, ok := role.(string)
+	return value, ok
+}
+
+// GetSessionIDFromContext extracts the session of the login token from the
+// Gin context. Requests made with API keys or older tokens have none.
+func GetSessionIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	sessionID, exists := c.Get(SessionIDKey)
+	if !exists {
+		return uuid.Nil, false
+	}
+
+	id, ok := sessionID.(uuid.UUID)
+	return id, ok
+}
@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the client's key
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses replayed from an earlier request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// maxIdempotentBodySize bounds the request bodies buffered for hashing and
+	// replay; it matches the transcription provider's upload limit
+	maxIdempotentBodySize = 25 << 20
+)
+
+// IdempotencyMiddleware replays the stored response of an earlier request made
+// with the same Idempotency-Key by the same user, and rejects reuse of a key
+// with a different payload. Requests without the header pass through.
+func IdempotencyMiddleware(idempotencyService *services.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+				Message: "Idempotency-Key must be at most 255 characters",
+				Code:    "INVALID_REQUEST",
+			})
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorDTO{
+					Message: "Request body must be at most 25 MB",
+					Code:    "REQUEST_TOO_LARGE",
+				})
+			} else {
+				c.JSON(http.StatusBadRequest, dto.ErrorDTO{
+					Message: "Failed to read request body",
+					Code:    "INVALID_REQUEST",
+				})
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		scopedKey := idempotencyScope(c) + ":" + c.FullPath() + ":" + key
+		requestHash := hashRequest(c.Request, body)
+
+		record, err := idempotencyService.Begin(ctx, scopedKey, requestHash)
+		if err != nil {
+			statusCode := http.StatusInternalServerError
+			code := "INTERNAL_ERROR"
+
+			switch {
+			case errors.Is(err, services.ErrIdempotencyKeyInProgress):
+				statusCode = http.StatusConflict
+				code = "IDEMPOTENCY_KEY_IN_PROGRESS"
+			case errors.Is(err, services.ErrIdempotencyKeyReused):
+				statusCode = http.StatusUnprocessableEntity
+				code = "IDEMPOTENCY_KEY_REUSED"
+			}
+
+			c.JSON(statusCode, dto.ErrorDTO{
+				Message: err.Error(),
+				Code:    code,
+			})
+			c.Abort()
+			return
+		}
+
+		if record.IsCompleted() {
+			for name, value := range record.ResponseHeaders {
+				c.Header(name, value)
+			}
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(record.ResponseStatus, record.ResponseHeaders["Content-Type"], record.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				_ = idempotencyService.Release(ctx, scopedKey)
+				panic(recovered)
+			}
+		}()
+
+		c.Next()
+
+		headers := map[string]string{
+			"Content-Type": c.Writer.Header().Get("Content-Type"),
+		}
+		if err := idempotencyService.Complete(ctx, record, c.Writer.Status(), headers, recorder.body.Bytes()); err != nil {
+			_ = idempotencyService.Release(ctx, scopedKey)
+		}
+	}
+}
+
+// idempotencyScope keys records by authenticated user, falling back to the
+// client IP for public routes such as registration
+func idempotencyScope(c *gin.Context) string {
+	if userID, ok := GetUserIDFromContext(c); ok {
+		return "user:" + userID.String()
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// hashRequest fingerprints the request payload. Multipart bodies are hashed
+// part by part so that a retry encoded with a different boundary still matches.
+func hashRequest(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err == nil && strings.HasPrefix(mediaType, "multipart/") {
+		if hashMultipart(hash, body, params["boundary"]) == nil {
+			return hex.EncodeToString(hash.Sum(nil))
+		}
+		hash.Reset()
+		hash.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	}
+
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func hashMultipart(hash io.Writer, body []byte, boundary string) error {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+
+	parts := make([]string, 0)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		partHash := sha256.New()
+		if _, err := io.Copy(partHash, part); err != nil {
+			return err
+		}
+		parts = append(parts, part.FormName()+"\x00"+part.FileName()+"\x00"+hex.EncodeToString(partHash.Sum(nil)))
+	}
+
+	sort.Strings(parts)
+	for _, p := range parts {
+		hash.Write([]byte(p + "\n"))
+	}
+	return nil
+}
+
+// responseRecorder captures the response body while writing it through
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+	RetryAfterHeader         = "Retry-After"
+)
+
+// RateLimitKeyFunc identifies the client a request is counted against
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// RateLimitByIP counts requests per client IP
+func RateLimitByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// RateLimitByUser counts requests per authenticated user, falling back to the
+// client IP when the request is anonymous. It must run after AuthMiddleware.
+func RateLimitByUser(c *gin.Context) string {
+	if userID, ok := GetUserIDFromContext(c); ok {
+		return "user:" + userID.String()
+	}
+	return RateLimitByIP(c)
+}
+
+// RateLimitMiddleware enforces a token bucket policy and reports the client's
+// budget in RateLimit-* headers. Store failures let the request through so an
+// unavailable shared store does not take the API down.
+func RateLimitMiddleware(store repositories.RateLimitStore, policy entities.RateLimitPolicy, keyFunc RateLimitKeyFunc) gin.HandlerFunc {
+	policyHeader := strconv.Itoa(policy.Limit) + ";w=" + strconv.Itoa(int(policy.Window.Seconds()))
+
+	return func(c *gin.Context) {
+		key := policy.Name + ":" + keyFunc(c)
+
+		decision, err := store.Take(c.Request.Context(), key, policy)
+		if err != nil {
+			log.Printf("rate limit store unavailable for %s: %v", policy.Name, err)
+			c.Next()
+			return
+		}
+
+		c.Header(RateLimitLimitHeader, strconv.Itoa(decision.Limit))
+		c.Header(RateLimitRemainingHeader, strconv.Itoa(decision.Remaining))
+		c.Header(RateLimitResetHeader, strconv.Itoa(ceilSeconds(decision.ResetAfter)))
+		c.Header(RateLimitPolicyHeader, policyHeader)
+
+		if !decision.Allowed {
+			c.Header(RetryAfterHeader, strconv.Itoa(ceilSeconds(decision.RetryAfter)))
+			c.JSON(http.StatusTooManyRequests, dto.ErrorDTO{
+				Message: "Too many requests, please retry later",
+				Code:    "RATE_LIMITED",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// RequireRole only lets through users holding one of the given roles. It must
+// run after AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := GetUserRoleFromContext(c)
+		if ok {
+			for _, allowed := range roles {
+				if role == allowed {
+					c.Next()
+					return
+				}
+			}
+		}
+
+		c.JSON(http.StatusForbidden, dto.ErrorDTO{
+			Message: "Insufficient permissions",
+			Code:    "FORBIDDEN",
+		})
+		c.Abort()
+	}
+}
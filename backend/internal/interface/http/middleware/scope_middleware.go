@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// RequireScope rejects API key requests whose key was not granted scope.
+// Login tokens act with all of the user's permissions and always pass. It
+// must run after AuthOrAPIKeyMiddleware.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, exists := c.Get(ScopesKey)
+		if !exists {
+			c.Next()
+			return
+		}
+
+		scopes, _ := value.([]string)
+		for _, granted := range scopes {
+			if granted == scope {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, dto.ErrorDTO{
+			Message: "API key is missing the " + scope + " scope",
+			Code:    "INSUFFICIENT_SCOPE",
+		})
+		c.Abort()
+	}
+}
@@ -1,9 +1,13 @@
 package http
 
 import (
+	"time"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/domain/repositories"
 	"github.com/voiceline/backend/internal/interface/http/handlers"
 	"github.com/voiceline/backend/internal/interface/http/middleware"
 	"github.com/voiceline/backend/internal/interface/mappers"
@@ -11,9 +15,48 @@ import (
 
 // Router sets up the HTTP router
 type Router struct {
-	engine               *gin.Engine
-	authService          *services.AuthService
-	transcriptionService *services.TranscriptionService
+	engine                *gin.Engine
+	authService           *services.AuthService
+	transcriptionService  *services.TranscriptionService
+	webhookService        *services.WebhookService
+	idempotencyService    *services.IdempotencyService
+	usageService          *services.UsageService
+	accountService        *services.AccountService
+	userService           *services.UserService
+	exportService         *services.ExportService
+	adminService          *services.AdminService
+	apiKeyService         *services.APIKeyService
+	signingKeyService     *services.SigningKeyService
+	oidcService           *services.OIDCService
+	mfaService            *services.MFAService
+	sessionService        *services.SessionService
+	organizationService   *services.OrganizationService
+	sharingService        *services.SharingService
+	commentService        *services.CommentService
+	summaryService        *services.SummaryService
+	translationService    *services.TranslationService
+	textProcessingService *services.TextProcessingService
+	rateLimitStore        repositories.RateLimitStore
+	rateLimitPolicies     RateLimitPolicies
+}
+
+// RateLimitPolicies configures the rate limit of each route group
+type RateLimitPolicies struct {
+	// Auth is applied per client IP to the public auth routes
+	Auth entities.RateLimitPolicy
+	// Transcriptions is applied per user to the transcription routes
+	Transcriptions entities.RateLimitPolicy
+	// API is applied per user to the remaining authenticated routes
+	API entities.RateLimitPolicy
+}
+
+// DefaultRateLimitPolicies returns limits suited to interactive clients
+func DefaultRateLimitPolicies() RateLimitPolicies {
+	return RateLimitPolicies{
+		Auth:           entities.RateLimitPolicy{Name: "auth", Limit: 10, Window: time.Minute},
+		Transcriptions: entities.RateLimitPolicy{Name: "transcriptions", Limit: 60, Window: time.Minute},
+		API:            entities.RateLimitPolicy{Name: "api", Limit: 300, Window: time.Minute},
+	}
 }
 
 // NewRouter creates a new HTTP router
@@ -28,14 +71,139 @@ func NewRouter(
 	}
 }
 
+// WithWebhookService enables the webhook management routes
+func (r *Router) WithWebhookService(webhookService *services.WebhookService) *Router {
+	r.webhookService = webhookService
+	return r
+}
+
+// WithIdempotencyService enables Idempotency-Key handling on routes that
+// create resources
+func (r *Router) WithIdempotencyService(idempotencyService *services.IdempotencyService) *Router {
+	r.idempotencyService = idempotencyService
+	return r
+}
+
+// WithUsageService enables the usage reporting route
+func (r *Router) WithUsageService(usageService *services.UsageService) *Router {
+	r.usageService = usageService
+	return r
+}
+
+// WithAccountService enables the email verification and password reset routes
+func (r *Router) WithAccountService(accountService *services.AccountService) *Router {
+	r.accountService = accountService
+	return r
+}
+
+// WithUserService enables the profile routes of the authenticated user
+func (r *Router) WithUserService(userService *services.UserService) *Router {
+	r.userService = userService
+	return r
+}
+
+// WithExportService enables personal data exports
+func (r *Router) WithExportService(exportService *services.ExportService) *Router {
+	r.exportService = exportService
+	return r
+}
+
+// WithAdminService enables the /admin routes for admin and support staff
+func (r *Router) WithAdminService(adminService *services.AdminService) *Router {
+	r.adminService = adminService
+	return r
+}
+
+// WithAPIKeyService enables the API key management routes
+func (r *Router) WithAPIKeyService(apiKeyService *services.APIKeyService) *Router {
+	r.apiKeyService = apiKeyService
+	return r
+}
+
+// WithSigningKeys publishes the token verification keys as a JWKS
+func (r *Router) WithSigningKeys(signingKeyService *services.SigningKeyService) *Router {
+	r.signingKeyService = signingKeyService
+	return r
+}
+
+// WithOIDCService enables login through external identity providers
+func (r *Router) WithOIDCService(oidcService *services.OIDCService) *Router {
+	r.oidcService = oidcService
+	return r
+}
+
+// WithMFAService enables two-factor authentication settings and logins
+func (r *Router) WithMFAService(mfaService *services.MFAService) *Router {
+	r.mfaService = mfaService
+	return r
+}
+
+// WithSessionService enables listing and revoking the user's sessions
+func (r *Router) WithSessionService(sessionService *services.SessionService) *Router {
+	r.sessionService = sessionService
+	return r
+}
+
+// WithOrganizationService enables organizations, their members and invitations
+func (r *Router) WithOrganizationService(organizationService *services.OrganizationService) *Router {
+	r.organizationService = organizationService
+	return r
+}
+
+// WithSharingService enables sharing transcriptions with users and through
+// public links
+func (r *Router) WithSharingService(sharingService *services.SharingService) *Router {
+	r.sharingService = sharingService
+	return r
+}
+
+// WithCommentService enables comments and highlights on transcriptions
+func (r *Router) WithCommentService(commentService *services.CommentService) *Router {
+	r.commentService = commentService
+	return r
+}
+
+// WithSummaryService enables summaries of transcriptions
+func (r *Router) WithSummaryService(summaryService *services.SummaryService) *Router {
+	r.summaryService = summaryService
+	return r
+}
+
+// WithTranslationService enables translations of transcriptions
+func (r *Router) WithTranslationService(translationService *services.TranslationService) *Router {
+	r.translationService = translationService
+	return r
+}
+
+// WithTextProcessingService enables per user text processing settings
+func (r *Router) WithTextProcessingService(textProcessingService *services.TextProcessingService) *Router {
+	r.textProcessingService = textProcessingService
+	return r
+}
+
+// WithRateLimiting enables per route group rate limits backed by store
+func (r *Router) WithRateLimiting(store repositories.RateLimitStore, policies RateLimitPolicies) *Router {
+	r.rateLimitStore = store
+	r.rateLimitPolicies = policies
+	return r
+}
+
 // Setup configures all routes
 func (r *Router) Setup() *gin.Engine {
 	// Configure CORS
 	r.engine.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowOrigins: []string{"*"},
+		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{"Origin", "Content-Type", "Authorization", middleware.APIKeyHeader, middleware.IdempotencyKeyHeader},
+		ExposeHeaders: []string{
+			"Content-Length",
+			middleware.IdempotentReplayedHeader,
+			middleware.RateLimitLimitHeader,
+			middleware.RateLimitRemainingHeader,
+			middleware.RateLimitResetHeader,
+			middleware.RateLimitPolicyHeader,
+			middleware.RetryAfterHeader,
+		},
 		AllowCredentials: true,
 	}))
 
@@ -48,6 +216,17 @@ func (r *Router) Setup() *gin.Engine {
 	authHandler := handlers.NewAuthHandler(r.authService, userMapper)
 	transcriptionHandler := handlers.NewTranscriptionHandler(r.transcriptionService, transcriptionMapper)
 
+	idempotent := r.idempotencyMiddleware()
+	authLimit := r.rateLimitMiddleware(r.rateLimitPolicies.Auth, middleware.RateLimitByIP)
+	transcriptionLimit := r.rateLimitMiddleware(r.rateLimitPolicies.Transcriptions, middleware.RateLimitByUser)
+	apiLimit := r.rateLimitMiddleware(r.rateLimitPolicies.API, middleware.RateLimitByUser)
+
+	// Token verification keys for other services
+	if r.signingKeyService != nil {
+		jwksHandler := handlers.NewJWKSHandler(r.signingKeyService, mappers.NewJWKMapper())
+		r.engine.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+	}
+
 	// API v1 routes
 	v1 := r.engine.Group("/api/v1")
 	{
@@ -56,20 +235,241 @@ func (r *Router) Setup() *gin.Engine {
 
 		// Auth routes
 		auth := v1.Group("/auth")
+		auth.Use(authLimit)
 		{
-			auth.POST("/register", authHandler.Register)
+			auth.POST("/register", idempotent, authHandler.Register)
 			auth.POST("/login", authHandler.Login)
+
+			if r.mfaService != nil {
+				auth.POST("/mfa/verify", authHandler.VerifyMFA)
+			}
+
+			if r.accountService != nil {
+				accountHandler := handlers.NewAccountHandler(r.accountService, userMapper)
+
+				auth.POST("/verify-email", middleware.AuthMiddleware(r.authService), accountHandler.RequestEmailVerification)
+				auth.POST("/verify-email/confirm", accountHandler.ConfirmEmail)
+				auth.POST("/password-reset", accountHandler.RequestPasswordReset)
+				auth.POST("/password-reset/confirm", accountHandler.ConfirmPasswordReset)
+			}
+
+			if r.oidcService != nil {
+				oidcHandler := handlers.NewOIDCHandler(r.oidcService, userMapper)
+
+				auth.GET("/oidc/providers", oidcHandler.GetProviders)
+				auth.POST("/oidc/:provider/authorize", oidcHandler.Authorize)
+				auth.POST("/oidc/:provider/callback", oidcHandler.Callback)
+			}
 		}
 
-		// Transcription routes (protected)
+		// Transcription routes (protected, API keys accepted)
+		readTranscriptions := middleware.RequireScope(entities.ScopeTranscriptionsRead)
+		writeTranscriptions := middleware.RequireScope(entities.ScopeTranscriptionsWrite)
+
 		transcriptions := v1.Group("/transcriptions")
-		transcriptions.Use(middleware.AuthMiddleware(r.authService))
+		transcriptions.Use(middleware.AuthOrAPIKeyMiddleware(r.authService), transcriptionLimit)
 		{
-			transcriptions.POST("", transcriptionHandler.TranscribeAudio)
-			transcriptions.GET("", transcriptionHandler.GetTranscriptions)
-			transcriptions.GET("/:id", transcriptionHandler.GetTranscription)
+			transcriptions.POST("", writeTranscriptions, idempotent, transcriptionHandler.TranscribeAudio)
+			transcriptions.GET("", readTranscriptions, transcriptionHandler.GetTranscriptions)
+			transcriptions.GET("/tags", readTranscriptions, transcriptionHandler.GetTags)
+			transcriptions.POST("/tags/merge", writeTranscriptions, transcriptionHandler.MergeTags)
+			transcriptions.PATCH("/tags/:tag", writeTranscriptions, transcriptionHandler.RenameTag)
+			transcriptions.GET("/:id", readTranscriptions, transcriptionHandler.GetTranscription)
+			transcriptions.PATCH("/:id", writeTranscriptions, transcriptionHandler.UpdateTranscription)
+			transcriptions.GET("/:id/speakers", readTranscriptions, transcriptionHandler.GetSpeakers)
+			transcriptions.PATCH("/:id/speakers/:label", writeTranscriptions, transcriptionHandler.RenameSpeaker)
+
+			if r.sharingService != nil {
+				sharingHandler := handlers.NewSharingHandler(r.sharingService, mappers.NewSharingMapper(), transcriptionMapper)
+
+				transcriptions.GET("/shared", readTranscriptions, sharingHandler.GetSharedTranscriptions)
+				transcriptions.POST("/:id/shares", writeTranscriptions, sharingHandler.ShareTranscription)
+				transcriptions.GET("/:id/shares", readTranscriptions, sharingHandler.GetShares)
+				transcriptions.DELETE("/:id/shares/:shareId", writeTranscriptions, sharingHandler.RevokeShare)
+				transcriptions.POST("/:id/links", writeTranscriptions, sharingHandler.CreateLink)
+				transcriptions.GET("/:id/links", readTranscriptions, sharingHandler.GetLinks)
+				transcriptions.DELETE("/:id/links/:linkId", writeTranscriptions, sharingHandler.RevokeLink)
+
+				// Public links grant read access without a bearer token
+				v1.GET("/shared/:token", apiLimit, sharingHandler.GetPublicTranscription)
+			}
+
+			if r.commentService != nil {
+				commentHandler := handlers.NewCommentHandler(r.commentService, mappers.NewCommentMapper())
+
+				transcriptions.POST("/:id/comments", writeTranscriptions, commentHandler.CreateComment)
+				transcriptions.GET("/:id/comments", readTranscriptions, commentHandler.GetComments)
+				transcriptions.PATCH("/:id/comments/:commentId", writeTranscriptions, commentHandler.UpdateComment)
+				transcriptions.DELETE("/:id/comments/:commentId", writeTranscriptions, commentHandler.DeleteComment)
+				transcriptions.POST("/:id/comments/:commentId/resolve", writeTranscriptions, commentHandler.ResolveComment)
+				transcriptions.POST("/:id/comments/:commentId/unresolve", writeTranscriptions, commentHandler.UnresolveComment)
+				transcriptions.POST("/:id/highlights", writeTranscriptions, commentHandler.CreateHighlight)
+				transcriptions.GET("/:id/highlights", readTranscriptions, commentHandler.GetHighlights)
+				transcriptions.DELETE("/:id/highlights/:highlightId", writeTranscriptions, commentHandler.DeleteHighlight)
+			}
+
+			if r.summaryService != nil {
+				summaryHandler := handlers.NewSummaryHandler(r.summaryService, mappers.NewSummaryMapper())
+
+				transcriptions.POST("/:id/summary", writeTranscriptions, summaryHandler.CreateSummary)
+				transcriptions.GET("/:id/summary", readTranscriptions, summaryHandler.GetSummary)
+			}
+
+			if r.translationService != nil {
+				translationHandler := handlers.NewTranslationHandler(r.translationService, mappers.NewTranslationMapper())
+
+				transcriptions.POST("/:id/translations", writeTranscriptions, translationHandler.CreateTranslation)
+				transcriptions.GET("/:id/translations", readTranscriptions, translationHandler.GetTranslations)
+				transcriptions.GET("/:id/translations/:translationId", readTranscriptions, translationHandler.GetTranslation)
+				transcriptions.GET("/:id/translations/:translationId/export", readTranscriptions, translationHandler.ExportTranslation)
+			}
+		}
+
+		// Organization routes (protected, login tokens only)
+		if r.organizationService != nil {
+			organizationHandler := handlers.NewOrganizationHandler(r.organizationService, mappers.NewOrganizationMapper())
+			redactionHandler := handlers.NewRedactionHandler(r.transcriptionService, mappers.NewOrganizationMapper())
+
+			organizations := v1.Group("/organizations")
+			organizations.Use(middleware.AuthMiddleware(r.authService), apiLimit)
+			{
+				organizations.POST("", organizationHandler.CreateOrganization)
+				organizations.GET("", organizationHandler.GetOrganizations)
+				organizations.POST("/invitations/accept", organizationHandler.AcceptInvitation)
+				organizations.GET("/:id", organizationHandler.GetOrganization)
+				organizations.PATCH("/:id", organizationHandler.UpdateOrganization)
+				organizations.GET("/:id/members", organizationHandler.GetMembers)
+				organizations.PATCH("/:id/members/:userId", organizationHandler.UpdateMemberRole)
+				organizations.DELETE("/:id/members/:userId", organizationHandler.RemoveMember)
+				organizations.POST("/:id/invitations", organizationHandler.CreateInvitation)
+				organizations.GET("/:id/invitations", organizationHandler.GetInvitations)
+				organizations.DELETE("/:id/invitations/:invitationId", organizationHandler.RevokeInvitation)
+				organizations.PUT("/:id/redaction-policy", redactionHandler.SetRedactionPolicy)
+			}
+		}
+
+		// API key routes (protected, login tokens only)
+		if r.apiKeyService != nil {
+			apiKeyHandler := handlers.NewAPIKeyHandler(r.apiKeyService, mappers.NewAPIKeyMapper())
+
+			apiKeys := v1.Group("/api-keys")
+			apiKeys.Use(middleware.AuthMiddleware(r.authService), apiLimit)
+			{
+				apiKeys.POST("", apiKeyHandler.CreateAPIKey)
+				apiKeys.GET("", apiKeyHandler.GetAPIKeys)
+				apiKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+			}
+		}
+
+		// User routes (protected)
+		if r.userService != nil {
+			userHandler := handlers.NewUserHandler(r.userService, userMapper)
+
+			users := v1.Group("/users")
+			users.Use(middleware.AuthMiddleware(r.authService), apiLimit)
+			{
+				users.GET("/me", userHandler.GetMe)
+				users.PATCH("/me", userHandler.UpdateMe)
+				users.POST("/me/password", userHandler.ChangePassword)
+				users.DELETE("/me", userHandler.DeleteMe)
+
+				if r.mfaService != nil {
+					mfaHandler := handlers.NewMFAHandler(r.mfaService)
+
+					users.GET("/me/mfa", mfaHandler.GetStatus)
+					users.POST("/me/mfa/totp", mfaHandler.BeginEnrollment)
+					users.POST("/me/mfa/totp/confirm", mfaHandler.ConfirmEnrollment)
+					users.DELETE("/me/mfa/totp", mfaHandler.Disable)
+					users.POST("/me/mfa/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+				}
+
+				if r.sessionService != nil {
+					sessionHandler := handlers.NewSessionHandler(r.sessionService, mappers.NewSessionMapper())
+
+					users.GET("/me/sessions", sessionHandler.GetSessions)
+					users.DELETE("/me/sessions/:id", sessionHandler.RevokeSession)
+				}
+
+				if r.textProcessingService != nil {
+					textProcessingHandler := handlers.NewTextProcessingHandler(r.textProcessingService, mappers.NewTextProcessingMapper())
+
+					users.GET("/me/text-processing", textProcessingHandler.GetSettings)
+					users.PUT("/me/text-processing", textProcessingHandler.UpdateSettings)
+				}
+
+				if r.exportService != nil {
+					exportHandler := handlers.NewExportHandler(r.exportService, mappers.NewExportMapper())
+
+					users.POST("/me/export", exportHandler.RequestExport)
+					users.GET("/me/exports", exportHandler.GetExports)
+					users.GET("/me/exports/:id", exportHandler.GetExport)
+
+					// Signed links authorize downloads without a bearer token
+					v1.GET("/exports/:id/download", apiLimit, exportHandler.Download)
+				}
+			}
+		}
+
+		// Usage routes (protected)
+		if r.usageService != nil {
+			usageHandler := handlers.NewUsageHandler(r.usageService, mappers.NewUsageMapper())
+			v1.GET("/usage", middleware.AuthOrAPIKeyMiddleware(r.authService), apiLimit, middleware.RequireScope(entities.ScopeUsageRead), usageHandler.GetUsage)
+		}
+
+		// Webhook routes (protected, API keys accepted)
+		if r.webhookService != nil {
+			webhookHandler := handlers.NewWebhookHandler(r.webhookService, mappers.NewWebhookMapper())
+			readWebhooks := middleware.RequireScope(entities.ScopeWebhooksRead)
+			writeWebhooks := middleware.RequireScope(entities.ScopeWebhooksWrite)
+
+			webhooks := v1.Group("/webhooks")
+			webhooks.Use(middleware.AuthOrAPIKeyMiddleware(r.authService), apiLimit)
+			{
+				webhooks.POST("", writeWebhooks, webhookHandler.CreateWebhook)
+				webhooks.GET("", readWebhooks, webhookHandler.GetWebhooks)
+				webhooks.GET("/:id", readWebhooks, webhookHandler.GetWebhook)
+				webhooks.PATCH("/:id", writeWebhooks, webhookHandler.UpdateWebhook)
+				webhooks.DELETE("/:id", writeWebhooks, webhookHandler.DeleteWebhook)
+				webhooks.GET("/:id/deliveries", readWebhooks, webhookHandler.GetDeliveries)
+				webhooks.POST("/:id/deliveries/:deliveryId/redeliver", writeWebhooks, webhookHandler.Redeliver)
+			}
+		}
+
+		// Admin routes (admin and support staff)
+		if r.adminService != nil {
+			adminHandler := handlers.NewAdminHandler(r.adminService, mappers.NewAdminMapper(), transcriptionMapper, mappers.NewUsageMapper())
+			adminOnly := middleware.RequireRole(entities.RoleAdmin)
+
+			admin := v1.Group("/admin")
+			admin.Use(middleware.AuthMiddleware(r.authService), apiLimit, middleware.RequireRole(entities.RoleAdmin, entities.RoleSupport))
+			{
+				admin.GET("/users", adminHandler.SearchUsers)
+				admin.GET("/users/:id", adminHandler.GetUser)
+				admin.GET("/users/:id/transcriptions", adminHandler.GetUserTranscriptions)
+				admin.GET("/transcriptions/:id", adminHandler.GetTranscription)
+
+				admin.PATCH("/users/:id/role", adminOnly, adminHandler.SetRole)
+				admin.POST("/users/:id/disable", adminOnly, adminHandler.DisableUser)
+				admin.POST("/users/:id/enable", adminOnly, adminHandler.EnableUser)
+				admin.POST("/users/:id/quota/reset", adminOnly, adminHandler.ResetQuota)
+				admin.GET("/audit-log", adminOnly, adminHandler.GetAuditLog)
+			}
 		}
 	}
 
 	return r.engine
 }
+
+func (r *Router) idempotencyMiddleware() gin.HandlerFunc {
+	if r.idempotencyService == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return middleware.IdempotencyMiddleware(r.idempotencyService)
+}
+
+func (r *Router) rateLimitMiddleware(policy entities.RateLimitPolicy, keyFunc middleware.RateLimitKeyFunc) gin.HandlerFunc {
+	if r.rateLimitStore == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return middleware.RateLimitMiddleware(r.rateLimitStore, policy, keyFunc)
+}
@@ -0,0 +1,69 @@
+package mappers
+
+import (
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// AdminMapper handles mapping of administration data to DTOs
+type AdminMapper struct{}
+
+// NewAdminMapper creates a new AdminMapper
+func NewAdminMapper() *AdminMapper {
+	return &AdminMapper{}
+}
+
+// ToUserDTO converts a User entity to an AdminUserDTO
+func (m *AdminMapper) ToUserDTO(user *entities.User) *dto.AdminUserDTO {
+	if user == nil {
+		return nil
+	}
+
+	return &dto.AdminUserDTO{
+		ID:            user.ID.String(),
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		Name:          user.Name,
+		Plan:          user.Plan,
+		Role:          user.Role,
+		Disabled:      user.Disabled,
+		DisabledAt:    user.DisabledAt,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+}
+
+// ToUserListDTO converts a page of users to an AdminUserListDTO
+func (m *AdminMapper) ToUserListDTO(page *services.UserPage) *dto.AdminUserListDTO {
+	users := make([]*dto.AdminUserDTO, len(page.Users))
+	for i, user := range page.Users {
+		users[i] = m.ToUserDTO(user)
+	}
+
+	return &dto.AdminUserListDTO{
+		Users:  users,
+		Total:  page.Total,
+		Offset: page.Offset,
+		Limit:  page.Limit,
+	}
+}
+
+// ToAuditLogDTOs converts audit log entries to DTOs
+func (m *AdminMapper) ToAuditLogDTOs(entries []*entities.AuditLogEntry) []*dto.AuditLogEntryDTO {
+	result := make([]*dto.AuditLogEntryDTO, len(entries))
+	for i, entry := range entries {
+		result[i] = &dto.AuditLogEntryDTO{
+			ID:         entry.ID.String(),
+			ActorID:    entry.ActorID.String(),
+			ActorRole:  entry.ActorRole,
+			Action:     entry.Action,
+			TargetType: entry.TargetType,
+			TargetID:   entry.TargetID.String(),
+			Details:    entry.Details,
+			IP:         entry.IP,
+			CreatedAt:  entry.CreatedAt,
+		}
+	}
+	return result
+}
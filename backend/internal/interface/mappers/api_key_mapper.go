@@ -0,0 +1,51 @@
+package mappers
+
+import (
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// APIKeyMapper handles mapping between APIKey entities and DTOs
+type APIKeyMapper struct{}
+
+// NewAPIKeyMapper creates a new APIKeyMapper
+func NewAPIKeyMapper() *APIKeyMapper {
+	return &APIKeyMapper{}
+}
+
+// ToDTO converts an APIKey entity to an APIKeyDTO without the secret
+func (m *APIKeyMapper) ToDTO(key *entities.APIKey) *dto.APIKeyDTO {
+	if key == nil {
+		return nil
+	}
+
+	return &dto.APIKeyDTO{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		LastUsedAt: key.LastUsedAt,
+		ExpiresAt:  key.ExpiresAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// ToCreatedDTO converts a newly created APIKey to an APIKeyDTO including the
+// plaintext key, which is only revealed once
+func (m *APIKeyMapper) ToCreatedDTO(key *entities.APIKey, secret string) *dto.APIKeyDTO {
+	result := m.ToDTO(key)
+	if result != nil {
+		result.Key = secret
+	}
+	return result
+}
+
+// ToDTOs converts a slice of APIKey entities to APIKeyDTOs
+func (m *APIKeyMapper) ToDTOs(keys []*entities.APIKey) []*dto.APIKeyDTO {
+	dtos := make([]*dto.APIKeyDTO, len(keys))
+	for i, key := range keys {
+		dtos[i] = m.ToDTO(key)
+	}
+	return dtos
+}
@@ -0,0 +1,111 @@
+package mappers
+
+import (
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// CommentMapper handles mapping between comments, highlights and DTOs
+type CommentMapper struct{}
+
+// NewCommentMapper creates a new CommentMapper
+func NewCommentMapper() *CommentMapper {
+	return &CommentMapper{}
+}
+
+// ToDTO converts a Comment entity to a CommentDTO without replies
+func (m *CommentMapper) ToDTO(comment *entities.Comment) *dto.CommentDTO {
+	if comment == nil {
+		return nil
+	}
+
+	result := &dto.CommentDTO{
+		ID:         comment.ID.String(),
+		UserID:     comment.UserID.String(),
+		Body:       comment.Body,
+		Anchor:     m.toAnchorDTO(comment.Anchor),
+		Resolved:   comment.IsResolved(),
+		ResolvedAt: comment.ResolvedAt,
+		CreatedAt:  comment.CreatedAt,
+		UpdatedAt:  comment.UpdatedAt,
+	}
+
+	if comment.ParentID != nil {
+		result.ParentID = comment.ParentID.String()
+	}
+
+	if comment.ResolvedBy != nil {
+		result.ResolvedBy = comment.ResolvedBy.String()
+	}
+
+	return result
+}
+
+// ToThreadDTO converts a thread to the CommentDTO of its top-level comment
+// carrying the replies
+func (m *CommentMapper) ToThreadDTO(thread *services.Thread) *dto.CommentDTO {
+	if thread == nil {
+		return nil
+	}
+
+	result := m.ToDTO(thread.Comment)
+	result.Replies = make([]*dto.CommentDTO, len(thread.Replies))
+	for i, reply := range thread.Replies {
+		result.Replies[i] = m.ToDTO(reply)
+	}
+	return result
+}
+
+// ToThreadDTOs converts a slice of threads to CommentDTOs
+func (m *CommentMapper) ToThreadDTOs(threads []*services.Thread) []*dto.CommentDTO {
+	dtos := make([]*dto.CommentDTO, len(threads))
+	for i, thread := range threads {
+		dtos[i] = m.ToThreadDTO(thread)
+	}
+	return dtos
+}
+
+// ToHighlightDTO converts a Highlight entity to a HighlightDTO
+func (m *CommentMapper) ToHighlightDTO(highlight *entities.Highlight) *dto.HighlightDTO {
+	if highlight == nil {
+		return nil
+	}
+
+	result := &dto.HighlightDTO{
+		ID:        highlight.ID.String(),
+		UserID:    highlight.UserID.String(),
+		Color:     highlight.Color,
+		Note:      highlight.Note,
+		CreatedAt: highlight.CreatedAt,
+	}
+
+	if anchor := m.toAnchorDTO(highlight.Anchor); anchor != nil {
+		result.Anchor = *anchor
+	}
+
+	return result
+}
+
+// ToHighlightDTOs converts a slice of Highlight entities to HighlightDTOs
+func (m *CommentMapper) ToHighlightDTOs(highlights []*entities.Highlight) []*dto.HighlightDTO {
+	dtos := make([]*dto.HighlightDTO, len(highlights))
+	for i, highlight := range highlights {
+		dtos[i] = m.ToHighlightDTO(highlight)
+	}
+	return dtos
+}
+
+func (m *CommentMapper) toAnchorDTO(anchor *entities.Anchor) *dto.AnchorDTO {
+	if anchor == nil {
+		return nil
+	}
+
+	return &dto.AnchorDTO{
+		StartTime: anchor.StartTime,
+		EndTime:   anchor.EndTime,
+		TextStart: anchor.TextStart,
+		TextEnd:   anchor.TextEnd,
+		Quote:     anchor.Quote,
+	}
+}
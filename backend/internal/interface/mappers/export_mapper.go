@@ -0,0 +1,58 @@
+package mappers
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// ExportMapper handles mapping between ExportJob entities and DTOs
+type ExportMapper struct{}
+
+// NewExportMapper creates a new ExportMapper
+func NewExportMapper() *ExportMapper {
+	return &ExportMapper{}
+}
+
+// ToDTO converts an ExportJob to an ExportJobDTO, including the download
+// URL when a signed link is given
+func (m *ExportMapper) ToDTO(job *entities.ExportJob, link *services.DownloadLink) *dto.ExportJobDTO {
+	if job == nil {
+		return nil
+	}
+
+	result := &dto.ExportJobDTO{
+		ID:          job.ID.String(),
+		Status:      string(job.Status),
+		Size:        job.Size,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+		ExpiresAt:   job.ExpiresAt,
+	}
+
+	if link != nil {
+		query := url.Values{}
+		query.Set("expires", strconv.FormatInt(link.ExpiresAt.Unix(), 10))
+		query.Set("signature", link.Signature)
+
+		expiresAt := link.ExpiresAt
+		result.DownloadURL = fmt.Sprintf("/api/v1/exports/%s/download?%s", job.ID, query.Encode())
+		result.DownloadExpiresAt = &expiresAt
+	}
+
+	return result
+}
+
+// ToDTOs converts export jobs to DTOs without download links
+func (m *ExportMapper) ToDTOs(jobs []*entities.ExportJob) []*dto.ExportJobDTO {
+	result := make([]*dto.ExportJobDTO, len(jobs))
+	for i, job := range jobs {
+		result[i] = m.ToDTO(job, nil)
+	}
+	return result
+}
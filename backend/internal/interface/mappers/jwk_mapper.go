@@ -0,0 +1,58 @@
+package mappers
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// JWKMapper handles mapping between signing keys and JSON Web Keys
+type JWKMapper struct{}
+
+// NewJWKMapper creates a new JWKMapper
+func NewJWKMapper() *JWKMapper {
+	return &JWKMapper{}
+}
+
+// ToDTO converts the public half of a SigningKey to a JWKDTO
+func (m *JWKMapper) ToDTO(key *entities.SigningKey) *dto.JWKDTO {
+	if key == nil {
+		return nil
+	}
+
+	result := &dto.JWKDTO{
+		KeyID:     key.ID,
+		Algorithm: key.Algorithm,
+		Use:       "sig",
+	}
+
+	switch publicKey := key.PublicKey().(type) {
+	case *rsa.PublicKey:
+		result.KeyType = "RSA"
+		result.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		result.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case ed25519.PublicKey:
+		result.KeyType = "OKP"
+		result.Curve = "Ed25519"
+		result.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	default:
+		return nil
+	}
+
+	return result
+}
+
+// ToSetDTO converts signing keys to a JWKSetDTO
+func (m *JWKMapper) ToSetDTO(keys []*entities.SigningKey) *dto.JWKSetDTO {
+	result := &dto.JWKSetDTO{Keys: make([]*dto.JWKDTO, 0, len(keys))}
+	for _, key := range keys {
+		if jwk := m.ToDTO(key); jwk != nil {
+			result.Keys = append(result.Keys, jwk)
+		}
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package mappers
+
+import (
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// OrganizationMapper handles mapping between organizations and DTOs
+type OrganizationMapper struct{}
+
+// NewOrganizationMapper creates a new OrganizationMapper
+func NewOrganizationMapper() *OrganizationMapper {
+	return &OrganizationMapper{}
+}
+
+// ToDTO converts an organization and the user's membership to an OrganizationDTO
+func (m *OrganizationMapper) ToDTO(membership *services.Membership) *dto.OrganizationDTO {
+	if membership == nil {
+		return nil
+	}
+
+	return &dto.OrganizationDTO{
+		ID:                membership.Organization.ID.String(),
+		Name:              membership.Organization.Name,
+		Role:              membership.Member.Role,
+		RedactionRequired: membership.Organization.RedactionRequired,
+		CreatedAt:         membership.Organization.CreatedAt,
+	}
+}
+
+// ToDTOs converts a slice of memberships to OrganizationDTOs
+func (m *OrganizationMapper) ToDTOs(memberships []*services.Membership) []*dto.OrganizationDTO {
+	dtos := make([]*dto.OrganizationDTO, len(memberships))
+	for i, membership := range memberships {
+		dtos[i] = m.ToDTO(membership)
+	}
+	return dtos
+}
+
+// ToMemberDTO converts a member and their account to an OrganizationMemberDTO
+func (m *OrganizationMapper) ToMemberDTO(profile *services.MemberProfile) *dto.OrganizationMemberDTO {
+	if profile == nil {
+		return nil
+	}
+
+	return &dto.OrganizationMemberDTO{
+		UserID:   profile.Member.UserID.String(),
+		Email:    profile.User.Email,
+		Name:     profile.User.Name,
+		Role:     profile.Member.Role,
+		JoinedAt: profile.Member.CreatedAt,
+	}
+}
+
+// ToMemberDTOs converts a slice of members to OrganizationMemberDTOs
+func (m *OrganizationMapper) ToMemberDTOs(profiles []*services.MemberProfile) []*dto.OrganizationMemberDTO {
+	dtos := make([]*dto.OrganizationMemberDTO, len(profiles))
+	for i, profile := range profiles {
+		dtos[i] = m.ToMemberDTO(profile)
+	}
+	return dtos
+}
+
+// ToInvitationDTO converts an invitation to an OrganizationInvitationDTO
+// without its token
+func (m *OrganizationMapper) ToInvitationDTO(invitation *entities.OrganizationInvitation) *dto.OrganizationInvitationDTO {
+	if invitation == nil {
+		return nil
+	}
+
+	return &dto.OrganizationInvitationDTO{
+		ID:        invitation.ID.String(),
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+}
+
+// ToInvitationDTOs converts a slice of invitations to OrganizationInvitationDTOs
+func (m *OrganizationMapper) ToInvitationDTOs(invitations []*entities.OrganizationInvitation) []*dto.OrganizationInvitationDTO {
+	dtos := make([]*dto.OrganizationInvitationDTO, len(invitations))
+	for i, invitation := range invitations {
+		dtos[i] = m.ToInvitationDTO(invitation)
+	}
+	return dtos
+}
@@ -0,0 +1,43 @@
+package mappers
+
+import (
+	"github.com/google/uuid"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// SessionMapper handles mapping between Session entities and DTOs
+type SessionMapper struct{}
+
+// NewSessionMapper creates a new SessionMapper
+func NewSessionMapper() *SessionMapper {
+	return &SessionMapper{}
+}
+
+// ToDTO converts a Session entity to a SessionDTO, marking it as current when
+// it is the session of the requesting token
+func (m *SessionMapper) ToDTO(session *entities.Session, currentID uuid.UUID) *dto.SessionDTO {
+	if session == nil {
+		return nil
+	}
+
+	return &dto.SessionDTO{
+		ID:         session.ID.String(),
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		Current:    currentID != uuid.Nil && session.ID == currentID,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
+
+// ToDTOs converts a slice of Session entities to SessionDTOs
+func (m *SessionMapper) ToDTOs(sessions []*entities.Session, currentID uuid.UUID) []*dto.SessionDTO {
+	dtos := make([]*dto.SessionDTO, len(sessions))
+	for i, session := range sessions {
+		dtos[i] = m.ToDTO(session, currentID)
+	}
+	return dtos
+}
@@ -0,0 +1,91 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// SharingMapper handles mapping between transcription shares, links and DTOs
+type SharingMapper struct{}
+
+// NewSharingMapper creates a new SharingMapper
+func NewSharingMapper() *SharingMapper {
+	return &SharingMapper{}
+}
+
+// ToShareDTO converts a share to a TranscriptionShareDTO
+func (m *SharingMapper) ToShareDTO(share *entities.TranscriptionShare) *dto.TranscriptionShareDTO {
+	if share == nil {
+		return nil
+	}
+
+	return &dto.TranscriptionShareDTO{
+		ID:         share.ID.String(),
+		Email:      share.Email,
+		Permission: share.Permission,
+		CreatedAt:  share.CreatedAt,
+	}
+}
+
+// ToShareDTOs converts a slice of shares to TranscriptionShareDTOs
+func (m *SharingMapper) ToShareDTOs(shares []*entities.TranscriptionShare) []*dto.TranscriptionShareDTO {
+	dtos := make([]*dto.TranscriptionShareDTO, len(shares))
+	for i, share := range shares {
+		dtos[i] = m.ToShareDTO(share)
+	}
+	return dtos
+}
+
+// ToLinkDTO converts a share link to a ShareLinkDTO without its URL
+func (m *SharingMapper) ToLinkDTO(link *entities.ShareLink) *dto.ShareLinkDTO {
+	if link == nil {
+		return nil
+	}
+
+	return &dto.ShareLinkDTO{
+		ID:        link.ID.String(),
+		Active:    link.IsActive(time.Now()),
+		ExpiresAt: link.ExpiresAt,
+		RevokedAt: link.RevokedAt,
+		CreatedAt: link.CreatedAt,
+	}
+}
+
+// ToLinkDTOs converts a slice of share links to ShareLinkDTOs
+func (m *SharingMapper) ToLinkDTOs(links []*entities.ShareLink) []*dto.ShareLinkDTO {
+	dtos := make([]*dto.ShareLinkDTO, len(links))
+	for i, link := range links {
+		dtos[i] = m.ToLinkDTO(link)
+	}
+	return dtos
+}
+
+// ToCreatedLinkDTO converts a newly created link to a ShareLinkDTO with its URL
+func (m *SharingMapper) ToCreatedLinkDTO(created *services.CreatedShareLink) *dto.ShareLinkDTO {
+	if created == nil {
+		return nil
+	}
+
+	result := m.ToLinkDTO(created.Link)
+	result.URL = created.URL
+	return result
+}
+
+// ToSharedTranscriptionDTO converts a transcription opened through a public
+// link to a SharedTranscriptionDTO
+func (m *SharingMapper) ToSharedTranscriptionDTO(transcription *entities.Transcription) *dto.SharedTranscriptionDTO {
+	if transcription == nil {
+		return nil
+	}
+
+	return &dto.SharedTranscriptionDTO{
+		ID:        transcription.ID.String(),
+		Text:      transcription.Text,
+		Status:    string(transcription.Status),
+		Duration:  transcription.Duration,
+		CreatedAt: transcription.CreatedAt,
+	}
+}
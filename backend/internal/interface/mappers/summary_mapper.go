@@ -0,0 +1,31 @@
+package mappers
+
+import (
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// SummaryMapper handles mapping between summaries and DTOs
+type SummaryMapper struct{}
+
+// NewSummaryMapper creates a new SummaryMapper
+func NewSummaryMapper() *SummaryMapper {
+	return &SummaryMapper{}
+}
+
+// ToDTO converts the summary of a transcription to a SummaryDTO
+func (m *SummaryMapper) ToDTO(transcription *entities.Transcription) *dto.SummaryDTO {
+	if transcription == nil || transcription.Summary == nil {
+		return nil
+	}
+
+	summary := transcription.Summary
+	return &dto.SummaryDTO{
+		Summary:     summary.Text,
+		KeyPoints:   summary.KeyPoints,
+		ActionItems: summary.ActionItems,
+		Model:       summary.Model,
+		Stale:       !summary.IsCurrent(transcription.Text),
+		GeneratedAt: summary.GeneratedAt,
+	}
+}
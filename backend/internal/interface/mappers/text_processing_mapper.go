@@ -0,0 +1,38 @@
+package mappers
+
+import (
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// TextProcessingMapper handles mapping between text processing settings and DTOs
+type TextProcessingMapper struct{}
+
+// NewTextProcessingMapper creates a new TextProcessingMapper
+func NewTextProcessingMapper() *TextProcessingMapper {
+	return &TextProcessingMapper{}
+}
+
+// ToDTO converts text processing settings to a TextProcessingDTO
+func (m *TextProcessingMapper) ToDTO(settings *entities.TextProcessingSettings) *dto.TextProcessingDTO {
+	if settings == nil {
+		return nil
+	}
+
+	result := &dto.TextProcessingDTO{
+		Stages:          make([]string, len(settings.Stages)),
+		Replacements:    make([]dto.TextReplacementDTO, len(settings.Replacements)),
+		AvailableStages: make([]string, len(entities.TextStages)),
+		UpdatedAt:       settings.UpdatedAt,
+	}
+	for i, stage := range settings.Stages {
+		result.Stages[i] = string(stage)
+	}
+	for i, replacement := range settings.Replacements {
+		result.Replacements[i] = dto.TextReplacementDTO{Find: replacement.Find, Replace: replacement.Replace}
+	}
+	for i, stage := range entities.TextStages {
+		result.AvailableStages[i] = string(stage)
+	}
+	return result
+}
@@ -1,6 +1,7 @@
 package mappers
 
 import (
+	"github.com/voiceline/backend/internal/application/services"
 	"github.com/voiceline/backend/internal/domain/entities"
 	"github.com/voiceline/backend/internal/interface/dto"
 )
@@ -19,14 +20,57 @@ func (m *TranscriptionMapper) ToDTO(transcription *entities.Transcription) *dto.
 		return nil
 	}
 
-	return &dto.TranscriptionDTO{
+	result := &dto.TranscriptionDTO{
 		ID:        transcription.ID.String(),
 		UserID:    transcription.UserID.String(),
 		Text:      transcription.Text,
 		Status:    string(transcription.Status),
 		Duration:  transcription.Duration,
+		Title:     transcription.Title,
+		Folder:    transcription.Folder,
+		Tags:      transcription.Tags,
+		Metadata:  transcription.Metadata,
 		CreatedAt: transcription.CreatedAt,
 	}
+
+	if segments := transcription.SpeakerSegments(); len(segments) > 0 {
+		result.Segments = make([]dto.SegmentDTO, len(segments))
+		for i, segment := range segments {
+			result.Segments[i] = dto.SegmentDTO{
+				Start:       segment.Start,
+				End:         segment.End,
+				Speaker:     segment.Speaker,
+				SpeakerName: transcription.SpeakerName(segment.Speaker),
+				Text:        segment.Text,
+			}
+		}
+	}
+
+	// The raw text is never returned while redaction is required
+	if transcription.RawText != transcription.Text && !transcription.RedactionRequired {
+		result.RawText = transcription.RawText
+	}
+
+	result.Redacted = transcription.RedactionRequired
+	if redaction := transcription.Redaction; redaction != nil && len(redaction.Findings) > 0 {
+		if !transcription.RedactionRequired {
+			result.RedactedText = redaction.Text
+		}
+		result.PII = make(map[string]int)
+		for kind, count := range redaction.PIICounts() {
+			result.PII[string(kind)] = count
+		}
+	}
+
+	if transcription.DuplicateOf != nil {
+		result.DuplicateOf = transcription.DuplicateOf.String()
+	}
+
+	if transcription.OrganizationID != nil {
+		result.OrganizationID = transcription.OrganizationID.String()
+	}
+
+	return result
 }
 
 // ToDTOs converts a slice of Transcription entities to TranscriptionDTOs
@@ -37,3 +81,22 @@ func (m *TranscriptionMapper) ToDTOs(transcriptions []*entities.Transcription) [
 	}
 	return dtos
 }
+
+// ToSpeakerDTOs lists the speakers of a transcription with their names
+func (m *TranscriptionMapper) ToSpeakerDTOs(transcription *entities.Transcription) []*dto.SpeakerDTO {
+	labels := transcription.Speakers()
+	dtos := make([]*dto.SpeakerDTO, len(labels))
+	for i, label := range labels {
+		dtos[i] = &dto.SpeakerDTO{Label: label, Name: transcription.SpeakerName(label)}
+	}
+	return dtos
+}
+
+// ToTagDTOs converts tag counts to TagDTOs
+func (m *TranscriptionMapper) ToTagDTOs(tags []*services.TagCount) []*dto.TagDTO {
+	dtos := make([]*dto.TagDTO, len(tags))
+	for i, tag := range tags {
+		dtos[i] = &dto.TagDTO{Name: tag.Tag, Count: tag.Count}
+	}
+	return dtos
+}
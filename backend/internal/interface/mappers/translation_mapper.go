@@ -0,0 +1,50 @@
+package mappers
+
+import (
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// TranslationMapper handles mapping between translations and DTOs
+type TranslationMapper struct{}
+
+// NewTranslationMapper creates a new TranslationMapper
+func NewTranslationMapper() *TranslationMapper {
+	return &TranslationMapper{}
+}
+
+// ToDTO converts a Translation entity to a TranslationDTO
+func (m *TranslationMapper) ToDTO(translation *entities.Translation) *dto.TranslationDTO {
+	if translation == nil {
+		return nil
+	}
+
+	result := &dto.TranslationDTO{
+		ID:              translation.ID.String(),
+		TranscriptionID: translation.TranscriptionID.String(),
+		Language:        translation.Language,
+		Version:         translation.Version,
+		Text:            translation.Text,
+		CreatedAt:       translation.CreatedAt,
+	}
+
+	for _, segment := range translation.Segments {
+		result.Segments = append(result.Segments, dto.SegmentDTO{
+			Start:   segment.Start,
+			End:     segment.End,
+			Speaker: segment.Speaker,
+			Text:    segment.Text,
+		})
+	}
+
+	return result
+}
+
+// ToDTOs converts a slice of Translation entities to TranslationDTOs
+func (m *TranslationMapper) ToDTOs(translations []*entities.Translation) []*dto.TranslationDTO {
+	dtos := make([]*dto.TranslationDTO, len(translations))
+	for i, translation := range translations {
+		dtos[i] = m.ToDTO(translation)
+	}
+	return dtos
+}
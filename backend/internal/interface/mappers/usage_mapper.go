@@ -0,0 +1,35 @@
+package mappers
+
+import (
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// UsageMapper handles mapping between usage summaries and DTOs
+type UsageMapper struct{}
+
+// NewUsageMapper creates a new UsageMapper
+func NewUsageMapper() *UsageMapper {
+	return &UsageMapper{}
+}
+
+// ToDTO converts a UsageSummary to a UsageDTO
+func (m *UsageMapper) ToDTO(summary *services.UsageSummary) *dto.UsageDTO {
+	if summary == nil {
+		return nil
+	}
+
+	return &dto.UsageDTO{
+		Plan:             summary.Plan,
+		PeriodStart:      summary.PeriodStart,
+		PeriodEnd:        summary.PeriodEnd,
+		QuotaMinutes:     summary.QuotaMinutes,
+		UsedMinutes:      summary.UsedMinutes(),
+		RemainingMinutes: summary.RemainingMinutes(),
+		AudioSeconds:     summary.AudioSeconds,
+		EstimatedCost:    summary.EstimatedCost,
+		Transcriptions:   summary.Transcriptions,
+		Summaries:        summary.Summaries,
+		Translations:     summary.Translations,
+	}
+}
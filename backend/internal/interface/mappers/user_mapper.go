@@ -20,10 +20,13 @@ func (m *UserMapper) ToDTO(user *entities.User) *dto.UserDTO {
 	}
 
 	return &dto.UserDTO{
-		ID:        user.ID.String(),
-		Email:     user.Email,
-		Name:      user.Name,
-		CreatedAt: user.CreatedAt,
+		ID:            user.ID.String(),
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		Name:          user.Name,
+		Plan:          user.Plan,
+		Role:          user.Role,
+		CreatedAt:     user.CreatedAt,
 	}
 }
 
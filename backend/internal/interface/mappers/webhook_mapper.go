@@ -0,0 +1,90 @@
+package mappers
+
+import (
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/dto"
+)
+
+// WebhookMapper handles mapping between Webhook entities and DTOs
+type WebhookMapper struct{}
+
+// NewWebhookMapper creates a new WebhookMapper
+func NewWebhookMapper() *WebhookMapper {
+	return &WebhookMapper{}
+}
+
+// ToDTO converts a Webhook entity to a WebhookDTO without its signing secret
+func (m *WebhookMapper) ToDTO(webhook *entities.Webhook) *dto.WebhookDTO {
+	if webhook == nil {
+		return nil
+	}
+
+	return &dto.WebhookDTO{
+		ID:        webhook.ID.String(),
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+// ToCreatedDTO converts a newly created Webhook to a WebhookDTO including the
+// signing secret, which is only revealed once
+func (m *WebhookMapper) ToCreatedDTO(webhook *entities.Webhook) *dto.WebhookDTO {
+	result := m.ToDTO(webhook)
+	if result != nil {
+		result.Secret = webhook.Secret
+	}
+	return result
+}
+
+// ToDTOs converts a slice of Webhook entities to WebhookDTOs
+func (m *WebhookMapper) ToDTOs(webhooks []*entities.Webhook) []*dto.WebhookDTO {
+	dtos := make([]*dto.WebhookDTO, len(webhooks))
+	for i, webhook := range webhooks {
+		dtos[i] = m.ToDTO(webhook)
+	}
+	return dtos
+}
+
+// ToDeliveryDTO converts a WebhookDelivery entity to a WebhookDeliveryDTO
+func (m *WebhookMapper) ToDeliveryDTO(delivery *entities.WebhookDelivery) *dto.WebhookDeliveryDTO {
+	if delivery == nil {
+		return nil
+	}
+
+	result := &dto.WebhookDeliveryDTO{
+		ID:             delivery.ID.String(),
+		WebhookID:      delivery.WebhookID.String(),
+		EventID:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		Payload:        string(delivery.Payload),
+		CreatedAt:      delivery.CreatedAt,
+	}
+
+	if delivery.IsPending() {
+		nextAttemptAt := delivery.NextAttemptAt
+		result.NextAttemptAt = &nextAttemptAt
+	}
+
+	if delivery.RedeliveryOf != nil {
+		result.RedeliveryOf = delivery.RedeliveryOf.String()
+	}
+
+	return result
+}
+
+// ToDeliveryDTOs converts a slice of WebhookDelivery entities to DTOs
+func (m *WebhookMapper) ToDeliveryDTOs(deliveries []*entities.WebhookDelivery) []*dto.WebhookDeliveryDTO {
+	dtos := make([]*dto.WebhookDeliveryDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		dtos[i] = m.ToDeliveryDTO(delivery)
+	}
+	return dtos
+}
@@ -0,0 +1,163 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var tokenLinkPattern = regexp.MustCompile(`token=([A-Za-z0-9_-]+)`)
+
+// mailedToken extracts the token from the last link emailed to the address
+func mailedToken(t *testing.T, app *testApp, to string) string {
+	message, ok := app.mailer.LastTo(to)
+	if !assert.True(t, ok, "no email sent to %s", to) {
+		return ""
+	}
+
+	match := tokenLinkPattern.FindStringSubmatch(message.Body)
+	if !assert.Len(t, match, 2, "no token link in email") {
+		return ""
+	}
+	return match[1]
+}
+
+// registerVerifiedUser registers the address and confirms the mailed
+// verification link
+func registerVerifiedUser(t *testing.T, app *testApp, email string) string {
+	token := registerUser(t, app.URL, email)
+
+	app.deliver(t)
+	resp := doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email/confirm", "", map[string]string{
+		"token": mailedToken(t, app, email),
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	return token
+}
+
+func TestAccountIntegration_EmailVerification(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "verify@example.com")
+	app.deliver(t)
+
+	message, ok := app.mailer.LastTo("verify@example.com")
+	assert.True(t, ok)
+	assert.Contains(t, message.Body, "https://app.example.com/verify-email?token=")
+
+	verificationToken := mailedToken(t, app, "verify@example.com")
+
+	resp := doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email/confirm", "", map[string]string{"token": verificationToken})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var user map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&user)
+	assert.Equal(t, true, user["emailVerified"])
+
+	// Tokens are single-use
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email/confirm", "", map[string]string{"token": verificationToken})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email", token, nil)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestAccountIntegration_ResendVerificationInvalidatesEarlierToken(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "resend@example.com")
+	app.deliver(t)
+	firstToken := mailedToken(t, app, "resend@example.com")
+
+	resp := doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email", token, nil)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	secondToken := mailedToken(t, app, "resend@example.com")
+	assert.NotEqual(t, firstToken, secondToken)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email/confirm", "", map[string]string{"token": firstToken})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email/confirm", "", map[string]string{"token": secondToken})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAccountIntegration_PasswordReset(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "forgetful@example.com")
+
+	// Unknown addresses are accepted without sending anything
+	resp := doJSON(t, "POST", app.URL+"/api/v1/auth/password-reset", "", map[string]string{"email": "nobody@example.com"})
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	_, sent := app.mailer.LastTo("nobody@example.com")
+	assert.False(t, sent)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/password-reset", "", map[string]string{"email": "forgetful@example.com"})
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	resetToken := mailedToken(t, app, "forgetful@example.com")
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/password-reset/confirm", "", map[string]string{
+		"token":    resetToken,
+		"password": "short",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/password-reset/confirm", "", map[string]string{
+		"token":    resetToken,
+		"password": "new-password123",
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// Every existing session is logged out
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me", token, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/password-reset/confirm", "", map[string]string{
+		"token":    resetToken,
+		"password": "another-password123",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/login", "", map[string]string{
+		"email":    "forgetful@example.com",
+		"password": "password123",
+	})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/login", "", map[string]string{
+		"email":    "forgetful@example.com",
+		"password": "new-password123",
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	assert.Equal(t, true, result["user"].(map[string]interface{})["emailVerified"])
+}
+
+func TestAccountIntegration_VerificationTokenCannotResetPassword(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	registerUser(t, app.URL, "mixup@example.com")
+	app.deliver(t)
+	verificationToken := mailedToken(t, app, "mixup@example.com")
+
+	resp := doJSON(t, "POST", app.URL+"/api/v1/auth/password-reset/confirm", "", map[string]string{
+		"token":    verificationToken,
+		"password": "new-password123",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email/confirm", "", map[string]string{"token": verificationToken})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,184 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeJSON(resp *http.Response) map[string]interface{} {
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return result
+}
+
+// registerAdmin registers the address listed in ADMIN_EMAILS and verifies it,
+// which grants the admin role
+func registerAdmin(t *testing.T, app *testApp) string {
+	token := registerUser(t, app.URL, "admin@example.com")
+	assert.Equal(t, "user", getMe(t, app.URL, token)["role"])
+
+	app.deliver(t)
+	resp := doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email/confirm", "", map[string]string{
+		"token": mailedToken(t, app, "admin@example.com"),
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	return token
+}
+
+func TestAdminIntegration_RequiresStaffRole(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "regular@example.com")
+	assert.Equal(t, "user", getMe(t, app.URL, token)["role"])
+
+	resp := doJSON(t, "GET", app.URL+"/api/v1/admin/users", token, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/admin/users", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAdminIntegration_SearchUsers(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	adminToken := registerAdmin(t, app)
+	assert.Equal(t, "admin", getMe(t, app.URL, adminToken)["role"])
+	registerUser(t, app.URL, "alice@example.com")
+	registerUser(t, app.URL, "bob@example.com")
+
+	resp := doJSON(t, "GET", app.URL+"/api/v1/admin/users?q=ALICE", adminToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	page := decodeJSON(resp)
+	assert.Equal(t, float64(1), page["total"])
+	users := page["users"].([]interface{})
+	assert.Equal(t, "alice@example.com", users[0].(map[string]interface{})["email"])
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/admin/users?role=admin", adminToken, nil)
+	assert.Equal(t, float64(1), decodeJSON(resp)["total"])
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/admin/users?limit=2&offset=2", adminToken, nil)
+	page = decodeJSON(resp)
+	assert.Equal(t, float64(3), page["total"])
+	assert.Len(t, page["users"], 1)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/admin/users?role=root", adminToken, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAdminIntegration_SupportViewsTranscriptionsWithAudit(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	adminToken := registerAdmin(t, app)
+	supportToken := registerUser(t, app.URL, "support@example.com")
+	supportID := getMe(t, app.URL, supportToken)["id"].(string)
+	customerToken := registerUser(t, app.URL, "customer@example.com")
+	customerID := getMe(t, app.URL, customerToken)["id"].(string)
+
+	resp := doJSON(t, "PATCH", app.URL+"/api/v1/admin/users/"+supportID+"/role", adminToken, map[string]string{"role": "support"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "support", decodeJSON(resp)["role"])
+
+	transcription := decodeTranscription(uploadAudio(t, app.URL, customerToken))
+	transcriptionID := transcription["id"].(string)
+
+	// The role change applies to the support user's existing token
+	resp = doJSON(t, "GET", app.URL+"/api/v1/admin/transcriptions/"+transcriptionID+"?reason=ticket-42", supportToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Hello world", decodeJSON(resp)["text"])
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/admin/users/"+customerID+"/transcriptions", supportToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var transcriptions []interface{}
+	json.NewDecoder(resp.Body).Decode(&transcriptions)
+	assert.Len(t, transcriptions, 1)
+
+	// Support cannot manage accounts or read the audit log
+	resp = doJSON(t, "POST", app.URL+"/api/v1/admin/users/"+customerID+"/disable", supportToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = doJSON(t, "GET", app.URL+"/api/v1/admin/audit-log", supportToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/admin/audit-log?actor_id="+supportID, adminToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var entries []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&entries)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "transcriptions.listed", entries[0]["action"])
+		assert.Equal(t, customerID, entries[0]["target_id"])
+		assert.Equal(t, "transcription.viewed", entries[1]["action"])
+		assert.Equal(t, transcriptionID, entries[1]["target_id"])
+		assert.Equal(t, "support", entries[1]["actor_role"])
+		details := entries[1]["details"].(map[string]interface{})
+		assert.Equal(t, "ticket-42", details["reason"])
+		assert.Equal(t, customerID, details["owner_id"])
+	}
+
+	// Demotion revokes access immediately
+	resp = doJSON(t, "PATCH", app.URL+"/api/v1/admin/users/"+supportID+"/role", adminToken, map[string]string{"role": "user"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doJSON(t, "GET", app.URL+"/api/v1/admin/transcriptions/"+transcriptionID, supportToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestAdminIntegration_DisableAccount(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	adminToken := registerAdmin(t, app)
+	adminID := getMe(t, app.URL, adminToken)["id"].(string)
+	token := registerUser(t, app.URL, "abuser@example.com")
+	userID := getMe(t, app.URL, token)["id"].(string)
+
+	resp := doJSON(t, "POST", app.URL+"/api/v1/admin/users/"+userID+"/disable", adminToken, map[string]string{"reason": "abuse"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, true, decodeJSON(resp)["disabled"])
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me", token, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, "ACCOUNT_DISABLED", decodeJSON(resp)["code"])
+
+	resp = login(t, app.URL, "abuser@example.com", "password123")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// A wrong password does not reveal that the account is disabled
+	resp = login(t, app.URL, "abuser@example.com", "wrong-password")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/admin/users/"+userID+"/enable", adminToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/admin/users/"+adminID+"/disable", adminToken, nil)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestAdminIntegration_ResetQuota(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	adminToken := registerAdmin(t, app)
+	token := registerUser(t, app.URL, "heavy@example.com")
+	userID := getMe(t, app.URL, token)["id"].(string)
+
+	app.transcriber.duration = 3600
+	resp := uploadAudio(t, app.URL, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = uploadAudioWithHeaders(t, app.URL, token, []byte("more audio"), nil)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/admin/users/"+userID+"/quota/reset", adminToken, map[string]string{"reason": "goodwill"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, float64(0), decodeJSON(resp)["used_minutes"])
+
+	resp = uploadAudioWithHeaders(t, app.URL, token, []byte("more audio"), nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
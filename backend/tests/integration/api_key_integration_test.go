@@ -0,0 +1,140 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createAPIKey(t *testing.T, serverURL, token string, scopes ...string) (string, string) {
+	resp := doJSON(t, "POST", serverURL+"/api/v1/api-keys", token, map[string]interface{}{
+		"name":   "CI pipeline",
+		"scopes": scopes,
+	})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var key map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&key)
+	return key["id"].(string), key["key"].(string)
+}
+
+func withAPIKey(key string) map[string]string {
+	return map[string]string{"X-API-Key": key}
+}
+
+func TestAPIKeyIntegration_ScopesAreEnforced(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "scripts@example.com")
+	_, readKey := createAPIKey(t, app.URL, token, "transcriptions:read")
+	_, writeKey := createAPIKey(t, app.URL, token, "transcriptions:read", "transcriptions:write")
+
+	resp := uploadAudioWithHeaders(t, app.URL, "", []byte("audio"), withAPIKey(readKey))
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	var errBody map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&errBody)
+	assert.Equal(t, "INSUFFICIENT_SCOPE", errBody["code"])
+
+	resp = uploadAudioWithHeaders(t, app.URL, "", []byte("audio"), withAPIKey(writeKey))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSONWithHeaders(t, "GET", app.URL+"/api/v1/transcriptions", "", nil, withAPIKey(readKey))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var transcriptions []interface{}
+	json.NewDecoder(resp.Body).Decode(&transcriptions)
+	assert.Len(t, transcriptions, 1)
+
+	resp = doJSONWithHeaders(t, "GET", app.URL+"/api/v1/usage", "", nil, withAPIKey(readKey))
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// Account management needs a login token
+	resp = doJSONWithHeaders(t, "GET", app.URL+"/api/v1/users/me", "", nil, withAPIKey(writeKey))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = doJSONWithHeaders(t, "GET", app.URL+"/api/v1/api-keys", "", nil, withAPIKey(writeKey))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = doJSONWithHeaders(t, "GET", app.URL+"/api/v1/transcriptions", "", nil, withAPIKey("vl_not-a-key"))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAPIKeyIntegration_ListAndRevoke(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "revoke@example.com")
+	other := registerUser(t, app.URL, "other@example.com")
+	id, key := createAPIKey(t, app.URL, token, "transcriptions:read")
+
+	resp := doJSONWithHeaders(t, "GET", app.URL+"/api/v1/transcriptions", "", nil, withAPIKey(key))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/api-keys", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var keys []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&keys)
+	if assert.Len(t, keys, 1) {
+		assert.Nil(t, keys[0]["key"])
+		assert.Equal(t, key[:len(keys[0]["prefix"].(string))], keys[0]["prefix"])
+		assert.NotNil(t, keys[0]["last_used_at"])
+	}
+
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/api-keys/"+id, other, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/api-keys/"+id, token, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = doJSONWithHeaders(t, "GET", app.URL+"/api/v1/transcriptions", "", nil, withAPIKey(key))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAPIKeyIntegration_Validation(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "invalid-keys@example.com")
+
+	resp := doJSON(t, "POST", app.URL+"/api/v1/api-keys", token, map[string]interface{}{
+		"name":   "Bad scope",
+		"scopes": []string{"everything"},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/api-keys", token, map[string]interface{}{
+		"name":       "Expired",
+		"scopes":     []string{"usage:read"},
+		"expires_at": time.Now().Add(-time.Hour),
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAPIKeyIntegration_DisabledAccount(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	adminToken := registerAdmin(t, app)
+	token := registerUser(t, app.URL, "blocked@example.com")
+	userID := getMe(t, app.URL, token)["id"].(string)
+	_, key := createAPIKey(t, app.URL, token, "usage:read")
+
+	resp := doJSON(t, "POST", app.URL+"/api/v1/admin/users/"+userID+"/disable", adminToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSONWithHeaders(t, "GET", app.URL+"/api/v1/usage", "", nil, withAPIKey(key))
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestAPIKeyIntegration_NoStaffPrivileges(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	adminToken := registerAdmin(t, app)
+	_, key := createAPIKey(t, app.URL, adminToken, "transcriptions:read")
+
+	resp := doJSONWithHeaders(t, "GET", app.URL+"/api/v1/admin/users", "", nil, withAPIKey(key))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,318 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/infrastructure/mail"
+	"github.com/voiceline/backend/internal/infrastructure/oidc"
+	"github.com/voiceline/backend/internal/infrastructure/persistence"
+	"github.com/voiceline/backend/internal/infrastructure/summarizer"
+	"github.com/voiceline/backend/internal/infrastructure/webhook"
+	httpInterface "github.com/voiceline/backend/internal/interface/http"
+)
+
+// stubTranscriber returns a fixed transcription without calling a provider
+type stubTranscriber struct {
+	text     string
+	duration float64
+	calls    int32
+}
+
+func (s *stubTranscriber) TranscribeAudio(ctx context.Context, audio io.Reader) (string, float64, error) {
+	atomic.AddInt32(&s.calls, 1)
+	_, _ = io.Copy(io.Discard, audio)
+	return s.text, s.duration, nil
+}
+
+func (s *stubTranscriber) Calls() int {
+	return int(atomic.LoadInt32(&s.calls))
+}
+
+// stubDiarizer attributes the words of the text to two alternating speakers,
+// splitting the duration evenly between them
+type stubDiarizer struct{}
+
+func (stubDiarizer) Diarize(ctx context.Context, audio io.Reader, text string, duration float64) ([]entities.Segment, error) {
+	words := strings.Fields(text)
+	step := duration / float64(len(words))
+	segments := make([]entities.Segment, len(words))
+	for i, word := range words {
+		segments[i] = entities.Segment{
+			Start:   float64(i) * step,
+			End:     float64(i+1) * step,
+			Speaker: fmt.Sprintf("SPEAKER_%02d", i%2),
+			Text:    word,
+		}
+	}
+	return segments, nil
+}
+
+// stubTranslator marks each passage with the target language instead of
+// calling a provider
+type stubTranslator struct{}
+
+func (stubTranslator) Translate(ctx context.Context, passages []string, language string) ([]string, error) {
+	translated := make([]string, len(passages))
+	for i, passage := range passages {
+		translated[i] = "[" + language + "] " + passage
+	}
+	return translated, nil
+}
+
+// testApp is a server wired like cmd/server with every optional feature
+// enabled, exposing the services tests need to drive background work
+type testApp struct {
+	*httptest.Server
+	transcriber      *stubTranscriber
+	mailer           *mail.MemoryMailer
+	audioStore       *persistence.MemoryFileStore
+	exportService    *services.ExportService
+	sessionService   *services.SessionService
+	signingKeys      *services.SigningKeyService
+	identityProvider *stubIdentityProvider
+	webhookService   *services.WebhookService
+	usageService     *services.UsageService
+	eventBus         *services.EventBus
+	outboxDispatcher *services.OutboxDispatcher
+}
+
+// Close shuts down the app and its stand-in identity provider
+func (a *testApp) Close() {
+	a.Server.Close()
+	a.identityProvider.Close()
+}
+
+// deliver dispatches pending domain events and attempts the resulting deliveries
+func (a *testApp) deliver(t *testing.T) int {
+	_, err := a.outboxDispatcher.DispatchPending(context.Background())
+	assert.NoError(t, err)
+
+	processed, err := a.webhookService.ProcessDueDeliveries(context.Background())
+	assert.NoError(t, err)
+	return processed
+}
+
+func setupTestApp() *testApp {
+	outboxRepo := persistence.NewMemoryOutboxRepository()
+	transactor := persistence.NewMemoryTransactor()
+	transcriber := &stubTranscriber{text: "Hello world", duration: 3}
+
+	userRepo := persistence.NewMemoryUserRepository()
+	apiKeyService := services.NewAPIKeyService(persistence.NewMemoryAPIKeyRepository())
+	mfaService := services.NewMFAService(persistence.NewMemoryTOTPFactorRepository(), userRepo, services.DefaultMFAIssuer)
+	sessionService := services.NewSessionService(persistence.NewMemorySessionRepository())
+	authService := services.NewAuthService(userRepo, "test-secret").
+		WithOutbox(transactor, outboxRepo).
+		WithAdminEmails([]string{"admin@example.com"}).
+		WithAPIKeys(apiKeyService).
+		WithMFA(mfaService).
+		WithSessions(sessionService)
+
+	signingKeyService := services.NewSigningKeyService(persistence.NewMemorySigningKeyRepository(), services.DefaultSigningKeyPolicy())
+	if err := signingKeyService.EnsureActiveKey(context.Background()); err != nil {
+		panic(err)
+	}
+	authService.WithSigningKeys(signingKeyService)
+
+	usageService := services.NewUsageService(
+		persistence.NewMemoryUsageRepository(),
+		persistence.NewMemoryUsageQuotaRepository(),
+		userRepo,
+		services.DefaultUsagePolicy(),
+	)
+
+	audioStore := persistence.NewMemoryFileStore()
+	transcriptionRepo := persistence.NewMemoryTranscriptionRepository()
+	textProcessingService := services.NewTextProcessingService(persistence.NewMemoryTextProcessingSettingsRepository())
+	transcriptionService := services.NewTranscriptionService(transcriptionRepo, transcriber).
+		WithOutbox(transactor, outboxRepo).
+		WithAudioStore(audioStore).
+		WithUsageMetering(usageService).
+		WithDiarizer(stubDiarizer{}).
+		WithTextProcessing(textProcessingService)
+
+	mailer := mail.NewMemoryMailer()
+	accountService := services.NewAccountService(userRepo, persistence.NewMemoryUserTokenRepository(), mailer, services.AccountLinks{
+		VerifyEmailURL:   "https://app.example.com/verify-email",
+		ResetPasswordURL: "https://app.example.com/reset-password",
+	}).WithAdminEmails([]string{"admin@example.com"}).
+		WithSessions(sessionService)
+
+	organizationService := services.NewOrganizationService(
+		persistence.NewMemoryOrganizationRepository(),
+		persistence.NewMemoryOrganizationMemberRepository(),
+		persistence.NewMemoryOrganizationInvitationRepository(),
+		userRepo,
+		mailer,
+		"https://app.example.com/invitations",
+	)
+	sharingService := services.NewSharingService(
+		persistence.NewMemoryTranscriptionShareRepository(),
+		persistence.NewMemoryShareLinkRepository(),
+		transcriptionRepo,
+		userRepo,
+		"https://app.example.com/shared",
+	).WithNotifications(mailer, "https://app.example.com/transcriptions")
+	transcriptionService.WithOrganizations(organizationService).WithSharing(sharingService)
+	commentService := services.NewCommentService(
+		persistence.NewMemoryCommentRepository(),
+		persistence.NewMemoryHighlightRepository(),
+		transcriptionService,
+	)
+	summaryService := services.NewSummaryService(transcriptionRepo, transcriptionService, summarizer.NewLocalSummarizer()).
+		WithUsageMetering(usageService)
+	translationRepo := persistence.NewMemoryTranslationRepository()
+	translationService := services.NewTranslationService(translationRepo, transcriptionService, stubTranslator{}).
+		WithUsageMetering(usageService)
+
+	userService := services.NewUserService(userRepo, transcriptionService).
+		WithOutbox(transactor, outboxRepo).
+		WithAccountService(accountService).
+		WithMFA(mfaService).
+		WithSessions(sessionService)
+
+	exportService := services.NewExportService(
+		persistence.NewMemoryExportJobRepository(),
+		userRepo,
+		transcriptionRepo,
+		persistence.NewMemoryFileStore(),
+		"test-secret",
+	).WithAudioStore(audioStore).
+		WithTranslations(translationRepo)
+
+	adminService := services.NewAdminService(userRepo, transcriptionRepo, persistence.NewMemoryAuditLogRepository(), usageService)
+
+	identityProvider := newStubIdentityProvider()
+	oidcService := services.NewOIDCService(
+		persistence.NewMemoryExternalIdentityRepository(),
+		persistence.NewMemoryOIDCLoginRequestRepository(),
+		userRepo,
+		authService,
+	).WithProvider("corp", oidc.NewProvider(oidc.Config{
+		Issuer:       identityProvider.URL,
+		ClientID:     oidcClientID,
+		ClientSecret: oidcClientSecret,
+		RedirectURL:  oidcRedirectURL,
+	}, 5*time.Second))
+
+	webhookService := services.NewWebhookService(
+		persistence.NewMemoryWebhookRepository(),
+		persistence.NewMemoryWebhookDeliveryRepository(),
+		webhook.NewHTTPSender(0).AllowPrivateNetworks(),
+	).AllowPrivateNetworks()
+	idempotencyService := services.NewIdempotencyService(persistence.NewMemoryIdempotencyRepository(), time.Hour)
+
+	eventBus := services.NewEventBus()
+	webhookService.Subscribe(eventBus)
+	accountService.Subscribe(eventBus)
+	sessionService.Subscribe(eventBus)
+	exportService.Subscribe(eventBus)
+	apiKeyService.Subscribe(eventBus)
+	oidcService.Subscribe(eventBus)
+	mfaService.Subscribe(eventBus)
+	organizationService.Subscribe(eventBus)
+	sharingService.Subscribe(eventBus)
+	commentService.Subscribe(eventBus)
+	summaryService.Subscribe(eventBus)
+	translationService.Subscribe(eventBus)
+	textProcessingService.Subscribe(eventBus)
+
+	router := httpInterface.NewRouter(authService, transcriptionService).
+		WithWebhookService(webhookService).
+		WithIdempotencyService(idempotencyService).
+		WithUsageService(usageService).
+		WithAccountService(accountService).
+		WithUserService(userService).
+		WithExportService(exportService).
+		WithAdminService(adminService).
+		WithAPIKeyService(apiKeyService).
+		WithSigningKeys(signingKeyService).
+		WithOIDCService(oidcService).
+		WithMFAService(mfaService).
+		WithSessionService(sessionService).
+		WithOrganizationService(organizationService).
+		WithSharingService(sharingService).
+		WithCommentService(commentService).
+		WithSummaryService(summaryService).
+		WithTranslationService(translationService).
+		WithTextProcessingService(textProcessingService)
+
+	return &testApp{
+		Server:           httptest.NewServer(router.Setup()),
+		transcriber:      transcriber,
+		mailer:           mailer,
+		audioStore:       audioStore,
+		exportService:    exportService,
+		sessionService:   sessionService,
+		signingKeys:      signingKeyService,
+		identityProvider: identityProvider,
+		webhookService:   webhookService,
+		usageService:     usageService,
+		eventBus:         eventBus,
+		outboxDispatcher: services.NewOutboxDispatcher(outboxRepo, eventBus).WithRetryPolicy(3, 0),
+	}
+}
+
+func doJSON(t *testing.T, method, url, token string, payload interface{}) *http.Response {
+	return doJSONWithHeaders(t, method, url, token, payload, nil)
+}
+
+func doJSONWithHeaders(t *testing.T, method, url, token string, payload interface{}, headers map[string]string) *http.Response {
+	var body io.Reader
+	if payload != nil {
+		encoded, _ := json.Marshal(payload)
+		body = bytes.NewBuffer(encoded)
+	}
+
+	req, _ := http.NewRequest(method, url, body)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	return resp
+}
+
+func uploadAudio(t *testing.T, serverURL, token string) *http.Response {
+	return uploadAudioWithHeaders(t, serverURL, token, []byte("fake audio"), nil)
+}
+
+func uploadAudioWithHeaders(t *testing.T, serverURL, token string, audio []byte, headers map[string]string) *http.Response {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("audio", "memo.m4a")
+	part.Write(audio)
+	writer.Close()
+
+	req, _ := http.NewRequest("POST", serverURL+"/api/v1/transcriptions", body)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	return resp
+}
@@ -0,0 +1,141 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentIntegration_Threads(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "owner@example.com")
+	reviewerToken := registerVerifiedUser(t, app, "reviewer@example.com")
+	outsiderToken := registerUser(t, app.URL, "outsider@example.com")
+
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, ownerToken))["id"].(string)
+	commentsURL := app.URL + "/api/v1/transcriptions/" + transcriptionID + "/comments"
+	shareTranscription(t, app.URL, ownerToken, transcriptionID, "reviewer@example.com", "read")
+
+	// Comments follow transcription access
+	resp := doJSON(t, "POST", commentsURL, outsiderToken, map[string]interface{}{"body": "Hi"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "POST", commentsURL, reviewerToken, map[string]interface{}{
+		"body":   "Should this be capitalized?",
+		"anchor": map[string]interface{}{"start_time": 0.5, "end_time": 1.5, "text_start": 6, "text_end": 11},
+	})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	comment := decodeJSON(resp)
+	commentID := comment["id"].(string)
+	anchor := comment["anchor"].(map[string]interface{})
+	assert.Equal(t, "world", anchor["quote"])
+	assert.Equal(t, false, comment["resolved"])
+
+	resp = doJSON(t, "POST", commentsURL, ownerToken, map[string]interface{}{
+		"body":   "Out of range",
+		"anchor": map[string]interface{}{"text_start": 6, "text_end": 60},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", commentsURL, ownerToken, map[string]interface{}{"body": "No, keep it", "parent_id": commentID})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	reply := decodeJSON(resp)
+	assert.Equal(t, commentID, reply["parent_id"])
+
+	resp = doJSON(t, "POST", commentsURL, ownerToken, map[string]interface{}{"body": "General remark"})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// Only authors edit their comments
+	resp = doJSON(t, "PATCH", commentsURL+"/"+commentID, ownerToken, map[string]string{"body": "Rewritten"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "PATCH", commentsURL+"/"+commentID, reviewerToken, map[string]string{"body": "Capitalize 'world'?"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Capitalize 'world'?", decodeJSON(resp)["body"])
+
+	resp = doJSON(t, "POST", commentsURL+"/"+reply["id"].(string)+"/resolve", ownerToken, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", commentsURL+"/"+commentID+"/resolve", ownerToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, true, decodeJSON(resp)["resolved"])
+
+	resp = doJSON(t, "GET", commentsURL, reviewerToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var threads []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&threads))
+	assert.Len(t, threads, 2)
+	assert.Equal(t, commentID, threads[0]["id"])
+	assert.Len(t, threads[0]["replies"], 1)
+
+	resp = doJSON(t, "GET", commentsURL+"?resolved=false", reviewerToken, nil)
+	threads = nil
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&threads))
+	assert.Len(t, threads, 1)
+	assert.Equal(t, "General remark", threads[0]["body"])
+
+	resp = doJSON(t, "POST", commentsURL+"/"+commentID+"/unresolve", reviewerToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, false, decodeJSON(resp)["resolved"])
+
+	// The transcription's owner may delete any thread, replies included
+	resp = doJSON(t, "DELETE", commentsURL+"/"+commentID, ownerToken, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = doJSON(t, "GET", commentsURL, ownerToken, nil)
+	threads = nil
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&threads))
+	assert.Len(t, threads, 1)
+
+	resp = doJSON(t, "DELETE", commentsURL+"/"+reply["id"].(string), ownerToken, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCommentIntegration_Highlights(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "owner@example.com")
+	reviewerToken := registerVerifiedUser(t, app, "reviewer@example.com")
+
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, ownerToken))["id"].(string)
+	highlightsURL := app.URL + "/api/v1/transcriptions/" + transcriptionID + "/highlights"
+
+	resp := doJSON(t, "POST", highlightsURL, reviewerToken, map[string]interface{}{
+		"anchor": map[string]interface{}{"text_start": 0, "text_end": 5},
+	})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	shareTranscription(t, app.URL, ownerToken, transcriptionID, "reviewer@example.com", "read")
+
+	resp = doJSON(t, "POST", highlightsURL, reviewerToken, map[string]interface{}{"color": "green"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", highlightsURL, reviewerToken, map[string]interface{}{
+		"anchor": map[string]interface{}{"text_start": 0, "text_end": 5},
+		"color":  "green",
+		"note":   "Greeting",
+	})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	highlight := decodeJSON(resp)
+	assert.Equal(t, "green", highlight["color"])
+	assert.Equal(t, "Hello", highlight["anchor"].(map[string]interface{})["quote"])
+
+	resp = doJSON(t, "GET", highlightsURL, ownerToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var highlights []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&highlights))
+	assert.Len(t, highlights, 1)
+
+	resp = doJSON(t, "DELETE", highlightsURL+"/"+highlight["id"].(string), ownerToken, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = doJSON(t, "GET", highlightsURL, reviewerToken, nil)
+	highlights = nil
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&highlights))
+	assert.Empty(t, highlights)
+}
@@ -0,0 +1,115 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/infrastructure/persistence"
+	httpInterface "github.com/voiceline/backend/internal/interface/http"
+)
+
+func setupDeduplicationServer(scope services.DeduplicationScope) (*httptest.Server, *stubTranscriber) {
+	transcriber := &stubTranscriber{text: "Same memo", duration: 4}
+
+	authService := services.NewAuthService(persistence.NewMemoryUserRepository(), "test-secret")
+	transcriptionService := services.NewTranscriptionService(persistence.NewMemoryTranscriptionRepository(), transcriber).
+		WithDeduplication(scope)
+
+	router := httpInterface.NewRouter(authService, transcriptionService)
+	return httptest.NewServer(router.Setup()), transcriber
+}
+
+func registerUser(t *testing.T, serverURL, email string) string {
+	resp := doJSON(t, "POST", serverURL+"/api/v1/auth/register", "", map[string]string{
+		"email":    email,
+		"password": "password123",
+		"name":     "Dedup User",
+	})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return result["token"].(string)
+}
+
+func decodeTranscription(resp *http.Response) map[string]interface{} {
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return result
+}
+
+func TestDeduplicationIntegration_SameUser(t *testing.T) {
+	server, transcriber := setupDeduplicationServer(services.DeduplicationUser)
+	defer server.Close()
+
+	token := registerUser(t, server.URL, "dedup@example.com")
+
+	first := decodeTranscription(uploadAudioWithHeaders(t, server.URL, token, []byte("audio bytes"), nil))
+	second := decodeTranscription(uploadAudioWithHeaders(t, server.URL, token, []byte("audio bytes"), nil))
+
+	assert.Equal(t, 1, transcriber.Calls())
+	assert.NotEqual(t, first["id"], second["id"])
+	assert.Equal(t, first["id"], second["duplicate_of"])
+	assert.Equal(t, "Same memo", second["text"])
+	assert.Equal(t, "completed", second["status"])
+	assert.Nil(t, first["duplicate_of"])
+
+	// Duplicates of duplicates link to the original
+	third := decodeTranscription(uploadAudioWithHeaders(t, server.URL, token, []byte("audio bytes"), nil))
+	assert.Equal(t, first["id"], third["duplicate_of"])
+
+	uploadAudioWithHeaders(t, server.URL, token, []byte("other bytes"), nil)
+	assert.Equal(t, 2, transcriber.Calls())
+}
+
+func TestDeduplicationIntegration_Scopes(t *testing.T) {
+	t.Run("User scope ignores other users", func(t *testing.T) {
+		server, transcriber := setupDeduplicationServer(services.DeduplicationUser)
+		defer server.Close()
+
+		uploadAudioWithHeaders(t, server.URL, registerUser(t, server.URL, "a@example.com"), []byte("shared"), nil)
+		uploadAudioWithHeaders(t, server.URL, registerUser(t, server.URL, "b@example.com"), []byte("shared"), nil)
+
+		assert.Equal(t, 2, transcriber.Calls())
+	})
+
+	t.Run("Global scope reuses any user's result", func(t *testing.T) {
+		server, transcriber := setupDeduplicationServer(services.DeduplicationGlobal)
+		defer server.Close()
+
+		uploadAudioWithHeaders(t, server.URL, registerUser(t, server.URL, "a@example.com"), []byte("shared"), nil)
+		second := decodeTranscription(uploadAudioWithHeaders(t, server.URL, registerUser(t, server.URL, "b@example.com"), []byte("shared"), nil))
+
+		assert.Equal(t, 1, transcriber.Calls())
+		assert.NotEmpty(t, second["duplicate_of"])
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		server, transcriber := setupDeduplicationServer(services.DeduplicationDisabled)
+		defer server.Close()
+
+		token := registerUser(t, server.URL, "a@example.com")
+		uploadAudioWithHeaders(t, server.URL, token, []byte("shared"), nil)
+		uploadAudioWithHeaders(t, server.URL, token, []byte("shared"), nil)
+
+		assert.Equal(t, 2, transcriber.Calls())
+	})
+
+	t.Run("Unknown scopes reuse nothing", func(t *testing.T) {
+		server, transcriber := setupDeduplicationServer(services.DeduplicationScope("everyone"))
+		defer server.Close()
+
+		token := registerUser(t, server.URL, "a@example.com")
+		uploadAudioWithHeaders(t, server.URL, token, []byte("shared"), nil)
+		uploadAudioWithHeaders(t, server.URL, token, []byte("shared"), nil)
+
+		assert.Equal(t, 2, transcriber.Calls())
+
+		_, err := services.ParseDeduplicationScope("everyone")
+		assert.Equal(t, services.ErrInvalidDedupScope, err)
+	})
+}
@@ -0,0 +1,237 @@
+package integration
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/infrastructure/persistence"
+)
+
+func decodeExport(resp *http.Response) map[string]interface{} {
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return result
+}
+
+func readZipFile(t *testing.T, archive *zip.Reader, name string) []byte {
+	file, err := archive.Open(name)
+	if !assert.NoError(t, err, name) {
+		return nil
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	return content
+}
+
+func TestExportIntegration_RequestAndDownload(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "export@example.com")
+	transcription := decodeTranscription(uploadAudioWithHeaders(t, app.URL, token, []byte("exported audio"), nil))
+
+	resp := doJSON(t, "POST", app.URL+"/api/v1/transcriptions/"+transcription["id"].(string)+"/translations?target=de", token, nil)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = doJSON(t, "PATCH", app.URL+"/api/v1/transcriptions/"+transcription["id"].(string)+"/speakers/SPEAKER_00", token, map[string]string{"name": "Alice"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "PATCH", app.URL+"/api/v1/transcriptions/"+transcription["id"].(string), token, map[string]string{"text": "Hello, world"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	unedited := decodeTranscription(uploadAudioWithHeaders(t, app.URL, token, []byte("unedited audio"), nil))
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/users/me/export", token, nil)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	export := decodeExport(resp)
+	assert.Equal(t, "pending", export["status"])
+	exportURL := app.URL + "/api/v1/users/me/exports/" + export["id"].(string)
+
+	// Only one export may run at a time
+	resp = doJSON(t, "POST", app.URL+"/api/v1/users/me/export", token, nil)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = doJSON(t, "GET", exportURL, token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Nil(t, decodeExport(resp)["download_url"])
+
+	processed, err := app.exportService.ProcessPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	resp = doJSON(t, "GET", exportURL, token, nil)
+	export = decodeExport(resp)
+	assert.Equal(t, "completed", export["status"])
+	downloadURL, _ := export["download_url"].(string)
+	assert.NotEmpty(t, downloadURL)
+
+	// The signed link works without a bearer token
+	resp, err = http.Get(app.URL + downloadURL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/zip", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+
+	body, _ := io.ReadAll(resp.Body)
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.NoError(t, err)
+
+	var manifest struct {
+		User struct {
+			Email string `json:"email"`
+		} `json:"user"`
+		Transcriptions []struct {
+			ID        string `json:"id"`
+			Text      string `json:"text"`
+			RawText   string `json:"raw_text"`
+			Revisions []struct {
+				Text string `json:"text"`
+			} `json:"revisions"`
+			TextFile       string `json:"text_file"`
+			TranscriptFile string `json:"transcript_file"`
+			AudioFile      string `json:"audio_file"`
+			Segments       []struct {
+				SpeakerLabel string `json:"speaker_label"`
+				Speaker      string `json:"speaker"`
+			} `json:"segments"`
+			Translations []struct {
+				Language string `json:"language"`
+				Version  int    `json:"version"`
+				TextFile string `json:"text_file"`
+			} `json:"translations"`
+		} `json:"transcriptions"`
+	}
+	assert.NoError(t, json.Unmarshal(readZipFile(t, archive, "manifest.json"), &manifest))
+	assert.Equal(t, "export@example.com", manifest.User.Email)
+	if assert.Len(t, manifest.Transcriptions, 2) {
+		entry, other := manifest.Transcriptions[0], manifest.Transcriptions[1]
+		if entry.ID != transcription["id"] {
+			entry, other = other, entry
+		}
+		assert.Equal(t, transcription["id"], entry.ID)
+		assert.Equal(t, unedited["id"], other.ID)
+		assert.Equal(t, "Hello, world", entry.Text)
+		assert.Equal(t, "Hello world", entry.RawText)
+		if assert.Len(t, entry.Revisions, 1) {
+			assert.Equal(t, "Hello world", entry.Revisions[0].Text)
+		}
+		assert.Equal(t, "Hello, world", string(readZipFile(t, archive, entry.TextFile)))
+		assert.Equal(t, "exported audio", string(readZipFile(t, archive, entry.AudioFile)))
+		// Segments no longer match the edited text, so only the unedited
+		// transcription has them
+		assert.Empty(t, entry.TranscriptFile)
+		assert.Empty(t, entry.Segments)
+		assert.Equal(t, "SPEAKER_00: Hello\nSPEAKER_01: world", string(readZipFile(t, archive, other.TranscriptFile)))
+		if assert.Len(t, other.Segments, 2) {
+			assert.Equal(t, "SPEAKER_00", other.Segments[0].SpeakerLabel)
+			assert.Equal(t, "SPEAKER_00", other.Segments[0].Speaker)
+		}
+		if assert.Len(t, entry.Translations, 1) {
+			assert.Equal(t, "de", entry.Translations[0].Language)
+			assert.Equal(t, 1, entry.Translations[0].Version)
+			assert.Equal(t, "Alice: [de] Hello\nSPEAKER_01: [de] world", string(readZipFile(t, archive, entry.Translations[0].TextFile)))
+		}
+	}
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me/exports", token, nil)
+	var exports []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&exports)
+	assert.Len(t, exports, 1)
+}
+
+func TestExportIntegration_RejectsTamperedLinks(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "tamper@example.com")
+	export := decodeExport(doJSON(t, "POST", app.URL+"/api/v1/users/me/export", token, nil))
+	_, err := app.exportService.ProcessPending(context.Background())
+	assert.NoError(t, err)
+
+	resp := doJSON(t, "GET", app.URL+"/api/v1/users/me/exports/"+export["id"].(string), token, nil)
+	downloadURL := decodeExport(resp)["download_url"].(string)
+
+	tampered := strings.Replace(downloadURL, "signature=", "signature=00", 1)
+	resp, err = http.Get(app.URL + tampered)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	extended := strings.Replace(downloadURL, "expires=", "expires=9", 1)
+	resp, err = http.Get(app.URL + extended)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestExportIntegration_OtherUsersExport(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	owner := registerUser(t, app.URL, "owner@example.com")
+	other := registerUser(t, app.URL, "other@example.com")
+
+	export := decodeExport(doJSON(t, "POST", app.URL+"/api/v1/users/me/export", owner, nil))
+
+	resp := doJSON(t, "GET", app.URL+"/api/v1/users/me/exports/"+export["id"].(string), other, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestExportIntegration_RemovedWithAccount(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "gone@example.com")
+	export := decodeExport(doJSON(t, "POST", app.URL+"/api/v1/users/me/export", token, nil))
+	_, err := app.exportService.ProcessPending(context.Background())
+	assert.NoError(t, err)
+
+	resp := doJSON(t, "GET", app.URL+"/api/v1/users/me/exports/"+export["id"].(string), token, nil)
+	downloadURL := decodeExport(resp)["download_url"].(string)
+
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/users/me", token, map[string]string{"password": "password123"})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	app.deliver(t)
+
+	resp, err = http.Get(app.URL + downloadURL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestExportIntegration_StaleExportsDoNotBlock(t *testing.T) {
+	userRepo := persistence.NewMemoryUserRepository()
+	exportRepo := persistence.NewMemoryExportJobRepository()
+	user, _ := entities.NewUser("stale@example.com", "password123", "Stale User")
+	assert.NoError(t, userRepo.Create(context.Background(), user))
+
+	exportService := services.NewExportService(
+		exportRepo,
+		userRepo,
+		persistence.NewMemoryTranscriptionRepository(),
+		persistence.NewMemoryFileStore(),
+		"export-secret",
+	).WithTimeout(time.Nanosecond)
+
+	// A job left running by a crashed worker
+	abandoned, err := exportService.RequestExport(context.Background(), user.ID)
+	assert.NoError(t, err)
+	abandoned.Start()
+	assert.NoError(t, exportRepo.Update(context.Background(), abandoned))
+	time.Sleep(time.Millisecond)
+
+	_, err = exportService.RequestExport(context.Background(), user.ID)
+	assert.NoError(t, err)
+
+	job, _ := exportRepo.FindByID(context.Background(), abandoned.ID)
+	assert.Equal(t, entities.ExportFailed, job.Status)
+}
@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyIntegration_TranscriptionUpload(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := getAuthToken(app.Server)
+	headers := map[string]string{"Idempotency-Key": "upload-1"}
+
+	first := uploadAudioWithHeaders(t, app.URL, token, []byte("memo audio"), headers)
+	assert.Equal(t, http.StatusOK, first.StatusCode)
+	var firstBody map[string]interface{}
+	json.NewDecoder(first.Body).Decode(&firstBody)
+
+	// A retry re-encodes the multipart body with a new boundary
+	retry := uploadAudioWithHeaders(t, app.URL, token, []byte("memo audio"), headers)
+	assert.Equal(t, http.StatusOK, retry.StatusCode)
+	assert.Equal(t, "true", retry.Header.Get("Idempotent-Replayed"))
+	var retryBody map[string]interface{}
+	json.NewDecoder(retry.Body).Decode(&retryBody)
+
+	assert.Equal(t, firstBody["id"], retryBody["id"])
+	assert.Equal(t, 1, app.transcriber.Calls())
+
+	reused := uploadAudioWithHeaders(t, app.URL, token, []byte("different audio"), headers)
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.StatusCode)
+	var errBody map[string]interface{}
+	json.NewDecoder(reused.Body).Decode(&errBody)
+	assert.Equal(t, "IDEMPOTENCY_KEY_REUSED", errBody["code"])
+
+	fresh := uploadAudioWithHeaders(t, app.URL, token, []byte("another memo"), map[string]string{"Idempotency-Key": "upload-2"})
+	assert.Equal(t, http.StatusOK, fresh.StatusCode)
+	assert.Equal(t, 2, app.transcriber.Calls())
+
+	withoutKey := uploadAudio(t, app.URL, token)
+	assert.Equal(t, http.StatusOK, withoutKey.StatusCode)
+	assert.Empty(t, withoutKey.Header.Get("Idempotent-Replayed"))
+	assert.Equal(t, 3, app.transcriber.Calls())
+}
+
+func TestIdempotencyIntegration_Register(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	payload := map[string]string{
+		"email":    "idempotent@example.com",
+		"password": "password123",
+		"name":     "Idempotent User",
+	}
+	headers := map[string]string{"Idempotency-Key": "register-1"}
+
+	first := doJSONWithHeaders(t, "POST", app.URL+"/api/v1/auth/register", "", payload, headers)
+	assert.Equal(t, http.StatusCreated, first.StatusCode)
+
+	retry := doJSONWithHeaders(t, "POST", app.URL+"/api/v1/auth/register", "", payload, headers)
+	assert.Equal(t, http.StatusCreated, retry.StatusCode)
+	assert.Equal(t, "true", retry.Header.Get("Idempotent-Replayed"))
+
+	withoutKey := doJSON(t, "POST", app.URL+"/api/v1/auth/register", "", payload)
+	assert.Equal(t, http.StatusConflict, withoutKey.StatusCode)
+}
+
+func TestIdempotencyIntegration_ConflictNotStored(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	payload := map[string]string{
+		"email":    "taken@example.com",
+		"password": "password123",
+		"name":     "Taken User",
+	}
+	created := doJSON(t, "POST", app.URL+"/api/v1/auth/register", "", payload)
+	assert.Equal(t, http.StatusCreated, created.StatusCode)
+
+	// A conflict depends on the state at the time, so retries run again
+	headers := map[string]string{"Idempotency-Key": "register-taken"}
+	first := doJSONWithHeaders(t, "POST", app.URL+"/api/v1/auth/register", "", payload, headers)
+	assert.Equal(t, http.StatusConflict, first.StatusCode)
+
+	retry := doJSONWithHeaders(t, "POST", app.URL+"/api/v1/auth/register", "", payload, headers)
+	assert.Equal(t, http.StatusConflict, retry.StatusCode)
+	assert.Empty(t, retry.Header.Get("Idempotent-Replayed"))
+}
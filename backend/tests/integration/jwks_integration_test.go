@@ -0,0 +1,141 @@
+package integration
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/infrastructure/persistence"
+	httpInterface "github.com/voiceline/backend/internal/interface/http"
+)
+
+type jwks struct {
+	Keys []map[string]string `json:"keys"`
+}
+
+func getJWKS(t *testing.T, serverURL string) jwks {
+	resp, err := http.Get(serverURL + "/.well-known/jwks.json")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var set jwks
+	json.NewDecoder(resp.Body).Decode(&set)
+	return set
+}
+
+// verifyWithJWKS checks a token the way another service would, using only
+// the published key set
+func verifyWithJWKS(set jwks, token string) error {
+	_, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		for _, key := range set.Keys {
+			if key["kid"] == token.Header["kid"] {
+				x, err := base64.RawURLEncoding.DecodeString(key["x"])
+				return ed25519.PublicKey(x), err
+			}
+		}
+		return nil, jwt.ErrTokenUnverifiable
+	}, jwt.WithValidMethods([]string{"EdDSA"}))
+	return err
+}
+
+func TestJWKSIntegration_TokensVerifyWithPublishedKeys(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "jwks@example.com")
+
+	set := getJWKS(t, app.URL)
+	if assert.Len(t, set.Keys, 1) {
+		assert.Equal(t, "OKP", set.Keys[0]["kty"])
+		assert.Equal(t, "EdDSA", set.Keys[0]["alg"])
+		assert.Empty(t, set.Keys[0]["d"])
+	}
+
+	assert.NoError(t, verifyWithJWKS(set, token))
+}
+
+func TestJWKSIntegration_RotationKeepsOlderTokensValid(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	oldToken := registerUser(t, app.URL, "rotation@example.com")
+
+	_, err := app.signingKeys.Rotate(context.Background())
+	assert.NoError(t, err)
+
+	newToken := decodeJSON(login(t, app.URL, "rotation@example.com", "password123"))["token"].(string)
+	oldHeader, _, _ := jwt.NewParser().ParseUnverified(oldToken, jwt.MapClaims{})
+	newHeader, _, _ := jwt.NewParser().ParseUnverified(newToken, jwt.MapClaims{})
+	assert.NotEqual(t, oldHeader.Header["kid"], newHeader.Header["kid"])
+
+	set := getJWKS(t, app.URL)
+	assert.Len(t, set.Keys, 2)
+	assert.NoError(t, verifyWithJWKS(set, oldToken))
+	assert.NoError(t, verifyWithJWKS(set, newToken))
+
+	resp := doJSON(t, "GET", app.URL+"/api/v1/users/me", oldToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me", newToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestJWKSIntegration_ExpiredKeysStopVerifying(t *testing.T) {
+	policy := services.SigningKeyPolicy{
+		Algorithm:          entities.SigningAlgorithmRS256,
+		RotationInterval:   time.Hour,
+		VerificationPeriod: 0,
+	}
+	keys := services.NewSigningKeyService(persistence.NewMemorySigningKeyRepository(), policy)
+	assert.NoError(t, keys.EnsureActiveKey(context.Background()))
+
+	authService := services.NewAuthService(persistence.NewMemoryUserRepository(), "test-secret").WithSigningKeys(keys)
+	transcriptionService := services.NewTranscriptionService(persistence.NewMemoryTranscriptionRepository(), &stubTranscriber{})
+	server := httptest.NewServer(httpInterface.NewRouter(authService, transcriptionService).WithSigningKeys(keys).Setup())
+	defer server.Close()
+
+	token := registerUser(t, server.URL, "expired-key@example.com")
+	assert.Equal(t, "RS256", getJWKS(t, server.URL).Keys[0]["alg"])
+
+	resp := doJSON(t, "GET", server.URL+"/api/v1/transcriptions", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err := keys.Rotate(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, keys.EnsureActiveKey(context.Background()))
+	assert.Len(t, getJWKS(t, server.URL).Keys, 1)
+
+	resp = doJSON(t, "GET", server.URL+"/api/v1/transcriptions", token, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestJWKSIntegration_RejectsSharedSecretTokens(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "hs256@example.com")
+	userID := getMe(t, app.URL, token)["id"].(string)
+
+	// A token signed with the shared secret, naming the real key ID, must not
+	// be accepted once asymmetric signing is enabled
+	header, _, _ := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userID,
+		"role":    "admin",
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	forged.Header["kid"] = header.Header["kid"]
+	signed, err := forged.SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+
+	resp := doJSON(t, "GET", app.URL+"/api/v1/users/me", signed, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
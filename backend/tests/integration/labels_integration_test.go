@@ -0,0 +1,182 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// uploadWithFields uploads audio with additional multipart fields, repeating
+// fields given several values
+func uploadWithFields(t *testing.T, serverURL, token string, fields map[string][]string) *http.Response {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("audio", "memo.m4a")
+	part.Write([]byte("labelled audio"))
+	for name, values := range fields {
+		for _, value := range values {
+			writer.WriteField(name, value)
+		}
+	}
+	writer.Close()
+
+	req, _ := http.NewRequest("POST", serverURL+"/api/v1/transcriptions", body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	return resp
+}
+
+func listTranscriptions(t *testing.T, url, token string) []map[string]interface{} {
+	resp := doJSON(t, "GET", url, token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var transcriptions []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&transcriptions))
+	return transcriptions
+}
+
+func TestLabelsIntegration_UploadAndFilter(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "labels@example.com")
+
+	resp := uploadWithFields(t, app.URL, token, map[string][]string{
+		"title":            {"Weekly sync"},
+		"folder":           {"/meetings/2024/"},
+		"tags":             {"Team, weekly", "apollo"},
+		"metadata[client]": {"acme"},
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	labelled := decodeJSON(resp)
+	assert.Equal(t, "Weekly sync", labelled["title"])
+	assert.Equal(t, "meetings/2024", labelled["folder"])
+	assert.Equal(t, []interface{}{"apollo", "team", "weekly"}, labelled["tags"])
+	assert.Equal(t, map[string]interface{}{"client": "acme"}, labelled["metadata"])
+
+	resp = uploadWithFields(t, app.URL, token, map[string][]string{"tags": {"a,b", "bad,"}, "folder": {"a//b"}})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = uploadAudio(t, app.URL, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	plain := decodeJSON(resp)
+	assert.Nil(t, plain["tags"])
+
+	listURL := app.URL + "/api/v1/transcriptions"
+	assert.Len(t, listTranscriptions(t, listURL, token), 2)
+
+	filtered := listTranscriptions(t, listURL+"?tag=Team", token)
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, labelled["id"], filtered[0]["id"])
+
+	filtered = listTranscriptions(t, listURL+"?folder=meetings", token)
+	assert.Len(t, filtered, 1)
+	assert.Empty(t, listTranscriptions(t, listURL+"?folder=meetings/2023", token))
+	assert.Empty(t, listTranscriptions(t, listURL+"?tag=team&folder=archive", token))
+}
+
+func TestLabelsIntegration_Update(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "owner@example.com")
+	readerToken := registerVerifiedUser(t, app, "reader@example.com")
+
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, ownerToken))["id"].(string)
+	transcriptionURL := app.URL + "/api/v1/transcriptions/" + transcriptionID
+
+	resp := doJSON(t, "PATCH", transcriptionURL, ownerToken, map[string]interface{}{
+		"title":    "Call notes",
+		"tags":     []string{"Clients", "todo"},
+		"metadata": map[string]string{"client": "acme", "priority": "high"},
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	updated := decodeJSON(resp)
+	assert.Equal(t, "Call notes", updated["title"])
+	assert.Equal(t, []interface{}{"clients", "todo"}, updated["tags"])
+	assert.Equal(t, "Hello world", updated["text"])
+
+	// Omitted fields are left unchanged
+	resp = doJSON(t, "PATCH", transcriptionURL, ownerToken, map[string]interface{}{"folder": "calls"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	updated = decodeJSON(resp)
+	assert.Equal(t, "calls", updated["folder"])
+	assert.Equal(t, "Call notes", updated["title"])
+	assert.Len(t, updated["metadata"], 2)
+
+	// A rejected update changes nothing, not even its valid fields
+	resp = doJSON(t, "PATCH", transcriptionURL, ownerToken, map[string]interface{}{"title": "Renamed", "tags": []string{" "}})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp = doJSON(t, "PATCH", transcriptionURL, ownerToken, map[string]interface{}{"folder": "archive", "metadata": map[string]string{" ": "x"}})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp = doJSON(t, "PATCH", transcriptionURL, ownerToken, map[string]interface{}{"title": "Renamed", "text": ""})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "GET", transcriptionURL, ownerToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	current := decodeJSON(resp)
+	assert.Equal(t, "Call notes", current["title"])
+	assert.Equal(t, "calls", current["folder"])
+	assert.Equal(t, []interface{}{"clients", "todo"}, current["tags"])
+	assert.Len(t, current["metadata"], 2)
+
+	shareTranscription(t, app.URL, ownerToken, transcriptionID, "reader@example.com", "read")
+	resp = doJSON(t, "PATCH", transcriptionURL, readerToken, map[string]interface{}{"title": "Mine now"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestLabelsIntegration_RenameAndMergeTags(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "tags@example.com")
+	otherToken := registerUser(t, app.URL, "other@example.com")
+
+	uploadWithFields(t, app.URL, token, map[string][]string{"tags": {"meeting,todo"}})
+	uploadWithFields(t, app.URL, token, map[string][]string{"tags": {"meetings"}})
+	uploadWithFields(t, app.URL, token, map[string][]string{"tags": {"mtg"}})
+	uploadWithFields(t, app.URL, otherToken, map[string][]string{"tags": {"meetings"}})
+
+	resp := doJSON(t, "GET", app.URL+"/api/v1/transcriptions/tags", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var tags []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&tags))
+	assert.Len(t, tags, 4)
+	assert.Equal(t, "meeting", tags[0]["name"])
+	assert.Equal(t, float64(1), tags[0]["count"])
+
+	resp = doJSON(t, "PATCH", app.URL+"/api/v1/transcriptions/tags/todo", token, map[string]string{"name": "Tasks"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, float64(1), decodeJSON(resp)["updated"])
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/transcriptions/tags/merge", token, map[string]interface{}{
+		"tags": []string{"meetings", "mtg"},
+		"into": "meeting",
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, float64(2), decodeJSON(resp)["updated"])
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/transcriptions/tags", token, nil)
+	tags = nil
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&tags))
+	assert.Len(t, tags, 2)
+	assert.Equal(t, "meeting", tags[0]["name"])
+	assert.Equal(t, float64(3), tags[0]["count"])
+	assert.Equal(t, "tasks", tags[1]["name"])
+
+	// Other users' tags are untouched
+	assert.Len(t, listTranscriptions(t, app.URL+"/api/v1/transcriptions?tag=meetings", otherToken), 1)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/transcriptions/tags/merge", token, map[string]interface{}{
+		"tags": []string{"meeting"},
+		"into": "a,b",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,122 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/infrastructure/persistence"
+	httpInterface "github.com/voiceline/backend/internal/interface/http"
+)
+
+func setupLoginProtectedServer(policy services.LoginProtectionPolicy) (*httptest.Server, *services.AuthService) {
+	loginProtection := services.NewLoginProtectionService(persistence.NewMemoryLoginThrottleRepository(), policy)
+	authService := services.NewAuthService(persistence.NewMemoryUserRepository(), "test-secret").
+		WithLoginProtection(loginProtection)
+	transcriptionService := services.NewTranscriptionService(
+		persistence.NewMemoryTranscriptionRepository(),
+		&stubTranscriber{text: "Hello world", duration: 3},
+	)
+
+	router := httpInterface.NewRouter(authService, transcriptionService)
+	return httptest.NewServer(router.Setup()), authService
+}
+
+func login(t *testing.T, serverURL, email, password string) *http.Response {
+	return doJSON(t, "POST", serverURL+"/api/v1/auth/login", "", map[string]string{
+		"email":    email,
+		"password": password,
+	})
+}
+
+func TestLoginProtectionIntegration_AccountLockoutAndUnlock(t *testing.T) {
+	policy := services.DefaultLoginProtectionPolicy()
+	policy.Account.BaseDelay = 0
+	policy.Account.LockoutThreshold = 3
+
+	server, authService := setupLoginProtectedServer(policy)
+	defer server.Close()
+
+	registerUser(t, server.URL, "locked@example.com")
+
+	for i := 0; i < 3; i++ {
+		resp := login(t, server.URL, "locked@example.com", "wrong-password")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	// The correct password is refused while the account is locked
+	resp := login(t, server.URL, "locked@example.com", "password123")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "900", resp.Header.Get("Retry-After"))
+
+	assert.NoError(t, authService.UnlockAccount(context.Background(), "Locked@example.com"))
+
+	resp = login(t, server.URL, "locked@example.com", "password123")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestLoginProtectionIntegration_UnknownEmailBehavesLikeAccount(t *testing.T) {
+	policy := services.DefaultLoginProtectionPolicy()
+	policy.Account.BaseDelay = 0
+	policy.Account.LockoutThreshold = 2
+
+	server, _ := setupLoginProtectedServer(policy)
+	defer server.Close()
+
+	registerUser(t, server.URL, "known@example.com")
+
+	for _, email := range []string{"known@example.com", "unknown@example.com"} {
+		for i := 0; i < 2; i++ {
+			resp := login(t, server.URL, email, "wrong-password")
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		}
+
+		resp := login(t, server.URL, email, "wrong-password")
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, email)
+	}
+}
+
+func TestLoginProtectionIntegration_IPLockout(t *testing.T) {
+	policy := services.DefaultLoginProtectionPolicy()
+	policy.IP.BaseDelay = 0
+	policy.IP.LockoutThreshold = 3
+	policy.IP.LockoutDuration = time.Minute
+
+	server, _ := setupLoginProtectedServer(policy)
+	defer server.Close()
+
+	registerUser(t, server.URL, "victim@example.com")
+
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		resp := login(t, server.URL, email, "wrong-password")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	resp := login(t, server.URL, "victim@example.com", "password123")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+}
+
+func TestLoginProtectionIntegration_ProgressiveDelay(t *testing.T) {
+	policy := services.DefaultLoginProtectionPolicy()
+	policy.Account.FreeAttempts = 1
+	policy.Account.BaseDelay = time.Minute
+	policy.Account.MaxDelay = time.Hour
+
+	server, _ := setupLoginProtectedServer(policy)
+	defer server.Close()
+
+	resp := login(t, server.URL, "slow@example.com", "wrong-password")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = login(t, server.URL, "slow@example.com", "wrong-password")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = login(t, server.URL, "slow@example.com", "wrong-password")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+}
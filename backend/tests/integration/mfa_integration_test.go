@@ -0,0 +1,208 @@
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/infrastructure/persistence"
+	httpInterface "github.com/voiceline/backend/internal/interface/http"
+)
+
+// totpCode returns the authenticator app code steps 30 second periods from
+// now. Each code is accepted once, so tests use a different step per code.
+func totpCode(t *testing.T, secret string, steps int) string {
+	code, err := entities.GenerateTOTP(secret, time.Now().Add(time.Duration(steps)*30*time.Second))
+	assert.NoError(t, err)
+	return code
+}
+
+// enableMFA enrolls the user with the current step's code and returns the
+// secret and recovery codes
+func enableMFA(t *testing.T, serverURL, token string) (string, []string) {
+	resp := doJSON(t, "POST", serverURL+"/api/v1/users/me/mfa/totp", token, nil)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	secret := decodeJSON(resp)["secret"].(string)
+
+	resp = doJSON(t, "POST", serverURL+"/api/v1/users/me/mfa/totp/confirm", token, map[string]string{
+		"code": totpCode(t, secret, 0),
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var codes []string
+	for _, code := range decodeJSON(resp)["recovery_codes"].([]interface{}) {
+		codes = append(codes, code.(string))
+	}
+	return secret, codes
+}
+
+func mfaChallenge(t *testing.T, serverURL, email string) string {
+	resp := login(t, serverURL, email, "password123")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body := decodeJSON(resp)
+	assert.Equal(t, true, body["mfa_required"])
+	assert.Nil(t, body["token"])
+	return body["mfa_token"].(string)
+}
+
+func verifyMFA(t *testing.T, serverURL, mfaToken, code string) *http.Response {
+	return doJSON(t, "POST", serverURL+"/api/v1/auth/mfa/verify", "", map[string]string{
+		"mfa_token": mfaToken,
+		"code":      code,
+	})
+}
+
+func TestMFAIntegration_EnrollAndLogin(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "mfa@example.com")
+
+	resp := doJSON(t, "POST", app.URL+"/api/v1/users/me/mfa/totp", token, nil)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	enrollment := decodeJSON(resp)
+	assert.True(t, strings.HasPrefix(enrollment["provisioning_uri"].(string), "otpauth://totp/Voiceline:mfa@example.com?"))
+	assert.Contains(t, enrollment["provisioning_uri"], "secret="+enrollment["secret"].(string))
+
+	// Nothing is enforced until the enrollment is confirmed
+	resp = login(t, app.URL, "mfa@example.com", "password123")
+	assert.NotEmpty(t, decodeJSON(resp)["token"])
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/users/me/mfa/totp/confirm", token, map[string]string{"code": "000000"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "INVALID_MFA_CODE", decodeJSON(resp)["code"])
+
+	secret, recoveryCodes := enableMFA(t, app.URL, token)
+	assert.Len(t, recoveryCodes, 10)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me/mfa", token, nil)
+	status := decodeJSON(resp)
+	assert.Equal(t, true, status["enabled"])
+	assert.Equal(t, float64(10), status["recovery_codes_remaining"])
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/users/me/mfa/totp", token, nil)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// The password alone only yields a challenge, which is no access token
+	challenge := mfaChallenge(t, app.URL, "mfa@example.com")
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me", challenge, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = verifyMFA(t, app.URL, challenge, "123456")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "INVALID_MFA_CODE", decodeJSON(resp)["code"])
+
+	// The code used to confirm the enrollment cannot be replayed
+	resp = verifyMFA(t, app.URL, challenge, totpCode(t, secret, 0))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = verifyMFA(t, app.URL, challenge, totpCode(t, secret, 1))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body := decodeJSON(resp)
+	assert.Equal(t, "mfa@example.com", body["user"].(map[string]interface{})["email"])
+	assert.Equal(t, "mfa@example.com", getMe(t, app.URL, body["token"].(string))["email"])
+
+	// An access token cannot stand in for a challenge
+	resp = verifyMFA(t, app.URL, token, totpCode(t, secret, 1))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "INVALID_MFA_CHALLENGE", decodeJSON(resp)["code"])
+}
+
+func TestMFAIntegration_RecoveryCodes(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "recovery@example.com")
+	secret, recoveryCodes := enableMFA(t, app.URL, token)
+
+	challenge := mfaChallenge(t, app.URL, "recovery@example.com")
+	resp := verifyMFA(t, app.URL, challenge, strings.ToUpper(recoveryCodes[0]))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Recovery codes are single-use
+	resp = verifyMFA(t, app.URL, challenge, recoveryCodes[0])
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me/mfa", token, nil)
+	assert.Equal(t, float64(9), decodeJSON(resp)["recovery_codes_remaining"])
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/users/me/mfa/recovery-codes", token, map[string]string{
+		"code": totpCode(t, secret, 1),
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	newCodes := decodeJSON(resp)["recovery_codes"].([]interface{})
+	assert.Len(t, newCodes, 10)
+
+	resp = verifyMFA(t, app.URL, challenge, recoveryCodes[1])
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = verifyMFA(t, app.URL, challenge, newCodes[0].(string))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestMFAIntegration_Disable(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "disable-mfa@example.com")
+	_, recoveryCodes := enableMFA(t, app.URL, token)
+
+	resp := doJSON(t, "DELETE", app.URL+"/api/v1/users/me/mfa/totp", token, map[string]string{"code": "999999"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/users/me/mfa/totp", token, map[string]string{"code": recoveryCodes[0]})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = login(t, app.URL, "disable-mfa@example.com", "password123")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, decodeJSON(resp)["token"])
+
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/users/me/mfa/totp", token, map[string]string{"code": recoveryCodes[1]})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "MFA_NOT_ENABLED", decodeJSON(resp)["code"])
+}
+
+func TestMFAIntegration_WrongCodesCountAsFailedLogins(t *testing.T) {
+	policy := services.DefaultLoginProtectionPolicy()
+	policy.Account.BaseDelay = 0
+	policy.Account.LockoutThreshold = 3
+
+	userRepo := persistence.NewMemoryUserRepository()
+	mfaService := services.NewMFAService(persistence.NewMemoryTOTPFactorRepository(), userRepo, services.DefaultMFAIssuer)
+	authService := services.NewAuthService(userRepo, "test-secret").
+		WithLoginProtection(services.NewLoginProtectionService(persistence.NewMemoryLoginThrottleRepository(), policy)).
+		WithMFA(mfaService)
+	transcriptionService := services.NewTranscriptionService(
+		persistence.NewMemoryTranscriptionRepository(),
+		&stubTranscriber{text: "Hello world", duration: 3},
+	)
+	router := httpInterface.NewRouter(authService, transcriptionService).
+		WithUserService(services.NewUserService(userRepo, transcriptionService)).
+		WithMFAService(mfaService)
+
+	server := httptest.NewServer(router.Setup())
+	defer server.Close()
+
+	token := registerUser(t, server.URL, "guess@example.com")
+	secret, _ := enableMFA(t, server.URL, token)
+
+	challenge := mfaChallenge(t, server.URL, "guess@example.com")
+	for i := 0; i < 3; i++ {
+		resp := verifyMFA(t, server.URL, challenge, "000000")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	// Even the right code is refused once the account is locked
+	resp := verifyMFA(t, server.URL, challenge, totpCode(t, secret, 1))
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "TOO_MANY_LOGIN_ATTEMPTS", decodeJSON(resp)["code"])
+
+	resp = login(t, server.URL, "guess@example.com", "password123")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
@@ -0,0 +1,379 @@
+package integration
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+const (
+	oidcClientID     = "voiceline"
+	oidcClientSecret = "client-secret"
+	oidcRedirectURL  = "https://app.example.com/auth/callback/corp"
+)
+
+// stubIdentity is the account a user signs in with at the stub provider
+type stubIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	// Audience overrides the ID token audience to simulate a token that
+	// was issued to another client
+	Audience string
+}
+
+type stubAuthorization struct {
+	identity      stubIdentity
+	nonce         string
+	codeChallenge string
+}
+
+// stubIdentityProvider is a minimal OpenID Connect provider that issues
+// authorization codes for any identity a test asks for
+type stubIdentityProvider struct {
+	*httptest.Server
+	key   *rsa.PrivateKey
+	codes map[string]stubAuthorization
+	mu    sync.Mutex
+}
+
+func newStubIdentityProvider() *stubIdentityProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	provider := &stubIdentityProvider{key: key, codes: make(map[string]stubAuthorization)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", provider.discovery)
+	mux.HandleFunc("/jwks", provider.jwks)
+	mux.HandleFunc("/token", provider.token)
+	provider.Server = httptest.NewServer(mux)
+	return provider
+}
+
+// issuer ends in a slash like Auth0's, which ID tokens must match exactly
+func (p *stubIdentityProvider) issuer() string {
+	return p.URL + "/"
+}
+
+func (p *stubIdentityProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]string{
+		"issuer":                 p.issuer(),
+		"authorization_endpoint": p.URL + "/authorize",
+		"token_endpoint":         p.URL + "/token",
+		"jwks_uri":               p.URL + "/jwks",
+	})
+}
+
+func (p *stubIdentityProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "stub-key",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *stubIdentityProvider) token(w http.ResponseWriter, r *http.Request) {
+	clientID, secret, _ := r.BasicAuth()
+	if clientID != oidcClientID || secret != oidcClientSecret {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+
+	p.mu.Lock()
+	authorization, exists := p.codes[r.FormValue("code")]
+	delete(p.codes, r.FormValue("code"))
+	p.mu.Unlock()
+
+	if !exists || r.FormValue("grant_type") != "authorization_code" || r.FormValue("redirect_uri") != oidcRedirectURL ||
+		entities.PKCEChallenge(r.FormValue("code_verifier")) != authorization.codeChallenge {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	audience := authorization.identity.Audience
+	if audience == "" {
+		audience = oidcClientID
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            p.issuer(),
+		"sub":            authorization.identity.Subject,
+		"aud":            audience,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          authorization.nonce,
+		"email":          authorization.identity.Email,
+		"email_verified": authorization.identity.EmailVerified,
+		"name":           authorization.identity.Name,
+	})
+	idToken.Header["kid"] = "stub-key"
+	signed, _ := idToken.SignedString(p.key)
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "provider-access-token",
+		"token_type":   "Bearer",
+		"id_token":     signed,
+	})
+}
+
+// authorize plays the user signing in at the provider page the app sent
+// them to, and returns the code the provider redirects back with
+func (p *stubIdentityProvider) authorize(t *testing.T, authorizationURL string, identity stubIdentity) string {
+	parsed, err := url.Parse(authorizationURL)
+	assert.NoError(t, err)
+
+	query := parsed.Query()
+	assert.Equal(t, p.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, oidcClientID, query.Get("client_id"))
+	assert.Equal(t, oidcRedirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Contains(t, query.Get("scope"), "openid")
+
+	code := base64.RawURLEncoding.EncodeToString([]byte(identity.Subject + query.Get("state")))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.codes[code] = stubAuthorization{
+		identity:      identity,
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	return code
+}
+
+func startOIDCLogin(t *testing.T, serverURL string) map[string]interface{} {
+	resp := doJSON(t, "POST", serverURL+"/api/v1/auth/oidc/corp/authorize", "", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	return decodeJSON(resp)
+}
+
+func completeOIDCLogin(t *testing.T, serverURL, code, state string) *http.Response {
+	return doJSON(t, "POST", serverURL+"/api/v1/auth/oidc/corp/callback", "", map[string]string{
+		"code":  code,
+		"state": state,
+	})
+}
+
+func oidcLogin(t *testing.T, app *testApp, identity stubIdentity) *http.Response {
+	authorization := startOIDCLogin(t, app.URL)
+	code := app.identityProvider.authorize(t, authorization["authorization_url"].(string), identity)
+	return completeOIDCLogin(t, app.URL, code, authorization["state"].(string))
+}
+
+func TestOIDCIntegration_ListsProviders(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	resp, err := http.Get(app.URL + "/api/v1/auth/oidc/providers")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []interface{}{"corp"}, decodeJSON(resp)["providers"])
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/oidc/unknown/authorize", "", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "PROVIDER_NOT_FOUND", decodeJSON(resp)["code"])
+}
+
+func TestOIDCIntegration_FirstLoginCreatesUser(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	identity := stubIdentity{Subject: "emp-1", Email: "alice@corp.example.com", EmailVerified: true, Name: "Alice"}
+
+	resp := oidcLogin(t, app, identity)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body := decodeJSON(resp)
+	user := body["user"].(map[string]interface{})
+	assert.Equal(t, "alice@corp.example.com", user["email"])
+	assert.Equal(t, "Alice", user["name"])
+	assert.Equal(t, true, user["emailVerified"])
+
+	// The Voiceline token works like one from a password login
+	me := getMe(t, app.URL, body["token"].(string))
+	assert.Equal(t, user["id"], me["id"])
+
+	// Later logins resolve to the same user, even after an email change at
+	// the provider
+	identity.Email = "alice.smith@corp.example.com"
+	resp = oidcLogin(t, app, identity)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, user["id"], decodeJSON(resp)["user"].(map[string]interface{})["id"])
+
+	// The account has no password to log in with
+	resp = login(t, app.URL, "alice@corp.example.com", "password123")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestOIDCIntegration_LinksExistingAccountByVerifiedEmail(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "bob@corp.example.com")
+	userID := getMe(t, app.URL, token)["id"]
+
+	// A provider that has not verified the address cannot claim the account
+	resp := oidcLogin(t, app, stubIdentity{Subject: "emp-2", Email: "bob@corp.example.com"})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "USER_EXISTS", decodeJSON(resp)["code"])
+
+	// Nor is an account linked before its owner verified the address locally
+	resp = oidcLogin(t, app, stubIdentity{Subject: "emp-2", Email: "bob@corp.example.com", EmailVerified: true})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "ACCOUNT_UNVERIFIED", decodeJSON(resp)["code"])
+
+	app.deliver(t)
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email/confirm", "", map[string]string{
+		"token": mailedToken(t, app, "bob@corp.example.com"),
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = oidcLogin(t, app, stubIdentity{Subject: "emp-2", Email: "bob@corp.example.com", EmailVerified: true})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, userID, decodeJSON(resp)["user"].(map[string]interface{})["id"])
+
+	// The password keeps working alongside SSO
+	resp = login(t, app.URL, "bob@corp.example.com", "password123")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestOIDCIntegration_StateCannotBeReplayed(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	identity := stubIdentity{Subject: "emp-3", Email: "carol@corp.example.com", EmailVerified: true}
+	authorization := startOIDCLogin(t, app.URL)
+	state := authorization["state"].(string)
+
+	code := app.identityProvider.authorize(t, authorization["authorization_url"].(string), identity)
+	resp := completeOIDCLogin(t, app.URL, code, state)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	code = app.identityProvider.authorize(t, authorization["authorization_url"].(string), identity)
+	resp = completeOIDCLogin(t, app.URL, code, state)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "INVALID_STATE", decodeJSON(resp)["code"])
+
+	resp = completeOIDCLogin(t, app.URL, code, "made-up-state")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestOIDCIntegration_InterceptedCodeIsRejected(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	// A code issued for the victim's login cannot be redeemed through the
+	// attacker's login, whose PKCE verifier does not match
+	victim := startOIDCLogin(t, app.URL)
+	attacker := startOIDCLogin(t, app.URL)
+
+	code := app.identityProvider.authorize(t, victim["authorization_url"].(string), stubIdentity{
+		Subject: "emp-4", Email: "dave@corp.example.com", EmailVerified: true,
+	})
+
+	resp := completeOIDCLogin(t, app.URL, code, attacker["state"].(string))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "OIDC_LOGIN_FAILED", decodeJSON(resp)["code"])
+}
+
+func TestOIDCIntegration_RejectsIDTokenForAnotherClient(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	resp := oidcLogin(t, app, stubIdentity{
+		Subject: "emp-5", Email: "erin@corp.example.com", EmailVerified: true, Audience: "another-app",
+	})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "OIDC_LOGIN_FAILED", decodeJSON(resp)["code"])
+}
+
+func TestOIDCIntegration_DisabledAccountCannotLogIn(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	identity := stubIdentity{Subject: "emp-6", Email: "frank@corp.example.com", EmailVerified: true}
+	resp := oidcLogin(t, app, identity)
+	userID := decodeJSON(resp)["user"].(map[string]interface{})["id"].(string)
+
+	adminToken := registerAdmin(t, app)
+	resp = doJSON(t, "POST", app.URL+"/api/v1/admin/users/"+userID+"/disable", adminToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = oidcLogin(t, app, identity)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, "ACCOUNT_DISABLED", decodeJSON(resp)["code"])
+}
+
+func TestOIDCIntegration_RequiresSecondFactor(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	identity := stubIdentity{Subject: "emp-7", Email: "grace@corp.example.com", EmailVerified: true}
+	token := decodeJSON(oidcLogin(t, app, identity))["token"].(string)
+	secret, _ := enableMFA(t, app.URL, token)
+
+	resp := oidcLogin(t, app, identity)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body := decodeJSON(resp)
+	assert.Equal(t, true, body["mfa_required"])
+	assert.Nil(t, body["token"])
+
+	resp = verifyMFA(t, app.URL, body["mfa_token"].(string), totpCode(t, secret, 1))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, decodeJSON(resp)["token"])
+}
+
+func TestOIDCIntegration_PasswordlessAccountReauthenticates(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	identity := stubIdentity{Subject: "emp-8", Email: "heidi@corp.example.com", EmailVerified: true}
+	token := decodeJSON(oidcLogin(t, app, identity))["token"].(string)
+	secret, _ := enableMFA(t, app.URL, token)
+
+	// A wrong two-factor code is refused even from a fresh login
+	resp := doJSON(t, "POST", app.URL+"/api/v1/users/me/password", token, map[string]string{
+		"mfa_code":     "000000",
+		"new_password": "new-password123",
+	})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, "INVALID_CREDENTIALS", decodeJSON(resp)["code"])
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/users/me/password", token, map[string]string{
+		"mfa_code":     totpCode(t, secret, 1),
+		"new_password": "new-password123",
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = login(t, app.URL, "heidi@corp.example.com", "new-password123")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// A login through the provider a moment ago confirms the deletion of an
+	// account without a password
+	other := stubIdentity{Subject: "emp-9", Email: "ivan@corp.example.com", EmailVerified: true}
+	token = decodeJSON(oidcLogin(t, app, other))["token"].(string)
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/users/me", token, map[string]string{})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
@@ -0,0 +1,272 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createOrganization(t *testing.T, serverURL, token, name string) string {
+	resp := doJSON(t, "POST", serverURL+"/api/v1/organizations", token, map[string]string{"name": name})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	body := decodeJSON(resp)
+	assert.Equal(t, "owner", body["role"])
+	return body["id"].(string)
+}
+
+// joinOrganization invites the user by email and accepts the mailed invitation
+func joinOrganization(t *testing.T, app *testApp, organizationID, ownerToken, email, token, role string) {
+	resp := doJSON(t, "POST", app.URL+"/api/v1/organizations/"+organizationID+"/invitations", ownerToken, map[string]string{
+		"email": email,
+		"role":  role,
+	})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/organizations/invitations/accept", token, map[string]string{
+		"token": mailedToken(t, app, email),
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, role, decodeJSON(resp)["role"])
+}
+
+func uploadToOrganization(t *testing.T, serverURL, token, organizationID string) *http.Response {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("audio", "memo.m4a")
+	part.Write([]byte("team audio"))
+	writer.WriteField("organization_id", organizationID)
+	writer.Close()
+
+	req, _ := http.NewRequest("POST", serverURL+"/api/v1/transcriptions", body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	return resp
+}
+
+func TestOrganizationIntegration_SharedTranscriptions(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "owner@example.com")
+	memberToken := registerUser(t, app.URL, "member@example.com")
+	outsiderToken := registerUser(t, app.URL, "outsider@example.com")
+
+	organizationID := createOrganization(t, app.URL, ownerToken, "Acme")
+	joinOrganization(t, app, organizationID, ownerToken, "member@example.com", memberToken, "member")
+
+	// Outsiders can neither create nor read workspace transcriptions
+	resp := uploadToOrganization(t, app.URL, outsiderToken, organizationID)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = uploadToOrganization(t, app.URL, memberToken, organizationID)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	shared := decodeJSON(resp)
+	assert.Equal(t, organizationID, shared["organization_id"])
+	transcriptionURL := app.URL + "/api/v1/transcriptions/" + shared["id"].(string)
+
+	resp = uploadAudio(t, app.URL, memberToken)
+	personal := decodeJSON(resp)
+	assert.Nil(t, personal["organization_id"])
+
+	resp = doJSON(t, "GET", transcriptionURL, ownerToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/transcriptions/"+personal["id"].(string), ownerToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "GET", transcriptionURL, outsiderToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/transcriptions?organization_id="+organizationID, ownerToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var listed []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&listed)
+	assert.Len(t, listed, 1)
+	assert.Equal(t, shared["id"], listed[0]["id"])
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/transcriptions?organization_id="+organizationID, outsiderToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/transcriptions?organization_id=nope", ownerToken, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Members read each other's work, only the author and admins edit it
+	colleagueToken := registerUser(t, app.URL, "colleague@example.com")
+	joinOrganization(t, app, organizationID, ownerToken, "colleague@example.com", colleagueToken, "member")
+
+	resp = doJSON(t, "GET", transcriptionURL, colleagueToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doJSON(t, "PATCH", transcriptionURL, colleagueToken, map[string]string{"text": "Rewritten"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = doJSON(t, "PATCH", transcriptionURL, memberToken, map[string]string{"text": "Corrected"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doJSON(t, "PATCH", transcriptionURL, ownerToken, map[string]string{"text": "Reviewed"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Access ends with the membership, except to the member's own transcriptions
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/organizations/"+organizationID+"/members/"+getMe(t, app.URL, memberToken)["id"].(string), memberToken, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = doJSON(t, "GET", transcriptionURL, memberToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doJSON(t, "GET", transcriptionURL, ownerToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = uploadToOrganization(t, app.URL, memberToken, organizationID)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestOrganizationIntegration_Invitations(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "lead@example.com")
+	organizationID := createOrganization(t, app.URL, ownerToken, "Acme")
+	invitationsURL := app.URL + "/api/v1/organizations/" + organizationID + "/invitations"
+
+	resp := doJSON(t, "POST", invitationsURL, ownerToken, map[string]string{"email": "Hire@Example.com"})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "member", decodeJSON(resp)["role"])
+
+	message, ok := app.mailer.LastTo("hire@example.com")
+	assert.True(t, ok)
+	assert.Contains(t, message.Body, "https://app.example.com/invitations?token=")
+	assert.Contains(t, message.Body, "Acme")
+	token := mailedToken(t, app, "hire@example.com")
+
+	resp = doJSON(t, "GET", invitationsURL, ownerToken, nil)
+	var pending []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&pending)
+	assert.Len(t, pending, 1)
+
+	// The invitation only works for the invited address
+	otherToken := registerUser(t, app.URL, "other@example.com")
+	resp = doJSON(t, "POST", app.URL+"/api/v1/organizations/invitations/accept", otherToken, map[string]string{"token": token})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, "INVITATION_EMAIL_MISMATCH", decodeJSON(resp)["code"])
+
+	hireToken := registerUser(t, app.URL, "hire@example.com")
+	resp = doJSON(t, "POST", app.URL+"/api/v1/organizations/invitations/accept", hireToken, map[string]string{"token": token})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/organizations/invitations/accept", hireToken, map[string]string{"token": token})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "INVALID_INVITATION", decodeJSON(resp)["code"])
+
+	resp = doJSON(t, "POST", invitationsURL, ownerToken, map[string]string{"email": "hire@example.com"})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Members cannot invite, and revoked invitations cannot be accepted
+	resp = doJSON(t, "POST", invitationsURL, hireToken, map[string]string{"email": "friend@example.com"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "POST", invitationsURL, ownerToken, map[string]string{"email": "friend@example.com", "role": "admin"})
+	invitationID := decodeJSON(resp)["id"].(string)
+	friendInvitation := mailedToken(t, app, "friend@example.com")
+
+	resp = doJSON(t, "DELETE", invitationsURL+"/"+invitationID, ownerToken, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	friendToken := registerUser(t, app.URL, "friend@example.com")
+	resp = doJSON(t, "POST", app.URL+"/api/v1/organizations/invitations/accept", friendToken, map[string]string{"token": friendInvitation})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/organizations", hireToken, nil)
+	var organizations []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&organizations)
+	assert.Len(t, organizations, 1)
+	assert.Equal(t, "Acme", organizations[0]["name"])
+	assert.Equal(t, "member", organizations[0]["role"])
+}
+
+func TestOrganizationIntegration_MemberManagement(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "founder@example.com")
+	adminToken := registerUser(t, app.URL, "admin2@example.com")
+	memberToken := registerUser(t, app.URL, "staff@example.com")
+	ownerID := getMe(t, app.URL, ownerToken)["id"].(string)
+	adminID := getMe(t, app.URL, adminToken)["id"].(string)
+	memberID := getMe(t, app.URL, memberToken)["id"].(string)
+
+	organizationID := createOrganization(t, app.URL, ownerToken, "Acme")
+	organizationURL := app.URL + "/api/v1/organizations/" + organizationID
+	joinOrganization(t, app, organizationID, ownerToken, "admin2@example.com", adminToken, "admin")
+	joinOrganization(t, app, organizationID, ownerToken, "staff@example.com", memberToken, "member")
+
+	resp := doJSON(t, "GET", organizationURL+"/members", memberToken, nil)
+	var members []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&members)
+	assert.Len(t, members, 3)
+	assert.Equal(t, "founder@example.com", members[0]["email"])
+	assert.Equal(t, "owner", members[0]["role"])
+
+	resp = doJSON(t, "PATCH", organizationURL, memberToken, map[string]string{"name": "Renamed"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = doJSON(t, "PATCH", organizationURL, adminToken, map[string]string{"name": "Acme Inc"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Acme Inc", decodeJSON(resp)["name"])
+
+	// Admins manage members but not owners
+	resp = doJSON(t, "PATCH", organizationURL+"/members/"+memberID, adminToken, map[string]string{"role": "admin"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "admin", decodeJSON(resp)["role"])
+
+	resp = doJSON(t, "PATCH", organizationURL+"/members/"+memberID, adminToken, map[string]string{"role": "owner"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = doJSON(t, "DELETE", organizationURL+"/members/"+ownerID, adminToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = doJSON(t, "PATCH", organizationURL+"/members/"+memberID, adminToken, map[string]string{"role": "guest"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// The last owner can neither leave nor step down
+	resp = doJSON(t, "DELETE", organizationURL+"/members/"+ownerID, ownerToken, nil)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "LAST_OWNER", decodeJSON(resp)["code"])
+	resp = doJSON(t, "PATCH", organizationURL+"/members/"+ownerID, ownerToken, map[string]string{"role": "member"})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = doJSON(t, "PATCH", organizationURL+"/members/"+adminID, ownerToken, map[string]string{"role": "owner"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doJSON(t, "DELETE", organizationURL+"/members/"+ownerID, ownerToken, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = doJSON(t, "GET", organizationURL, ownerToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "DELETE", organizationURL+"/members/"+memberID, adminToken, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp = doJSON(t, "DELETE", organizationURL+"/members/"+memberID, adminToken, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestOrganizationIntegration_DeletedOwnerHandsOverOwnership(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "departing@example.com")
+	memberToken := registerUser(t, app.URL, "successor@example.com")
+	organizationID := createOrganization(t, app.URL, ownerToken, "Acme")
+	joinOrganization(t, app, organizationID, ownerToken, "successor@example.com", memberToken, "member")
+
+	soloID := createOrganization(t, app.URL, ownerToken, "Solo")
+
+	resp := doJSON(t, "DELETE", app.URL+"/api/v1/users/me", ownerToken, map[string]string{"password": "password123"})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	app.deliver(t)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/organizations/"+organizationID, memberToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "owner", decodeJSON(resp)["role"])
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/organizations/"+soloID, memberToken, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
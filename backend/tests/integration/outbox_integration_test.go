@@ -0,0 +1,81 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestOutboxIntegration_DispatchesDomainEvents(t *testing.T) {
+	server := setupTestApp()
+	defer server.Close()
+
+	var names []string
+	for _, name := range []string{entities.EventUserRegistered, entities.EventTranscriptionCreated, entities.EventTranscriptionCompleted} {
+		server.eventBus.Subscribe(name, func(ctx context.Context, event entities.DomainEvent) error {
+			names = append(names, event.EventName())
+			return nil
+		})
+	}
+
+	token := getAuthToken(server.Server)
+	resp := uploadAudio(t, server.URL, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	dispatched, err := server.outboxDispatcher.DispatchPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, dispatched)
+	assert.Equal(t, []string{
+		entities.EventUserRegistered,
+		entities.EventTranscriptionCreated,
+		entities.EventTranscriptionCompleted,
+	}, names)
+
+	dispatched, err = server.outboxDispatcher.DispatchPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dispatched)
+}
+
+func TestOutboxIntegration_FailedHandlerDoesNotDuplicateWebhooks(t *testing.T) {
+	server := setupTestApp()
+	defer server.Close()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	failures := 1
+	server.eventBus.Subscribe(entities.EventTranscriptionCompleted, func(ctx context.Context, event entities.DomainEvent) error {
+		if failures > 0 {
+			failures--
+			return errors.New("analytics unavailable")
+		}
+		return nil
+	})
+
+	token := getAuthToken(server.Server)
+	doJSON(t, "POST", server.URL+"/api/v1/webhooks", token, map[string]interface{}{
+		"url":    receiver.URL,
+		"events": []string{"transcription.completed"},
+	})
+	uploadAudio(t, server.URL, token)
+
+	// The completed event fails once and stays in the outbox
+	dispatched, err := server.outboxDispatcher.DispatchPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, dispatched)
+
+	dispatched, err = server.outboxDispatcher.DispatchPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dispatched)
+
+	processed, err := server.webhookService.ProcessDueDeliveries(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/infrastructure/persistence"
+	httpInterface "github.com/voiceline/backend/internal/interface/http"
+)
+
+func setupRateLimitedServer(policies httpInterface.RateLimitPolicies) *httptest.Server {
+	authService := services.NewAuthService(persistence.NewMemoryUserRepository(), "test-secret")
+	transcriptionService := services.NewTranscriptionService(
+		persistence.NewMemoryTranscriptionRepository(),
+		&stubTranscriber{text: "Hello world", duration: 3},
+	)
+
+	router := httpInterface.NewRouter(authService, transcriptionService).
+		WithRateLimiting(persistence.NewMemoryRateLimitStore().WithClock(frozenClock()), policies)
+	return httptest.NewServer(router.Setup())
+}
+
+// frozenClock stops time so that refills and Retry-After do not depend on
+// how long requests take
+func frozenClock() func() time.Time {
+	now := time.Now()
+	return func() time.Time { return now }
+}
+
+func TestRateLimitIntegration_AuthRoutesLimitedPerIP(t *testing.T) {
+	policies := httpInterface.DefaultRateLimitPolicies()
+	policies.Auth = entities.RateLimitPolicy{Name: "auth", Limit: 3, Window: time.Minute}
+
+	server := setupRateLimitedServer(policies)
+	defer server.Close()
+
+	credentials := map[string]string{"email": "nobody@example.com", "password": "wrong-password"}
+
+	for i := 0; i < 3; i++ {
+		resp := doJSON(t, "POST", server.URL+"/api/v1/auth/login", "", credentials)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, "3", resp.Header.Get("RateLimit-Limit"))
+		assert.Equal(t, strconv.Itoa(2-i), resp.Header.Get("RateLimit-Remaining"))
+		assert.Equal(t, "3;w=60", resp.Header.Get("RateLimit-Policy"))
+	}
+
+	resp := doJSON(t, "POST", server.URL+"/api/v1/auth/login", "", credentials)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "20", resp.Header.Get("Retry-After"))
+
+	// The auth bucket is shared by every auth route of the client
+	resp = doJSON(t, "POST", server.URL+"/api/v1/auth/register", "", map[string]string{
+		"email":    "new@example.com",
+		"password": "password123",
+		"name":     "New User",
+	})
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
+
+func TestRateLimitIntegration_TranscriptionRoutesLimitedPerUser(t *testing.T) {
+	policies := httpInterface.DefaultRateLimitPolicies()
+	policies.Transcriptions = entities.RateLimitPolicy{Name: "transcriptions", Limit: 2, Window: time.Minute}
+
+	server := setupRateLimitedServer(policies)
+	defer server.Close()
+
+	alice := registerUser(t, server.URL, "alice@example.com")
+	bob := registerUser(t, server.URL, "bob@example.com")
+
+	for i := 0; i < 2; i++ {
+		resp := doJSON(t, "GET", server.URL+"/api/v1/transcriptions", alice, nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	resp := doJSON(t, "GET", server.URL+"/api/v1/transcriptions", alice, nil)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+	// Another user from the same IP has their own bucket
+	resp = doJSON(t, "GET", server.URL+"/api/v1/transcriptions", bob, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("RateLimit-Remaining"))
+}
+
+func TestRateLimitIntegration_DisabledWithoutStore(t *testing.T) {
+	server := setupTestServer()
+	defer server.Close()
+
+	resp := doJSON(t, "POST", server.URL+"/api/v1/auth/login", "", map[string]string{
+		"email":    "nobody@example.com",
+		"password": "wrong-password",
+	})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("RateLimit-Limit"))
+}
@@ -0,0 +1,78 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactionIntegration_OrganizationPolicy(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "owner@example.com")
+	memberToken := registerUser(t, app.URL, "member@example.com")
+
+	organizationID := createOrganization(t, app.URL, ownerToken, "Acme")
+	joinOrganization(t, app, organizationID, ownerToken, "member@example.com", memberToken, "member")
+	policyURL := app.URL + "/api/v1/organizations/" + organizationID + "/redaction-policy"
+
+	resp := uploadToOrganization(t, app.URL, memberToken, organizationID)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	transcriptionURL := app.URL + "/api/v1/transcriptions/" + decodeJSON(resp)["id"].(string)
+
+	// Without the policy findings are reported next to the original text
+	resp = doJSON(t, "PATCH", transcriptionURL, memberToken, map[string]string{"text": "Mail jane@example.com"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body := decodeJSON(resp)
+	assert.Equal(t, "Mail jane@example.com", body["text"])
+	assert.Equal(t, "Mail [EMAIL]", body["redacted_text"])
+	assert.Equal(t, map[string]interface{}{"email": float64(1)}, body["pii"])
+	assert.Nil(t, body["redacted"])
+
+	resp = uploadAudio(t, app.URL, memberToken)
+	personalURL := app.URL + "/api/v1/transcriptions/" + decodeJSON(resp)["id"].(string)
+	resp = doJSON(t, "PATCH", personalURL, memberToken, map[string]string{"text": "Call 555 123 4567"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Only admins and owners set the policy
+	resp = doJSON(t, "PUT", policyURL, memberToken, map[string]bool{"required": true})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "PUT", policyURL, ownerToken, map[string]string{})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "PUT", policyURL, ownerToken, map[string]bool{"required": true})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, true, decodeJSON(resp)["redaction_required"])
+
+	resp = doJSON(t, "GET", transcriptionURL, ownerToken, nil)
+	body = decodeJSON(resp)
+	assert.Equal(t, "Mail [EMAIL]", body["text"])
+	assert.Equal(t, true, body["redacted"])
+	assert.Nil(t, body["redacted_text"])
+
+	// Edits and new uploads are redacted while the policy holds
+	resp = doJSON(t, "PATCH", transcriptionURL, memberToken, map[string]string{"text": "Mail jane@example.com or call 555 123 4567"})
+	assert.Equal(t, "Mail [EMAIL] or call [PHONE]", decodeJSON(resp)["text"])
+
+	resp = uploadToOrganization(t, app.URL, memberToken, organizationID)
+	assert.Equal(t, true, decodeJSON(resp)["redacted"])
+
+	// Personal transcriptions are not affected
+	resp = doJSON(t, "GET", personalURL, memberToken, nil)
+	body = decodeJSON(resp)
+	assert.Equal(t, "Call 555 123 4567", body["text"])
+	assert.Equal(t, "Call [PHONE]", body["redacted_text"])
+
+	// Lifting the policy restores the latest original text
+	resp = doJSON(t, "PUT", policyURL, ownerToken, map[string]bool{"required": false})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, false, decodeJSON(resp)["redaction_required"])
+
+	resp = doJSON(t, "GET", transcriptionURL, memberToken, nil)
+	body = decodeJSON(resp)
+	assert.Equal(t, "Mail jane@example.com or call 555 123 4567", body["text"])
+	assert.Equal(t, "Mail [EMAIL] or call [PHONE]", body["redacted_text"])
+}
@@ -0,0 +1,118 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func loginFromDevice(t *testing.T, serverURL, email, deviceName string) string {
+	resp := doJSON(t, "POST", serverURL+"/api/v1/auth/login", "", map[string]string{
+		"email":       email,
+		"password":    "password123",
+		"device_name": deviceName,
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	return decodeJSON(resp)["token"].(string)
+}
+
+func getSessions(t *testing.T, serverURL, token string) []map[string]interface{} {
+	resp := doJSON(t, "GET", serverURL+"/api/v1/users/me/sessions", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var sessions []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&sessions)
+	return sessions
+}
+
+func TestSessionIntegration_ListAndRevoke(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	registerToken := registerUser(t, app.URL, "devices@example.com")
+	laptopToken := loginFromDevice(t, app.URL, "devices@example.com", "Laptop")
+	phoneToken := loginFromDevice(t, app.URL, "devices@example.com", "Phone")
+
+	sessions := getSessions(t, app.URL, laptopToken)
+	assert.Len(t, sessions, 3)
+
+	var laptop, phone map[string]interface{}
+	for _, session := range sessions {
+		switch session["device_name"] {
+		case "Laptop":
+			laptop = session
+		case "Phone":
+			phone = session
+		}
+		assert.Equal(t, "127.0.0.1", session["ip"])
+		assert.Equal(t, "Go-http-client/1.1", session["user_agent"])
+	}
+	assert.Equal(t, true, laptop["current"])
+	assert.Equal(t, false, phone["current"])
+
+	resp := doJSON(t, "DELETE", app.URL+"/api/v1/users/me/sessions/"+phone["id"].(string), laptopToken, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// The revoked device is logged out while the others stay logged in
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me", phoneToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "devices@example.com", getMe(t, app.URL, registerToken)["email"])
+
+	assert.Len(t, getSessions(t, app.URL, laptopToken), 2)
+
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/users/me/sessions/"+phone["id"].(string), laptopToken, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Revoking the current session logs the caller out
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/users/me/sessions/"+laptop["id"].(string), laptopToken, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me/sessions", laptopToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestSessionIntegration_CannotRevokeOtherUsersSessions(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "owner@example.com")
+	otherToken := registerUser(t, app.URL, "other@example.com")
+	sessionID := getSessions(t, app.URL, ownerToken)[0]["id"].(string)
+
+	resp := doJSON(t, "DELETE", app.URL+"/api/v1/users/me/sessions/"+sessionID, otherToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/users/me/sessions/not-a-uuid", otherToken, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	assert.Equal(t, "owner@example.com", getMe(t, app.URL, ownerToken)["email"])
+}
+
+func TestSessionIntegration_RejectsTokensWithoutSession(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "sessionless@example.com")
+	userID := getMe(t, app.URL, token)["id"].(string)
+
+	// A validly signed token that no session can revoke is refused
+	key, err := app.signingKeys.SigningKey(context.Background())
+	assert.NoError(t, err)
+	sessionless := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), jwt.MapClaims{
+		"user_id": userID,
+		"role":    "user",
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	sessionless.Header["kid"] = key.ID
+	signed, err := sessionless.SignedString(key.PrivateKey)
+	assert.NoError(t, err)
+
+	resp := doJSON(t, "GET", app.URL+"/api/v1/users/me", signed, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,227 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func shareTranscription(t *testing.T, serverURL, token, transcriptionID, email, permission string) map[string]interface{} {
+	resp := doJSON(t, "POST", serverURL+"/api/v1/transcriptions/"+transcriptionID+"/shares", token, map[string]string{
+		"email":      email,
+		"permission": permission,
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	return decodeJSON(resp)
+}
+
+func TestSharingIntegration_ShareWithUser(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "owner@example.com")
+	recipientToken := registerVerifiedUser(t, app, "recipient@example.com")
+	outsiderToken := registerUser(t, app.URL, "outsider@example.com")
+
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, ownerToken))["id"].(string)
+	transcriptionURL := app.URL + "/api/v1/transcriptions/" + transcriptionID
+
+	resp := doJSON(t, "GET", transcriptionURL, recipientToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	share := shareTranscription(t, app.URL, ownerToken, transcriptionID, "recipient@example.com", "")
+	assert.Equal(t, "read", share["permission"])
+	assert.Equal(t, "recipient@example.com", share["email"])
+	assert.Nil(t, share["user_id"])
+	app.deliver(t)
+	message, ok := app.mailer.LastTo("recipient@example.com")
+	if assert.True(t, ok) {
+		assert.Contains(t, message.Body, "/transcriptions/"+transcriptionID)
+	}
+
+	// Read grants allow reading but not editing
+	resp = doJSON(t, "GET", transcriptionURL, recipientToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "PATCH", transcriptionURL, recipientToken, map[string]string{"text": "Edited by recipient"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "GET", transcriptionURL, outsiderToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// Recipients cannot manage the shares themselves
+	resp = doJSON(t, "POST", transcriptionURL+"/shares", recipientToken, map[string]string{"email": "outsider@example.com"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/transcriptions/shared", recipientToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var shared []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&shared))
+	assert.Len(t, shared, 1)
+	assert.Equal(t, transcriptionID, shared[0]["id"])
+
+	// Sharing again upgrades the existing grant
+	upgraded := shareTranscription(t, app.URL, ownerToken, transcriptionID, "recipient@example.com", "edit")
+	assert.Equal(t, share["id"], upgraded["id"])
+	assert.Equal(t, "edit", upgraded["permission"])
+
+	resp = doJSON(t, "PATCH", transcriptionURL, recipientToken, map[string]string{"text": "Edited by recipient"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Edited by recipient", decodeJSON(resp)["text"])
+
+	resp = doJSON(t, "GET", transcriptionURL+"/shares", ownerToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var shares []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&shares))
+	assert.Len(t, shares, 1)
+	assert.Equal(t, share["id"], shares[0]["id"])
+
+	resp = doJSON(t, "DELETE", transcriptionURL+"/shares/"+share["id"].(string), ownerToken, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = doJSON(t, "GET", transcriptionURL, recipientToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "DELETE", transcriptionURL+"/shares/"+share["id"].(string), ownerToken, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSharingIntegration_DoesNotRevealAccounts(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerVerifiedUser(t, app, "owner@example.com")
+	squatterToken := registerUser(t, app.URL, "unverified@example.com")
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, ownerToken))["id"].(string)
+	transcriptionURL := app.URL + "/api/v1/transcriptions/" + transcriptionID
+
+	resp := doJSON(t, "POST", transcriptionURL+"/shares", ownerToken, map[string]string{"email": "owner@example.com"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Registered or not, addresses get the same response and an email
+	unknown := shareTranscription(t, app.URL, ownerToken, transcriptionID, "newcomer@example.com", "read")
+	unverified := shareTranscription(t, app.URL, ownerToken, transcriptionID, "unverified@example.com", "read")
+	for _, share := range []map[string]interface{}{unknown, unverified} {
+		assert.ElementsMatch(t, []string{"id", "email", "permission", "created_at"}, keys(share))
+	}
+	app.deliver(t)
+	_, sent := app.mailer.LastTo("newcomer@example.com")
+	assert.True(t, sent)
+
+	// An unverified address does not unlock the share
+	resp = doJSON(t, "GET", transcriptionURL, squatterToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// Pending shares are claimed once the address is verified
+	newcomerToken := registerVerifiedUser(t, app, "newcomer@example.com")
+	resp = doJSON(t, "GET", transcriptionURL, newcomerToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/transcriptions/shared", newcomerToken, nil)
+	var shared []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&shared))
+	assert.Len(t, shared, 1)
+}
+
+func keys(object map[string]interface{}) []string {
+	result := make([]string, 0, len(object))
+	for key := range object {
+		result = append(result, key)
+	}
+	return result
+}
+
+func TestSharingIntegration_EditTranscription(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "editor@example.com")
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, token))["id"].(string)
+	transcriptionURL := app.URL + "/api/v1/transcriptions/" + transcriptionID
+
+	resp := doJSON(t, "PATCH", transcriptionURL, token, map[string]string{"text": ""})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "PATCH", transcriptionURL, token, map[string]string{"text": "Hello, world."})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "GET", transcriptionURL, token, nil)
+	assert.Equal(t, "Hello, world.", decodeJSON(resp)["text"])
+}
+
+func TestSharingIntegration_PublicLinks(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "owner@example.com")
+	otherToken := registerUser(t, app.URL, "other@example.com")
+
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, ownerToken))["id"].(string)
+	linksURL := app.URL + "/api/v1/transcriptions/" + transcriptionID + "/links"
+
+	resp := doJSON(t, "POST", linksURL, otherToken, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "POST", linksURL, ownerToken, map[string]string{"expires_at": time.Now().Add(-time.Hour).Format(time.RFC3339)})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", linksURL, ownerToken, nil)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	link := decodeJSON(resp)
+	assert.Equal(t, true, link["active"])
+	assert.True(t, strings.HasPrefix(link["url"].(string), "https://app.example.com/shared/"))
+	token := strings.TrimPrefix(link["url"].(string), "https://app.example.com/shared/")
+
+	// Anyone with the link can read the transcription, without its owner
+	resp = doJSON(t, "GET", app.URL+"/api/v1/shared/"+token, "", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	public := decodeJSON(resp)
+	assert.Equal(t, transcriptionID, public["id"])
+	assert.Equal(t, "Hello world", public["text"])
+	assert.NotContains(t, public, "user_id")
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/shared/not-a-token", "", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// The URL is only shown once
+	resp = doJSON(t, "GET", linksURL, ownerToken, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var links []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&links))
+	assert.Len(t, links, 1)
+	assert.NotContains(t, links[0], "url")
+
+	resp = doJSON(t, "DELETE", linksURL+"/"+link["id"].(string), ownerToken, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/shared/"+token, "", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = doJSON(t, "GET", linksURL, ownerToken, nil)
+	links = nil
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&links))
+	assert.Equal(t, false, links[0]["active"])
+}
+
+func TestSharingIntegration_DeletedOwnerRemovesLinks(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	ownerToken := registerUser(t, app.URL, "owner@example.com")
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, ownerToken))["id"].(string)
+
+	resp := doJSON(t, "POST", app.URL+"/api/v1/transcriptions/"+transcriptionID+"/links", ownerToken, nil)
+	link := decodeJSON(resp)
+	token := strings.TrimPrefix(link["url"].(string), "https://app.example.com/shared/")
+
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/users/me", ownerToken, map[string]string{"password": "password123"})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	app.deliver(t)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/shared/"+token, "", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
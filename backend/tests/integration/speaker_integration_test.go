@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpeakerIntegration_DiarizedSegments(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "speakers@example.com")
+	transcription := decodeJSON(uploadAudio(t, app.URL, token))
+	transcriptionURL := app.URL + "/api/v1/transcriptions/" + transcription["id"].(string)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"start": 0.0, "end": 1.5, "speaker": "SPEAKER_00", "speaker_name": "SPEAKER_00", "text": "Hello"},
+		map[string]interface{}{"start": 1.5, "end": 3.0, "speaker": "SPEAKER_01", "speaker_name": "SPEAKER_01", "text": "world"},
+	}, transcription["segments"])
+
+	resp := doJSON(t, "GET", transcriptionURL+"/speakers", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var speakers []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&speakers))
+	assert.Equal(t, []map[string]interface{}{
+		{"label": "SPEAKER_00", "name": "SPEAKER_00"},
+		{"label": "SPEAKER_01", "name": "SPEAKER_01"},
+	}, speakers)
+
+	resp = doJSON(t, "PATCH", transcriptionURL+"/speakers/SPEAKER_01", token, map[string]string{"name": " Bob "})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	speakers = nil
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&speakers))
+	assert.Equal(t, "Bob", speakers[1]["name"])
+
+	resp = doJSON(t, "GET", transcriptionURL, token, nil)
+	segments := decodeJSON(resp)["segments"].([]interface{})
+	assert.Equal(t, "SPEAKER_01", segments[1].(map[string]interface{})["speaker"])
+	assert.Equal(t, "Bob", segments[1].(map[string]interface{})["speaker_name"])
+
+	resp = doJSON(t, "PATCH", transcriptionURL+"/speakers/SPEAKER_07", token, map[string]string{"name": "Carol"})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = doJSON(t, "PATCH", transcriptionURL+"/speakers/SPEAKER_00", token, map[string]string{"name": strings.Repeat("a", 101)})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// An empty name restores the label
+	resp = doJSON(t, "PATCH", transcriptionURL+"/speakers/SPEAKER_01", token, map[string]string{"name": ""})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	speakers = nil
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&speakers))
+	assert.Equal(t, "SPEAKER_01", speakers[1]["name"])
+}
+
+func TestSpeakerIntegration_EditedTextDropsSegments(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "stale-speakers@example.com")
+	transcriptionURL := app.URL + "/api/v1/transcriptions/" + decodeJSON(uploadAudio(t, app.URL, token))["id"].(string)
+
+	resp := doJSON(t, "PATCH", transcriptionURL, token, map[string]string{"text": "Hello there"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	edited := decodeJSON(resp)
+	assert.Equal(t, "Hello there", edited["text"])
+	assert.Nil(t, edited["segments"])
+
+	resp = doJSON(t, "GET", transcriptionURL, token, nil)
+	assert.Nil(t, decodeJSON(resp)["segments"])
+
+	// Translations of the edited text carry no segments with the old text
+	resp = doJSON(t, "POST", transcriptionURL+"/translations?target=de", token, nil)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	translation := decodeJSON(resp)
+	assert.Equal(t, "[de] Hello there", translation["text"])
+	assert.Nil(t, translation["segments"])
+
+	resp = doJSON(t, "GET", transcriptionURL+"/translations/"+translation["id"].(string)+"/export", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "[de] Hello there", string(body))
+}
+
+func TestSpeakerIntegration_RenameRequiresEditAccess(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	owner := registerUser(t, app.URL, "speaker-owner@example.com")
+	reader := registerVerifiedUser(t, app, "speaker-reader@example.com")
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, owner))["id"].(string)
+	speakersURL := app.URL + "/api/v1/transcriptions/" + transcriptionID + "/speakers"
+
+	resp := doJSON(t, "GET", speakersURL, reader, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	shareTranscription(t, app.URL, owner, transcriptionID, "speaker-reader@example.com", "read")
+
+	resp = doJSON(t, "GET", speakersURL, reader, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "PATCH", speakersURL+"/SPEAKER_00", reader, map[string]string{"name": "Alice"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	shareTranscription(t, app.URL, owner, transcriptionID, "speaker-reader@example.com", "edit")
+
+	resp = doJSON(t, "PATCH", speakersURL+"/SPEAKER_00", reader, map[string]string{"name": "Alice"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
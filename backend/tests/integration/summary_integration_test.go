@@ -0,0 +1,67 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryIntegration_CachedAndRegenerated(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "summary@example.com")
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, token))["id"].(string)
+	transcriptionURL := app.URL + "/api/v1/transcriptions/" + transcriptionID
+	summaryURL := transcriptionURL + "/summary"
+
+	resp := doJSON(t, "GET", summaryURL, token, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = doJSON(t, "POST", summaryURL, token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	summary := decodeJSON(resp)
+	assert.Equal(t, "Hello world", summary["summary"])
+	assert.Equal(t, "local-extractive", summary["model"])
+	assert.Equal(t, false, summary["stale"])
+
+	resp = doJSON(t, "POST", summaryURL, token, nil)
+	assert.Equal(t, summary["generated_at"], decodeJSON(resp)["generated_at"])
+
+	text := "We reviewed the launch plan. The budget is approved. Alice will send the contract by Friday."
+	resp = doJSON(t, "PATCH", transcriptionURL, token, map[string]string{"text": text})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "GET", summaryURL, token, nil)
+	assert.Equal(t, true, decodeJSON(resp)["stale"])
+
+	app.deliver(t)
+
+	resp = doJSON(t, "GET", summaryURL, token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	regenerated := decodeJSON(resp)
+	assert.Equal(t, false, regenerated["stale"])
+	assert.NotEqual(t, summary["summary"], regenerated["summary"])
+	assert.Equal(t, []interface{}{"Alice will send the contract by Friday."}, regenerated["action_items"])
+}
+
+func TestSummaryIntegration_Access(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	owner := registerUser(t, app.URL, "summary-owner@example.com")
+	outsider := registerVerifiedUser(t, app, "summary-outsider@example.com")
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, owner))["id"].(string)
+	summaryURL := app.URL + "/api/v1/transcriptions/" + transcriptionID + "/summary"
+
+	resp := doJSON(t, "POST", summaryURL, outsider, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/transcriptions/not-a-uuid/summary", owner, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	shareTranscription(t, app.URL, owner, transcriptionID, "summary-outsider@example.com", "read")
+	resp = doJSON(t, "POST", summaryURL, outsider, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
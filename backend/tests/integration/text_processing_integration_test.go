@@ -0,0 +1,65 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextProcessingIntegration_Pipeline(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "writer@example.com")
+	otherToken := registerUser(t, app.URL, "reader@example.com")
+	settingsURL := app.URL + "/api/v1/users/me/text-processing"
+
+	resp := doJSON(t, "GET", settingsURL, token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body := decodeJSON(resp)
+	assert.Empty(t, body["stages"])
+	assert.Equal(t, []interface{}{"fillers", "numbers", "normalize", "replacements", "profanity"}, body["available_stages"])
+
+	for _, payload := range []map[string]interface{}{
+		{"stages": []string{"spelling"}},
+		{"stages": []string{"normalize", "normalize"}},
+		{"replacements": []map[string]string{{"find": "", "replace": "x"}}},
+	} {
+		resp = doJSON(t, "PUT", settingsURL, token, payload)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp = doJSON(t, "PUT", settingsURL, token, map[string]interface{}{
+		"stages":       []string{"fillers", "numbers", "normalize", "replacements", "profanity"},
+		"replacements": []map[string]string{{"find": "voice line", "replace": "Voiceline"}},
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, decodeJSON(resp)["stages"], 5)
+
+	// The stages run in order on new transcriptions; the provider's text is kept
+	raw := "um so voice line costs twenty five dollars, damn it"
+	app.transcriber.text = raw
+
+	resp = uploadAudio(t, app.URL, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body = decodeJSON(resp)
+	assert.Equal(t, "So Voiceline costs 25 dollars, d*** it.", body["text"])
+	assert.Equal(t, raw, body["raw_text"])
+
+	// Users without settings get the provider's text
+	resp = uploadAudio(t, app.URL, otherToken)
+	body = decodeJSON(resp)
+	assert.Equal(t, raw, body["text"])
+	assert.Nil(t, body["raw_text"])
+
+	// Settings are personal; saving empty ones turns processing off
+	resp = doJSON(t, "GET", settingsURL, otherToken, nil)
+	assert.Empty(t, decodeJSON(resp)["replacements"])
+
+	resp = doJSON(t, "PUT", settingsURL, token, map[string]interface{}{})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body = decodeJSON(resp)
+	assert.Empty(t, body["stages"])
+	assert.Empty(t, body["replacements"])
+}
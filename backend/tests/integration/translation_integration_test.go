@@ -0,0 +1,106 @@
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslationIntegration_Versions(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "translate@example.com")
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, token))["id"].(string)
+	transcriptionURL := app.URL + "/api/v1/transcriptions/" + transcriptionID
+	translationsURL := transcriptionURL + "/translations"
+
+	resp := doJSON(t, "POST", translationsURL, token, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", translationsURL+"?target=not a language", token, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", translationsURL+"?target=DE", token, nil)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	first := decodeJSON(resp)
+	assert.Equal(t, "de", first["language"])
+	assert.Equal(t, float64(1), first["version"])
+	assert.Equal(t, "[de] Hello world", first["text"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"start": 0.0, "end": 1.5, "speaker": "SPEAKER_00", "text": "[de] Hello"},
+		map[string]interface{}{"start": 1.5, "end": 3.0, "speaker": "SPEAKER_01", "text": "[de] world"},
+	}, first["segments"])
+
+	// Unchanged text reuses the latest version
+	resp = doJSON(t, "POST", translationsURL+"?target=de", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, first["id"], decodeJSON(resp)["id"])
+
+	resp = doJSON(t, "PATCH", transcriptionURL, token, map[string]string{"text": "Hello again"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "POST", translationsURL+"?target=de", token, nil)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	second := decodeJSON(resp)
+	assert.Equal(t, float64(2), second["version"])
+	assert.Equal(t, "[de] Hello again", second["text"])
+
+	resp = doJSON(t, "POST", translationsURL+"?target=pt-br", token, nil)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "pt-BR", decodeJSON(resp)["language"])
+
+	resp = doJSON(t, "GET", translationsURL, token, nil)
+	var translations []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&translations))
+	assert.Len(t, translations, 3)
+
+	resp = doJSON(t, "GET", translationsURL+"?target=de", token, nil)
+	translations = nil
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&translations))
+	if assert.Len(t, translations, 2) {
+		assert.Equal(t, first["id"], translations[0]["id"])
+		assert.Equal(t, second["id"], translations[1]["id"])
+	}
+
+	resp = doJSON(t, "GET", translationsURL+"/"+first["id"].(string), token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "[de] Hello world", decodeJSON(resp)["text"])
+
+	resp = doJSON(t, "GET", translationsURL+"/"+first["id"].(string)+"/export", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), transcriptionID+".de.v1.txt")
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "SPEAKER_00: [de] Hello\nSPEAKER_01: [de] world", string(body))
+}
+
+func TestTranslationIntegration_Access(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	owner := registerUser(t, app.URL, "translate-owner@example.com")
+	outsider := registerVerifiedUser(t, app, "translate-outsider@example.com")
+	transcriptionID := decodeJSON(uploadAudio(t, app.URL, owner))["id"].(string)
+	translationsURL := app.URL + "/api/v1/transcriptions/" + transcriptionID + "/translations"
+
+	resp := doJSON(t, "POST", translationsURL+"?target=fr", owner, nil)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	translationID := decodeJSON(resp)["id"].(string)
+
+	resp = doJSON(t, "POST", translationsURL+"?target=fr", outsider, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "GET", translationsURL+"/"+translationID, outsider, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	otherID := decodeJSON(uploadAudio(t, app.URL, owner))["id"].(string)
+	resp = doJSON(t, "GET", app.URL+"/api/v1/transcriptions/"+otherID+"/translations/"+translationID, owner, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	shareTranscription(t, app.URL, owner, transcriptionID, "translate-outsider@example.com", "read")
+	resp = doJSON(t, "GET", translationsURL+"/"+translationID, outsider, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,135 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func getUsage(t *testing.T, serverURL, token string) map[string]interface{} {
+	resp := doJSON(t, "GET", serverURL+"/api/v1/usage", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var usage map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&usage)
+	return usage
+}
+
+func TestUsageIntegration_MeteringAndQuota(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+	app.transcriber.duration = 30
+
+	token := registerUser(t, app.URL, "usage@example.com")
+
+	usage := getUsage(t, app.URL, token)
+	assert.Equal(t, "free", usage["plan"])
+	assert.Equal(t, 60.0, usage["quota_minutes"])
+	assert.Equal(t, 0.0, usage["used_minutes"])
+
+	resp := uploadAudioWithHeaders(t, app.URL, token, []byte("first"), nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	usage = getUsage(t, app.URL, token)
+	assert.Equal(t, 30.0, usage["audio_seconds"])
+	assert.Equal(t, 0.5, usage["used_minutes"])
+	assert.InDelta(t, 0.003, usage["estimated_cost"], 1e-9)
+	assert.Equal(t, 1.0, usage["transcriptions"])
+
+	// Lower the user's quota to one minute
+	var me map[string]interface{}
+	loginResp := doJSON(t, "POST", app.URL+"/api/v1/auth/login", "", map[string]string{
+		"email":    "usage@example.com",
+		"password": "password123",
+	})
+	json.NewDecoder(loginResp.Body).Decode(&me)
+	userID := uuid.MustParse(me["user"].(map[string]interface{})["id"].(string))
+	oneMinute := 1.0
+	assert.NoError(t, app.usageService.SetUserQuota(context.Background(), userID, &oneMinute))
+
+	resp = uploadAudioWithHeaders(t, app.URL, token, []byte("second"), nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = uploadAudioWithHeaders(t, app.URL, token, []byte("third"), nil)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	var errBody map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&errBody)
+	assert.Equal(t, "QUOTA_EXCEEDED", errBody["code"])
+	assert.Equal(t, 2, app.transcriber.Calls())
+
+	// Reusing an earlier result does not need quota
+	resp = uploadAudioWithHeaders(t, app.URL, token, []byte("first"), nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	usage = getUsage(t, app.URL, token)
+	assert.Equal(t, 1.0, usage["quota_minutes"])
+	assert.Equal(t, 0.0, usage["remaining_minutes"])
+	assert.Equal(t, 2.0, usage["transcriptions"])
+}
+
+func TestUsageIntegration_SummariesAndTranslationsAreMetered(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+	app.transcriber.duration = 30
+
+	owner := registerUser(t, app.URL, "metered-owner@example.com")
+	reader := registerVerifiedUser(t, app, "metered-reader@example.com")
+	first := decodeJSON(uploadAudioWithHeaders(t, app.URL, owner, []byte("first"), nil))["id"].(string)
+	second := decodeJSON(uploadAudioWithHeaders(t, app.URL, owner, []byte("second"), nil))["id"].(string)
+	shareTranscription(t, app.URL, owner, first, "metered-reader@example.com", "read")
+	shareTranscription(t, app.URL, owner, second, "metered-reader@example.com", "read")
+
+	// Read-only recipients pay for what they ask for
+	resp := doJSON(t, "POST", app.URL+"/api/v1/transcriptions/"+first+"/summary", reader, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doJSON(t, "POST", app.URL+"/api/v1/transcriptions/"+first+"/translations?target=fr", reader, nil)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// They add cost but leave the minutes for transcriptions
+	usage := getUsage(t, app.URL, reader)
+	assert.Equal(t, 0.0, usage["audio_seconds"])
+	assert.Equal(t, usage["quota_minutes"], usage["remaining_minutes"])
+	assert.Greater(t, usage["estimated_cost"], 0.0)
+	assert.Equal(t, 0.0, usage["transcriptions"])
+	assert.Equal(t, 1.0, usage["summaries"])
+	assert.Equal(t, 1.0, usage["translations"])
+	assert.Equal(t, 2.0, getUsage(t, app.URL, owner)["transcriptions"])
+
+	// Every target language is a new translation, none of them costs minutes
+	for _, target := range []string{"de", "es", "it"} {
+		resp = doJSON(t, "POST", app.URL+"/api/v1/transcriptions/"+second+"/translations?target="+target, owner, nil)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+	usage = getUsage(t, app.URL, owner)
+	assert.Equal(t, 60.0, usage["audio_seconds"])
+	assert.Equal(t, 3.0, usage["translations"])
+
+	noMinutes := 0.0
+	readerID := uuid.MustParse(getMe(t, app.URL, reader)["id"].(string))
+	assert.NoError(t, app.usageService.SetUserQuota(context.Background(), readerID, &noMinutes))
+
+	// Cached results stay free, new provider calls are refused
+	resp = doJSON(t, "POST", app.URL+"/api/v1/transcriptions/"+first+"/summary", reader, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doJSON(t, "POST", app.URL+"/api/v1/transcriptions/"+first+"/translations?target=fr", reader, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/transcriptions/"+second+"/summary", reader, nil)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "QUOTA_EXCEEDED", decodeJSON(resp)["code"])
+	resp = doJSON(t, "POST", app.URL+"/api/v1/transcriptions/"+first+"/translations?target=de", reader, nil)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "QUOTA_EXCEEDED", decodeJSON(resp)["code"])
+}
+
+func TestUsageIntegration_Unauthorized(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	resp := doJSON(t, "GET", app.URL+"/api/v1/usage", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,158 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func getMe(t *testing.T, serverURL, token string) map[string]interface{} {
+	resp := doJSON(t, "GET", serverURL+"/api/v1/users/me", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var user map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&user)
+	return user
+}
+
+func TestUserIntegration_GetAndUpdateProfile(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "profile@example.com")
+	registerUser(t, app.URL, "taken@example.com")
+
+	me := getMe(t, app.URL, token)
+	assert.Equal(t, "profile@example.com", me["email"])
+	assert.Equal(t, false, me["emailVerified"])
+
+	resp := doJSON(t, "PATCH", app.URL+"/api/v1/users/me", token, map[string]string{"name": "Renamed"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Renamed", getMe(t, app.URL, token)["name"])
+
+	// A rejected address leaves the name unchanged too
+	resp = doJSON(t, "PATCH", app.URL+"/api/v1/users/me", token, map[string]string{"name": "Again", "email": "taken@example.com"})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = doJSON(t, "PATCH", app.URL+"/api/v1/users/me", token, map[string]string{"name": "Again", "email": "not-an-email"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	me = getMe(t, app.URL, token)
+	assert.Equal(t, "Renamed", me["name"])
+	assert.Equal(t, "profile@example.com", me["email"])
+
+	resp = doJSON(t, "PATCH", app.URL+"/api/v1/users/me", token, map[string]string{"email": "moved@example.com"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var updated map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&updated)
+	assert.Equal(t, "moved@example.com", updated["email"])
+	assert.Equal(t, false, updated["emailVerified"])
+
+	// The new address must be verified again
+	verificationToken := mailedToken(t, app, "moved@example.com")
+	resp = doJSON(t, "POST", app.URL+"/api/v1/auth/verify-email/confirm", "", map[string]string{"token": verificationToken})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = login(t, app.URL, "profile@example.com", "password123")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = login(t, app.URL, "moved@example.com", "password123")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestUserIntegration_ChangePassword(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "password@example.com")
+	phoneToken := loginFromDevice(t, app.URL, "password@example.com", "Phone")
+
+	resp := doJSON(t, "POST", app.URL+"/api/v1/users/me/password", token, map[string]string{
+		"current_password": "wrong-password",
+		"new_password":     "new-password123",
+	})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/users/me/password", token, map[string]string{
+		"current_password": "password123",
+		"new_password":     "short",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/users/me/password", token, map[string]string{
+		"current_password": "password123",
+		"new_password":     "new-password123",
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// Other devices are logged out, the one making the change is not
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me", phoneToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = login(t, app.URL, "password@example.com", "password123")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = login(t, app.URL, "password@example.com", "new-password123")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestUserIntegration_DeleteAccountCascades(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	token := registerUser(t, app.URL, "leaving@example.com")
+	userID := uuid.MustParse(getMe(t, app.URL, token)["id"].(string))
+
+	resp := uploadAudio(t, app.URL, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, app.audioStore.Len())
+
+	resp = doJSON(t, "POST", app.URL+"/api/v1/webhooks", token, map[string]interface{}{
+		"url":    "https://example.com/hooks",
+		"events": []string{"transcription.completed"},
+	})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	app.deliver(t)
+
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/users/me", token, map[string]string{"password": "wrong-password"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = doJSON(t, "DELETE", app.URL+"/api/v1/users/me", token, map[string]string{"password": "password123"})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	app.deliver(t)
+
+	assert.Equal(t, 0, app.audioStore.Len())
+
+	webhooks, err := app.webhookService.GetUserWebhooks(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Empty(t, webhooks)
+
+	// Tokens of the deleted account are no longer accepted
+	resp = doJSON(t, "GET", app.URL+"/api/v1/users/me", token, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = doJSON(t, "GET", app.URL+"/api/v1/transcriptions", token, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	sessions, err := app.sessionService.GetUserSessions(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	resp = login(t, app.URL, "leaving@example.com", "password123")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestUserIntegration_Unauthorized(t *testing.T) {
+	app := setupTestApp()
+	defer app.Close()
+
+	resp := doJSON(t, "GET", app.URL+"/api/v1/users/me", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,185 @@
+package integration
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/application/services"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/infrastructure/persistence"
+	"github.com/voiceline/backend/internal/infrastructure/webhook"
+)
+
+func TestWebhookIntegration_CRUD(t *testing.T) {
+	server := setupTestApp()
+	defer server.Close()
+
+	token := getAuthToken(server.Server)
+
+	resp := doJSON(t, "POST", server.URL+"/api/v1/webhooks", token, map[string]interface{}{
+		"url":    "https://example.com/hooks",
+		"events": []string{"transcription.completed"},
+	})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&created)
+	assert.NotEmpty(t, created["secret"])
+	id := created["id"].(string)
+
+	resp = doJSON(t, "GET", server.URL+"/api/v1/webhooks/"+id, token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var fetched map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&fetched)
+	assert.Nil(t, fetched["secret"])
+
+	resp = doJSON(t, "PATCH", server.URL+"/api/v1/webhooks/"+id, token, map[string]interface{}{
+		"active": false,
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var updated map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&updated)
+	assert.Equal(t, false, updated["active"])
+
+	resp = doJSON(t, "POST", server.URL+"/api/v1/webhooks", token, map[string]interface{}{
+		"url":    "https://example.com/hooks",
+		"events": []string{"user.deleted"},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doJSON(t, "DELETE", server.URL+"/api/v1/webhooks/"+id, token, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = doJSON(t, "GET", server.URL+"/api/v1/webhooks/"+id, token, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestWebhookIntegration_SignedDeliveryAndRedelivery(t *testing.T) {
+	server := setupTestApp()
+	defer server.Close()
+
+	var mu sync.Mutex
+	var received []*http.Request
+	var bodies [][]byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, r)
+		bodies = append(bodies, body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	token := getAuthToken(server.Server)
+
+	resp := doJSON(t, "POST", server.URL+"/api/v1/webhooks", token, map[string]interface{}{
+		"url":    receiver.URL,
+		"events": []string{"transcription.completed"},
+	})
+	var created map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&created)
+	id := created["id"].(string)
+	secret := created["secret"].(string)
+
+	resp = uploadAudio(t, server.URL, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Equal(t, 1, server.deliver(t))
+	assert.Len(t, received, 1)
+	req := received[0]
+	assert.Equal(t, "transcription.completed", req.Header.Get("X-Voiceline-Event"))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(req.Header.Get("X-Voiceline-Timestamp") + "."))
+	mac.Write(bodies[0])
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), req.Header.Get("X-Voiceline-Signature"))
+
+	var payload map[string]interface{}
+	json.Unmarshal(bodies[0], &payload)
+	assert.Equal(t, "transcription.completed", payload["type"])
+	assert.Equal(t, "Hello world", payload["data"].(map[string]interface{})["text"])
+
+	resp = doJSON(t, "GET", server.URL+"/api/v1/webhooks/"+id+"/deliveries", token, nil)
+	var deliveries []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&deliveries)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, "succeeded", deliveries[0]["status"])
+
+	deliveryID := deliveries[0]["id"].(string)
+	resp = doJSON(t, "POST", server.URL+"/api/v1/webhooks/"+id+"/deliveries/"+deliveryID+"/redeliver", token, nil)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	assert.Equal(t, 1, server.deliver(t))
+	assert.Len(t, received, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestWebhookIntegration_PrivateAddresses(t *testing.T) {
+	webhookService := services.NewWebhookService(
+		persistence.NewMemoryWebhookRepository(),
+		persistence.NewMemoryWebhookDeliveryRepository(),
+		webhook.NewHTTPSender(time.Second),
+	)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	for _, url := range []string{
+		"http://127.0.0.1:8080/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.5/hooks",
+		"http://[::1]/hooks",
+		"http://localhost/hooks",
+		"http://api.localhost/hooks",
+	} {
+		_, err := webhookService.CreateWebhook(ctx, services.CreateWebhookInput{
+			UserID: userID,
+			URL:    url,
+			Events: []string{"transcription.completed"},
+		})
+		assert.Equal(t, entities.ErrWebhookAddressNotAllowed, err, url)
+	}
+
+	created, err := webhookService.CreateWebhook(ctx, services.CreateWebhookInput{
+		UserID: userID,
+		URL:    "https://93.184.216.34/hooks",
+		Events: []string{"transcription.completed"},
+	})
+	assert.NoError(t, err)
+
+	private := "http://192.168.0.10/hooks"
+	_, err = webhookService.UpdateWebhook(ctx, services.UpdateWebhookInput{ID: created.ID, UserID: userID, URL: &private})
+	assert.Equal(t, entities.ErrWebhookAddressNotAllowed, err)
+
+	loopback := "http://127.0.0.1/hooks"
+	_, err = webhookService.UpdateWebhook(ctx, services.UpdateWebhookInput{ID: created.ID, UserID: userID, URL: &loopback})
+	assert.Equal(t, entities.ErrWebhookAddressNotAllowed, err)
+
+	stored, err := webhookService.GetWebhook(ctx, created.ID, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://93.184.216.34/hooks", stored.URL)
+
+	// The sender checks the address it connects to, whatever the URL said
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	_, err = webhook.NewHTTPSender(time.Second).Send(ctx, receiver.URL, nil, []byte("{}"))
+	assert.ErrorIs(t, err, webhook.ErrAddressNotAllowed)
+
+	status, err := webhook.NewHTTPSender(time.Second).AllowPrivateNetworks().Send(ctx, receiver.URL, nil, []byte("{}"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+}
@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	userID := uuid.New()
+
+	key, secret, err := entities.NewAPIKey(userID, " CI ", []string{entities.ScopeTranscriptionsRead, entities.ScopeTranscriptionsRead}, nil)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, "vl_"))
+	assert.True(t, strings.HasPrefix(secret, key.Prefix))
+	assert.Equal(t, "CI", key.Name)
+	assert.Equal(t, entities.HashAPIKey(secret), key.KeyHash)
+	assert.NotContains(t, key.KeyHash, secret)
+	assert.Equal(t, []string{entities.ScopeTranscriptionsRead}, key.Scopes)
+	assert.True(t, key.IsActive(time.Now()))
+}
+
+func TestNewAPIKey_Validation(t *testing.T) {
+	userID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+
+	_, _, err := entities.NewAPIKey(userID, "", []string{entities.ScopeUsageRead}, nil)
+	assert.Equal(t, entities.ErrInvalidAPIKeyName, err)
+
+	_, _, err = entities.NewAPIKey(userID, "CI", nil, nil)
+	assert.Equal(t, entities.ErrNoAPIKeyScopes, err)
+
+	_, _, err = entities.NewAPIKey(userID, "CI", []string{"admin:*"}, nil)
+	assert.Equal(t, entities.ErrInvalidAPIKeyScope, err)
+
+	_, _, err = entities.NewAPIKey(userID, "CI", []string{entities.ScopeUsageRead}, &past)
+	assert.Equal(t, entities.ErrInvalidAPIKeyExpiry, err)
+}
+
+func TestAPIKey_HasScope(t *testing.T) {
+	key, _, _ := entities.NewAPIKey(uuid.New(), "CI", []string{entities.ScopeTranscriptionsRead}, nil)
+
+	assert.True(t, key.HasScope(entities.ScopeTranscriptionsRead))
+	assert.False(t, key.HasScope(entities.ScopeTranscriptionsWrite))
+}
+
+func TestAPIKey_IsActive(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	key, _, _ := entities.NewAPIKey(uuid.New(), "CI", []string{entities.ScopeUsageRead}, &expiresAt)
+
+	assert.True(t, key.IsActive(time.Now()))
+	assert.False(t, key.IsActive(expiresAt.Add(time.Second)))
+
+	key.Revoke()
+	assert.False(t, key.IsActive(time.Now()))
+}
+
+func TestAPIKey_MarkUsed(t *testing.T) {
+	key, _, _ := entities.NewAPIKey(uuid.New(), "CI", []string{entities.ScopeUsageRead}, nil)
+	now := time.Now()
+
+	assert.True(t, key.MarkUsed(now, time.Minute))
+	assert.Equal(t, now, *key.LastUsedAt)
+
+	assert.False(t, key.MarkUsed(now.Add(30*time.Second), time.Minute))
+	assert.Equal(t, now, *key.LastUsedAt)
+
+	assert.True(t, key.MarkUsed(now.Add(2*time.Minute), time.Minute))
+}
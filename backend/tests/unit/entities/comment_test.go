@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func float(v float64) *float64 { return &v }
+
+func integer(v int) *int { return &v }
+
+func TestNewAnchor(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+	_ = trans.Complete("Grüße aus Berlin", 10)
+
+	anchor, err := entities.NewAnchor(trans, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, anchor)
+
+	anchor, err = entities.NewAnchor(trans, float(1.5), float(4), integer(0), integer(5))
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, *anchor.StartTime)
+	assert.Equal(t, "Grüße", anchor.Quote)
+
+	invalid := []struct {
+		name               string
+		startTime, endTime *float64
+		textStart, textEnd *int
+	}{
+		{"Open time range", float(1), nil, nil, nil},
+		{"Reversed time range", float(4), float(2), nil, nil},
+		{"Beyond duration", float(8), float(12), nil, nil},
+		{"Open text span", nil, nil, integer(2), nil},
+		{"Empty text span", nil, nil, integer(3), integer(3)},
+		{"Beyond text", nil, nil, integer(6), integer(40)},
+	}
+
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := entities.NewAnchor(trans, tc.startTime, tc.endTime, tc.textStart, tc.textEnd)
+			assert.Equal(t, entities.ErrInvalidAnchor, err)
+		})
+	}
+}
+
+func TestComment_Threads(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+	_ = trans.Complete("Hello world", 3)
+	author := uuid.New()
+
+	_, err := entities.NewComment(trans, author, "   ", nil)
+	assert.Equal(t, entities.ErrInvalidCommentBody, err)
+
+	_, err = entities.NewComment(trans, author, strings.Repeat("a", 5001), nil)
+	assert.Equal(t, entities.ErrInvalidCommentBody, err)
+
+	comment, err := entities.NewComment(trans, author, "  Check this  ", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Check this", comment.Body)
+	assert.Equal(t, trans.UserID, comment.OwnerID)
+	assert.Equal(t, comment.ID, comment.ThreadID())
+
+	reply, err := entities.NewReply(comment, uuid.New(), "Done")
+	assert.NoError(t, err)
+	assert.True(t, reply.IsReply())
+	assert.Equal(t, comment.ID, reply.ThreadID())
+
+	// Replies to replies join the thread
+	nested, err := entities.NewReply(reply, uuid.New(), "Thanks")
+	assert.NoError(t, err)
+	assert.Equal(t, comment.ID, *nested.ParentID)
+
+	assert.Equal(t, entities.ErrResolveReply, reply.Resolve(author))
+
+	resolver := uuid.New()
+	assert.NoError(t, comment.Resolve(resolver))
+	assert.True(t, comment.IsResolved())
+	assert.Equal(t, resolver, *comment.ResolvedBy)
+
+	assert.NoError(t, comment.Unresolve())
+	assert.False(t, comment.IsResolved())
+	assert.Nil(t, comment.ResolvedBy)
+
+	assert.True(t, comment.CanDelete(author))
+	assert.True(t, comment.CanDelete(trans.UserID))
+	assert.False(t, comment.CanDelete(resolver))
+}
+
+func TestNewHighlight(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+	_ = trans.Complete("Hello world", 3)
+	anchor, _ := entities.NewAnchor(trans, nil, nil, integer(0), integer(5))
+
+	_, err := entities.NewHighlight(trans, uuid.New(), nil, "", "")
+	assert.Equal(t, entities.ErrAnchorRequired, err)
+
+	_, err = entities.NewHighlight(trans, uuid.New(), anchor, "purple", "")
+	assert.Equal(t, entities.ErrInvalidHighlightColor, err)
+
+	_, err = entities.NewHighlight(trans, uuid.New(), anchor, "", strings.Repeat("n", 1001))
+	assert.Equal(t, entities.ErrInvalidHighlightNote, err)
+
+	highlight, err := entities.NewHighlight(trans, uuid.New(), anchor, "", " Greeting ")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.DefaultHighlightColor, highlight.Color)
+	assert.Equal(t, "Greeting", highlight.Note)
+	assert.Equal(t, "Hello", highlight.Anchor.Quote)
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestUser_RecordsRegisteredEvent(t *testing.T) {
+	user, err := NewUser("events@example.com", "password123", "Events User")
+	assert.NoError(t, err)
+
+	events := user.PullEvents()
+	assert.Len(t, events, 1)
+
+	registered, ok := events[0].(entities.UserRegistered)
+	assert.True(t, ok)
+	assert.Equal(t, user.ID, registered.UserID)
+	assert.Equal(t, entities.EventUserRegistered, registered.EventName())
+
+	assert.Empty(t, user.PullEvents())
+}
+
+func TestTranscription_RecordsLifecycleEvents(t *testing.T) {
+	t.Run("Created and completed", func(t *testing.T) {
+		trans := NewTranscription(uuid.New())
+		_ = trans.Complete("Hello", 1.5)
+
+		events := trans.PullEvents()
+		assert.Len(t, events, 2)
+		assert.Equal(t, entities.EventTranscriptionCreated, events[0].EventName())
+		assert.Equal(t, entities.EventTranscriptionCompleted, events[1].EventName())
+
+		completed := events[1].(entities.TranscriptionCompleted)
+		assert.Equal(t, trans.ID, completed.TranscriptionID)
+		assert.Equal(t, "Hello", completed.Text)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		trans := NewTranscription(uuid.New())
+		trans.PullEvents()
+		trans.Fail()
+
+		events := trans.PullEvents()
+		assert.Len(t, events, 1)
+		assert.Equal(t, entities.EventTranscriptionFailed, events[0].EventName())
+	})
+
+	t.Run("Edited", func(t *testing.T) {
+		trans := NewTranscription(uuid.New())
+		_ = trans.Complete("Helo", 1)
+		trans.PullEvents()
+
+		editor := uuid.New()
+		assert.NoError(t, trans.EditText("Hello", editor))
+
+		events := trans.PullEvents()
+		assert.Len(t, events, 1)
+
+		edited := events[0].(entities.TranscriptionEdited)
+		assert.Equal(t, entities.EventTranscriptionEdited, edited.EventName())
+		assert.Equal(t, trans.ID, edited.TranscriptionID)
+		assert.Equal(t, editor, edited.EditedBy)
+		assert.Equal(t, "Hello", edited.Text)
+	})
+
+	t.Run("Invalid completion records nothing", func(t *testing.T) {
+		trans := NewTranscription(uuid.New())
+		trans.PullEvents()
+		_ = trans.Complete("", 1)
+
+		assert.Empty(t, trans.PullEvents())
+	})
+}
+
+func TestOutboxMessage_RoundTrip(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+	_ = trans.Complete("Round trip", 2)
+	original := trans.PullEvents()[1]
+
+	message, err := entities.NewOutboxMessage(original)
+	assert.NoError(t, err)
+	assert.Equal(t, original.EventID(), message.ID)
+	assert.True(t, message.IsDue(time.Now()))
+
+	decoded, err := message.Event()
+	assert.NoError(t, err)
+
+	completed := decoded.(*entities.TranscriptionCompleted)
+	assert.Equal(t, original.EventID(), completed.EventID())
+	assert.Equal(t, trans.ID, completed.TranscriptionID)
+	assert.Equal(t, "Round trip", completed.Text)
+}
+
+func TestOutboxMessage_RecordFailure(t *testing.T) {
+	user, _ := NewUser("outbox@example.com", "password123", "Outbox User")
+	message, _ := entities.NewOutboxMessage(user.PullEvents()[0])
+
+	message.RecordFailure("handler failed", time.Minute, 2)
+	assert.True(t, message.IsPending())
+	assert.False(t, message.IsDue(time.Now()))
+
+	message.RecordFailure("handler failed", time.Minute, 2)
+	assert.False(t, message.IsPending())
+	assert.NotNil(t, message.DiscardedAt)
+
+	_, err := entities.DecodeDomainEvent("unknown.event", []byte(`{}`))
+	assert.Equal(t, entities.ErrUnknownDomainEvent, err)
+}
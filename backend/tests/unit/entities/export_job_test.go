@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestNewExportJob(t *testing.T) {
+	userID := uuid.New()
+	job := entities.NewExportJob(userID)
+
+	assert.Equal(t, userID, job.UserID)
+	assert.True(t, job.IsPending())
+	assert.True(t, job.IsActive())
+	assert.True(t, job.BelongsToUser(userID))
+	assert.False(t, job.BelongsToUser(uuid.New()))
+}
+
+func TestExportJob_Lifecycle(t *testing.T) {
+	job := entities.NewExportJob(uuid.New())
+
+	job.Start()
+	assert.True(t, job.IsActive())
+	assert.False(t, job.IsPending())
+
+	job.Complete("exports/archive.zip", 128, time.Hour)
+	assert.True(t, job.IsCompleted())
+	assert.False(t, job.IsActive())
+	assert.Equal(t, "exports/archive.zip", job.ArchiveKey)
+	assert.Equal(t, int64(128), job.Size)
+	assert.NotNil(t, job.CompletedAt)
+	assert.False(t, job.IsExpired(time.Now()))
+	assert.True(t, job.IsExpired(time.Now().Add(2*time.Hour)))
+}
+
+func TestExportJob_IsStale(t *testing.T) {
+	job := entities.NewExportJob(uuid.New())
+	assert.False(t, job.IsStale(time.Now().Add(2*time.Hour), time.Hour))
+
+	job.Start()
+	assert.False(t, job.IsStale(time.Now(), time.Hour))
+	assert.True(t, job.IsStale(time.Now().Add(2*time.Hour), time.Hour))
+
+	job.Complete("exports/archive.zip", 128, time.Hour)
+	assert.False(t, job.IsStale(time.Now().Add(2*time.Hour), time.Hour))
+}
+
+func TestExportJob_Fail(t *testing.T) {
+	job := entities.NewExportJob(uuid.New())
+	job.Start()
+	job.Fail("boom")
+
+	assert.Equal(t, entities.ExportFailed, job.Status)
+	assert.Equal(t, "boom", job.Error)
+	assert.False(t, job.IsActive())
+	assert.False(t, job.IsCompleted())
+}
+
+func TestExportJob_VerifyDownload(t *testing.T) {
+	job := entities.NewExportJob(uuid.New())
+	secret := []byte("secret")
+	now := time.Now()
+	expires := now.Add(time.Minute).Unix()
+
+	signature := job.SignDownload(secret, expires)
+
+	assert.True(t, job.VerifyDownload(secret, expires, signature, now))
+	assert.False(t, job.VerifyDownload([]byte("other"), expires, signature, now))
+	assert.False(t, job.VerifyDownload(secret, expires+60, signature, now))
+	assert.False(t, job.VerifyDownload(secret, expires, "", now))
+	assert.False(t, job.VerifyDownload(secret, expires, signature, now.Add(2*time.Minute)))
+
+	other := entities.NewExportJob(job.UserID)
+	assert.False(t, other.VerifyDownload(secret, expires, signature, now))
+}
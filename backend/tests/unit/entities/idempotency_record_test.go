@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestIdempotencyRecord(t *testing.T) {
+	record := entities.NewIdempotencyRecord("user:1:/api/v1/transcriptions:key", "hash", time.Hour)
+
+	assert.False(t, record.IsCompleted())
+	assert.True(t, record.Matches("hash"))
+	assert.False(t, record.Matches("other"))
+	assert.False(t, record.IsExpired(time.Now()))
+	assert.True(t, record.IsExpired(time.Now().Add(2*time.Hour)))
+
+	record.Complete(201, map[string]string{"Content-Type": "application/json"}, []byte(`{}`))
+	assert.True(t, record.IsCompleted())
+	assert.Equal(t, 201, record.ResponseStatus)
+}
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+var testLoginThrottlePolicy = entities.LoginThrottlePolicy{
+	FreeAttempts:     2,
+	BaseDelay:        time.Second,
+	MaxDelay:         4 * time.Second,
+	LockoutThreshold: 6,
+	LockoutDuration:  time.Hour,
+	FailureWindow:    10 * time.Minute,
+}
+
+func TestLoginThrottle_ProgressiveDelay(t *testing.T) {
+	throttle := entities.NewLoginThrottle("account:user@example.com")
+	now := time.Now()
+
+	throttle.RecordFailure(testLoginThrottlePolicy, now)
+	throttle.RecordFailure(testLoginThrottlePolicy, now)
+	assert.Equal(t, time.Duration(0), throttle.RetryAfter(now))
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+	for _, delay := range expected {
+		throttle.RecordFailure(testLoginThrottlePolicy, now)
+		assert.Equal(t, delay, throttle.RetryAfter(now))
+		assert.False(t, throttle.IsLocked(now))
+	}
+
+	assert.Equal(t, time.Duration(0), throttle.RetryAfter(now.Add(4*time.Second)))
+}
+
+func TestLoginThrottle_Lockout(t *testing.T) {
+	throttle := entities.NewLoginThrottle("account:user@example.com")
+	now := time.Now()
+
+	for i := 0; i < testLoginThrottlePolicy.LockoutThreshold; i++ {
+		throttle.RecordFailure(testLoginThrottlePolicy, now)
+	}
+
+	assert.True(t, throttle.IsLocked(now))
+	assert.Equal(t, time.Hour, throttle.RetryAfter(now))
+	assert.False(t, throttle.IsExpired(now.Add(30*time.Minute)))
+
+	later := now.Add(time.Hour)
+	assert.False(t, throttle.IsLocked(later))
+	assert.Equal(t, time.Duration(0), throttle.RetryAfter(later))
+	assert.True(t, throttle.IsExpired(later))
+}
+
+func TestLoginThrottle_ForgetsOldFailures(t *testing.T) {
+	throttle := entities.NewLoginThrottle("ip:127.0.0.1")
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		throttle.RecordFailure(testLoginThrottlePolicy, now)
+	}
+	assert.Equal(t, 3, throttle.Failures)
+
+	later := now.Add(testLoginThrottlePolicy.FailureWindow + time.Second)
+	throttle.RecordFailure(testLoginThrottlePolicy, later)
+	assert.Equal(t, 1, throttle.Failures)
+	assert.Equal(t, time.Duration(0), throttle.RetryAfter(later))
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestPKCEChallenge(t *testing.T) {
+	// Example from RFC 7636 appendix B
+	assert.Equal(t,
+		"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		entities.PKCEChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"),
+	)
+}
+
+func TestNewOIDCLoginRequest(t *testing.T) {
+	request, err := entities.NewOIDCLoginRequest("corp", 10*time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "corp", request.Provider)
+	assert.Len(t, request.CodeVerifier, 43)
+	assert.NotEqual(t, request.State, request.Nonce)
+	assert.Equal(t, entities.PKCEChallenge(request.CodeVerifier), request.CodeChallenge())
+
+	assert.False(t, request.IsExpired(time.Now()))
+	assert.True(t, request.IsExpired(request.ExpiresAt))
+
+	other, _ := entities.NewOIDCLoginRequest("corp", 10*time.Minute)
+	assert.NotEqual(t, request.State, other.State)
+	assert.NotEqual(t, request.CodeVerifier, other.CodeVerifier)
+}
+
+func TestExternalIdentity_RecordLogin(t *testing.T) {
+	userID := uuid.New()
+	claims := &entities.IdentityClaims{Issuer: "https://idp.example.com", Subject: "42", Email: "old@example.com"}
+
+	identity := entities.NewExternalIdentity(userID, "corp", claims)
+	assert.Equal(t, userID, identity.UserID)
+	assert.Equal(t, "42", identity.Subject)
+
+	lastLogin := identity.LastLoginAt
+	time.Sleep(time.Millisecond)
+	identity.RecordLogin(&entities.IdentityClaims{Issuer: claims.Issuer, Subject: "42", Email: "new@example.com"})
+
+	assert.Equal(t, "new@example.com", identity.Email)
+	assert.True(t, identity.LastLoginAt.After(lastLogin))
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestNewOrganization(t *testing.T) {
+	creator := uuid.New()
+
+	organization, err := entities.NewOrganization("  Acme  ", creator)
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme", organization.Name)
+	assert.Equal(t, creator, organization.CreatedBy)
+
+	_, err = entities.NewOrganization(" ", creator)
+	assert.Equal(t, entities.ErrInvalidOrganizationName, err)
+
+	_, err = entities.NewOrganization(strings.Repeat("a", 101), creator)
+	assert.Equal(t, entities.ErrInvalidOrganizationName, err)
+}
+
+func TestOrganizationMember_Roles(t *testing.T) {
+	_, err := entities.NewOrganizationMember(uuid.New(), uuid.New(), "guest")
+	assert.Equal(t, entities.ErrInvalidOrganizationRole, err)
+
+	member, err := entities.NewOrganizationMember(uuid.New(), uuid.New(), entities.OrganizationRoleMember)
+	assert.NoError(t, err)
+	assert.False(t, member.CanManageMembers())
+	assert.False(t, member.IsOwner())
+
+	assert.NoError(t, member.ChangeRole(entities.OrganizationRoleAdmin))
+	assert.True(t, member.CanManageMembers())
+	assert.False(t, member.IsOwner())
+
+	assert.NoError(t, member.ChangeRole(entities.OrganizationRoleOwner))
+	assert.True(t, member.CanManageMembers())
+	assert.True(t, member.IsOwner())
+
+	assert.Equal(t, entities.ErrInvalidOrganizationRole, member.ChangeRole(""))
+}
+
+func TestNewOrganizationInvitation(t *testing.T) {
+	invitation, secret, err := entities.NewOrganizationInvitation(uuid.New(), " New.Hire@Example.com ", entities.OrganizationRoleMember, uuid.New(), time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new.hire@example.com", invitation.Email)
+	assert.Equal(t, entities.HashUserToken(secret), invitation.TokenHash)
+	assert.True(t, invitation.IsFor("NEW.HIRE@example.com"))
+	assert.False(t, invitation.IsFor("other@example.com"))
+	assert.True(t, invitation.IsPending(time.Now()))
+
+	_, _, err = entities.NewOrganizationInvitation(uuid.New(), "not-an-email", entities.OrganizationRoleMember, uuid.New(), time.Hour)
+	assert.Equal(t, entities.ErrInvalidEmail, err)
+
+	_, _, err = entities.NewOrganizationInvitation(uuid.New(), "a@example.com", "guest", uuid.New(), time.Hour)
+	assert.Equal(t, entities.ErrInvalidOrganizationRole, err)
+}
+
+func TestOrganizationInvitation_Accept(t *testing.T) {
+	invitation, _, _ := entities.NewOrganizationInvitation(uuid.New(), "a@example.com", entities.OrganizationRoleMember, uuid.New(), time.Hour)
+
+	assert.Equal(t, entities.ErrInvitationExpired, invitation.Accept(time.Now().Add(2*time.Hour)))
+	assert.NoError(t, invitation.Accept(time.Now()))
+	assert.False(t, invitation.IsPending(time.Now()))
+	assert.Equal(t, entities.ErrInvitationAccepted, invitation.Accept(time.Now()))
+}
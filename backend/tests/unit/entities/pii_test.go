@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestDetectPII(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"Email", "Write to jane.doe@example.com today", "Write to [EMAIL] today"},
+		{"Phone", "Call +1 (555) 123-4567 now", "Call [PHONE] now"},
+		{"Card number", "Card 4111 1111 1111 1111 expires soon", "Card [CARD_NUMBER] expires soon"},
+		{"Invalid card number", "Order 4111 1111 1111 1112 shipped", "Order 4111 1111 1111 1112 shipped"},
+		{"IBAN", "Pay DE89 3704 0044 0532 0130 00 please", "Pay [IBAN] please"},
+		{"Invalid IBAN", "Code DE00 ABCD EFGH IJKL MN", "Code DE00 ABCD EFGH IJKL MN"},
+		{"Short numbers", "Meet at 10 on floor 3, room 12345", "Meet at 10 on floor 3, room 12345"},
+		{"Card number followed by expiry", "4111 1111 1111 1111 12 26", "[CARD_NUMBER] 12 26"},
+		{"IBAN followed by a word", "DE89 3704 0044 0532 0130 00 OK", "[IBAN] OK"},
+		{"Compact IBAN", "IBAN DE89370400440532013000.", "IBAN [IBAN]."},
+		{"Plain large number", "we have 1500000 users", "we have 1500000 users"},
+		{"Phone in groups", "Call 555 123 4567", "Call [PHONE]"},
+		{"Phone with area code", "Call (030) 1234567", "Call [PHONE]"},
+		{"International phone", "Call +491701234567", "Call [PHONE]"},
+		{"Date", "Due 2024-01-15 or 15.01.2024", "Due 2024-01-15 or 15.01.2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := entities.DetectPII(tt.text, entities.AllPIIKinds)
+			assert.Equal(t, tt.want, entities.RedactPII(tt.text, findings))
+		})
+	}
+}
+
+func TestDetectPII_Kinds(t *testing.T) {
+	text := "Mail jane@example.com or call 555 123 4567"
+
+	findings := entities.DetectPII(text, []entities.PIIKind{entities.PIIEmail})
+	assert.Len(t, findings, 1)
+	assert.Equal(t, entities.PIIEmail, findings[0].Kind)
+	assert.Equal(t, "jane@example.com", text[findings[0].Start:findings[0].End])
+
+	assert.Empty(t, entities.DetectPII(text, nil))
+}
+
+func TestParsePIIKinds(t *testing.T) {
+	kinds, err := entities.ParsePIIKinds(" Email, iban ,")
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.PIIKind{entities.PIIEmail, entities.PIIIBAN}, kinds)
+
+	kinds, err = entities.ParsePIIKinds("")
+	assert.NoError(t, err)
+	assert.Empty(t, kinds)
+
+	_, err = entities.ParsePIIKinds("email,name")
+	assert.Equal(t, entities.ErrInvalidPIIKind, err)
+}
+
+func TestTranscription_Redact(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+	trans.Redact(entities.AllPIIKinds)
+	assert.Nil(t, trans.Redaction)
+
+	_ = trans.Complete("Mail jane@example.com", 2)
+	trans.Redact(entities.AllPIIKinds)
+
+	// Without the policy the original text is kept next to the redaction
+	assert.Equal(t, "Mail jane@example.com", trans.Text)
+	assert.Equal(t, "Mail [EMAIL]", trans.Redaction.Text)
+	assert.Equal(t, map[entities.PIIKind]int{entities.PIIEmail: 1}, trans.Redaction.PIICounts())
+}
+
+func TestTranscription_RequireRedaction(t *testing.T) {
+	trans := diarizedTranscription()
+	_ = trans.EditText("Hello there, jane@example.com. Hi. Bye now.", trans.UserID)
+	trans.Summary = &entities.Summary{Text: "Greetings"}
+
+	assert.True(t, trans.RequireRedaction(entities.AllPIIKinds))
+	assert.False(t, trans.RequireRedaction(entities.AllPIIKinds))
+	assert.Equal(t, "Hello there, [EMAIL]. Hi. Bye now.", trans.Text)
+	assert.Nil(t, trans.Summary)
+
+	// Pending events carry the redacted text only
+	for _, event := range trans.PullEvents() {
+		if edited, ok := event.(entities.TranscriptionEdited); ok {
+			assert.Equal(t, trans.Text, edited.Text)
+		}
+	}
+
+	// Edits of the redacted text are redacted again
+	assert.NoError(t, trans.EditText("Reach jane@example.com or 555 123 4567", trans.UserID))
+	trans.Redact(entities.AllPIIKinds)
+	assert.Equal(t, "Reach [EMAIL] or [PHONE]", trans.Text)
+
+	assert.True(t, trans.ReleaseRedaction())
+	assert.False(t, trans.ReleaseRedaction())
+	assert.Equal(t, "Reach jane@example.com or 555 123 4567", trans.Text)
+	assert.Equal(t, "Hello there.", trans.Segments[0].Text)
+}
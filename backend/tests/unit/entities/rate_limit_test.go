@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestParseRateLimitPolicy(t *testing.T) {
+	policy, err := entities.ParseRateLimitPolicy("auth", "10/1m")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.RateLimitPolicy{Name: "auth", Limit: 10, Window: time.Minute}, policy)
+
+	for _, spec := range []string{"", "10", "0/1m", "ten/1m", "10/forever", "10/-1s"} {
+		_, err := entities.ParseRateLimitPolicy("auth", spec)
+		assert.Equal(t, entities.ErrInvalidRateLimitPolicy, err, spec)
+	}
+}
+
+func TestTokenBucket_Take(t *testing.T) {
+	policy := entities.RateLimitPolicy{Name: "test", Limit: 2, Window: 10 * time.Second}
+	now := time.Now()
+	bucket := entities.NewTokenBucket(policy, now)
+
+	first := bucket.Take(policy, now)
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 1, first.Remaining)
+	assert.Equal(t, 5*time.Second, first.ResetAfter)
+
+	second := bucket.Take(policy, now)
+	assert.True(t, second.Allowed)
+	assert.Equal(t, 0, second.Remaining)
+
+	denied := bucket.Take(policy, now)
+	assert.False(t, denied.Allowed)
+	assert.Equal(t, 5*time.Second, denied.RetryAfter)
+	assert.Equal(t, 10*time.Second, denied.ResetAfter)
+
+	// One token refills every five seconds
+	refilled := bucket.Take(policy, now.Add(5*time.Second))
+	assert.True(t, refilled.Allowed)
+	assert.Equal(t, 0, refilled.Remaining)
+}
+
+func TestTokenBucket_IsFull(t *testing.T) {
+	policy := entities.RateLimitPolicy{Name: "test", Limit: 2, Window: 10 * time.Second}
+	now := time.Now()
+	bucket := entities.NewTokenBucket(policy, now)
+
+	assert.True(t, bucket.IsFull(policy, now))
+
+	bucket.Take(policy, now)
+	assert.False(t, bucket.IsFull(policy, now))
+	assert.True(t, bucket.IsFull(policy, now.Add(5*time.Second)))
+}
@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func diarizedTranscription() *entities.Transcription {
+	trans := NewTranscription(uuid.New())
+	_ = trans.Complete("Hello there. Hi. Bye now.", 6)
+	_ = trans.SetSegments([]entities.Segment{
+		{Start: 0, End: 2, Speaker: "SPEAKER_00", Text: "Hello there."},
+		{Start: 2, End: 3, Speaker: "SPEAKER_01", Text: "Hi."},
+		{Start: 3, End: 4, Speaker: "SPEAKER_00", Text: "Bye"},
+		{Start: 4, End: 6, Speaker: "SPEAKER_00", Text: " now. "},
+	})
+	return trans
+}
+
+func TestTranscription_SetSegments(t *testing.T) {
+	trans := diarizedTranscription()
+	assert.Len(t, trans.Segments, 4)
+	assert.Equal(t, "now.", trans.Segments[3].Text)
+	assert.Equal(t, []string{"SPEAKER_00", "SPEAKER_01"}, trans.Speakers())
+
+	for _, segments := range [][]entities.Segment{
+		{{Start: 0, End: 7, Speaker: "A", Text: "past the end"}},
+		{{Start: 2, End: 1, Speaker: "A", Text: "backwards"}},
+		{{Start: 0, End: 1, Speaker: " ", Text: "no speaker"}},
+		{{Start: 0, End: 1, Speaker: "A", Text: ""}},
+		{{Start: 2, End: 3, Speaker: "A", Text: "b"}, {Start: 1, End: 2, Speaker: "B", Text: "a"}},
+	} {
+		assert.Equal(t, entities.ErrInvalidSegments, trans.SetSegments(segments))
+	}
+	assert.Len(t, trans.Segments, 4)
+}
+
+func TestTranscription_EditTextStalesSegments(t *testing.T) {
+	trans := diarizedTranscription()
+	assert.Len(t, trans.SpeakerSegments(), 4)
+
+	assert.NoError(t, trans.EditText("Hello there. Bye now.", trans.UserID))
+	assert.True(t, trans.SegmentsStale)
+	assert.Nil(t, trans.SpeakerSegments())
+	assert.Len(t, trans.Segments, 4)
+
+	// Stale segments cannot be translated
+	_, err := entities.NewTranslation(trans, trans.UserID, "de", 1, "Hallo", []string{"Hallo"})
+	assert.Equal(t, entities.ErrInvalidSegments, err)
+	_, err = entities.NewTranslation(trans, trans.UserID, "de", 1, "Hallo", nil)
+	assert.NoError(t, err)
+
+	// A new diarization matches the text again
+	assert.NoError(t, trans.SetSegments([]entities.Segment{{Start: 0, End: 6, Speaker: "SPEAKER_00", Text: "Hello there. Bye now."}}))
+	assert.False(t, trans.SegmentsStale)
+	assert.Len(t, trans.SpeakerSegments(), 1)
+
+	plain := NewTranscription(uuid.New())
+	_ = plain.Complete("Hello", 1)
+	assert.NoError(t, plain.EditText("Hello!", plain.UserID))
+	assert.False(t, plain.SegmentsStale)
+}
+
+func TestTranscription_RenameSpeaker(t *testing.T) {
+	trans := diarizedTranscription()
+
+	assert.NoError(t, trans.RenameSpeaker("SPEAKER_00", " Alice "))
+	assert.Equal(t, "Alice", trans.SpeakerName("SPEAKER_00"))
+	assert.Equal(t, "SPEAKER_01", trans.SpeakerName("SPEAKER_01"))
+
+	assert.Equal(t, entities.ErrSpeakerNotFound, trans.RenameSpeaker("SPEAKER_02", "Carol"))
+	assert.Equal(t, entities.ErrInvalidSpeakerName, trans.RenameSpeaker("SPEAKER_01", strings.Repeat("b", 101)))
+
+	assert.NoError(t, trans.RenameSpeaker("SPEAKER_00", ""))
+	assert.Equal(t, "SPEAKER_00", trans.SpeakerName("SPEAKER_00"))
+
+	// Names of speakers that disappear are dropped
+	_ = trans.RenameSpeaker("SPEAKER_01", "Bob")
+	_ = trans.SetSegments([]entities.Segment{{Start: 0, End: 6, Speaker: "SPEAKER_00", Text: "Hello"}})
+	assert.Empty(t, trans.SpeakerNames)
+}
+
+func TestTranscription_SpeakerTranscript(t *testing.T) {
+	trans := diarizedTranscription()
+	_ = trans.RenameSpeaker("SPEAKER_01", "Bob")
+
+	assert.Equal(t, "SPEAKER_00: Hello there.\nBob: Hi.\nSPEAKER_00: Bye now.", trans.SpeakerTranscript(trans.Segments))
+	assert.Empty(t, trans.SpeakerTranscript(nil))
+}
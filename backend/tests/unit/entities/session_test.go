@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestNewSession(t *testing.T) {
+	userID := uuid.New()
+
+	session := entities.NewSession(userID, "  Work laptop ", strings.Repeat("a", 600), "203.0.113.7", time.Hour)
+
+	assert.Equal(t, userID, session.UserID)
+	assert.Equal(t, "Work laptop", session.DeviceName)
+	assert.Len(t, session.UserAgent, 512)
+	assert.Equal(t, "203.0.113.7", session.IP)
+	assert.Equal(t, session.CreatedAt, session.LastSeenAt)
+	assert.True(t, session.BelongsToUser(userID))
+	assert.True(t, session.IsActive(time.Now()))
+	assert.False(t, session.IsActive(time.Now().Add(2*time.Hour)))
+}
+
+func TestSession_Revoke(t *testing.T) {
+	session := entities.NewSession(uuid.New(), "", "", "", time.Hour)
+
+	session.Revoke()
+	revokedAt := session.RevokedAt
+	session.Revoke()
+
+	assert.NotNil(t, revokedAt)
+	assert.Same(t, revokedAt, session.RevokedAt)
+	assert.False(t, session.IsActive(time.Now()))
+}
+
+func TestSession_Touch(t *testing.T) {
+	session := entities.NewSession(uuid.New(), "", "", "203.0.113.7", time.Hour)
+	now := session.LastSeenAt
+
+	assert.False(t, session.Touch(now.Add(30*time.Second), "203.0.113.7", time.Minute))
+	assert.Equal(t, now, session.LastSeenAt)
+
+	assert.True(t, session.Touch(now.Add(30*time.Second), "198.51.100.1", time.Minute))
+	assert.Equal(t, "198.51.100.1", session.IP)
+
+	assert.True(t, session.Touch(now.Add(2*time.Minute), "198.51.100.1", time.Minute))
+	assert.Equal(t, now.Add(2*time.Minute), session.LastSeenAt)
+}
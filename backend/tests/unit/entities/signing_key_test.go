@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestNewSigningKey(t *testing.T) {
+	edKey, err := entities.NewSigningKey(entities.SigningAlgorithmEdDSA)
+	assert.NoError(t, err)
+	assert.IsType(t, ed25519.PublicKey{}, edKey.PublicKey())
+	assert.NotEmpty(t, edKey.ID)
+	assert.True(t, edKey.IsActive())
+
+	rsaKey, err := entities.NewSigningKey(entities.SigningAlgorithmRS256)
+	assert.NoError(t, err)
+	assert.IsType(t, &rsa.PublicKey{}, rsaKey.PublicKey())
+	assert.NotEqual(t, edKey.ID, rsaKey.ID)
+
+	_, err = entities.NewSigningKey("HS256")
+	assert.Equal(t, entities.ErrUnsupportedSigningAlgorithm, err)
+}
+
+func TestSigningKey_Retire(t *testing.T) {
+	key, _ := entities.NewSigningKey(entities.SigningAlgorithmEdDSA)
+	assert.False(t, key.IsExpired(time.Now().Add(365*24*time.Hour)))
+
+	key.Retire(time.Hour)
+	assert.False(t, key.IsActive())
+	assert.False(t, key.IsExpired(time.Now()))
+	assert.True(t, key.IsExpired(time.Now().Add(2*time.Hour)))
+
+	expiresAt := *key.ExpiresAt
+	key.Retire(time.Minute)
+	assert.Equal(t, expiresAt, *key.ExpiresAt)
+}
+
+func TestSigningKey_IsDue(t *testing.T) {
+	key, _ := entities.NewSigningKey(entities.SigningAlgorithmEdDSA)
+
+	assert.False(t, key.IsDue(time.Now(), time.Hour))
+	assert.True(t, key.IsDue(time.Now().Add(2*time.Hour), time.Hour))
+
+	key.Retire(time.Hour)
+	assert.False(t, key.IsDue(time.Now().Add(2*time.Hour), time.Hour))
+}
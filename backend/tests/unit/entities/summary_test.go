@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestTranscription_Summarize(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+
+	summary := &entities.Summary{
+		Text:        " Launch plan reviewed. ",
+		KeyPoints:   []string{"Budget approved", " "},
+		ActionItems: nil,
+		Model:       "test",
+	}
+	assert.Equal(t, entities.ErrTranscriptionNotCompleted, trans.Summarize(summary))
+
+	_ = trans.Complete("We reviewed the launch plan.", 3)
+	assert.Equal(t, entities.ErrEmptySummary, trans.Summarize(&entities.Summary{Text: " "}))
+	assert.Nil(t, trans.Summary)
+
+	assert.NoError(t, trans.Summarize(summary))
+	assert.Equal(t, "Launch plan reviewed.", trans.Summary.Text)
+	assert.Equal(t, []string{"Budget approved"}, trans.Summary.KeyPoints)
+	assert.Empty(t, trans.Summary.ActionItems)
+	assert.Equal(t, "test", trans.Summary.Model)
+	assert.True(t, trans.HasCurrentSummary())
+}
+
+func TestTranscription_SummaryStaleAfterEdit(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+	_ = trans.Complete("Hello world", 3)
+	assert.False(t, trans.HasCurrentSummary())
+
+	_ = trans.Summarize(&entities.Summary{Text: "A greeting."})
+	assert.True(t, trans.HasCurrentSummary())
+
+	_ = trans.EditText("Goodbye world", uuid.New())
+	assert.NotNil(t, trans.Summary)
+	assert.False(t, trans.HasCurrentSummary())
+	assert.True(t, trans.Summary.IsCurrent("Hello world"))
+}
@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"  hello   world ", "Hello world."},
+		{"it works . does it ? yes", "It works. Does it? Yes."},
+		{"i think i'm done, i said", "I think I'm done, I said."},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, entities.NormalizeText(tt.text), tt.text)
+	}
+}
+
+func TestRemoveFillers(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Um, so we, uh, ship it", "so we, ship it"},
+		{"We are done, umm.", "We are done."},
+		{"Hmm Mm the summer is here", "the summer is here"},
+		{"An error occurred", "An error occurred"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, entities.RemoveFillers(tt.text), tt.text)
+	}
+}
+
+func TestFormatNumbers(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"twenty five people", "25 people"},
+		{"Three hundred and twelve pages", "312 pages"},
+		{"two thousand and five", "2005"},
+		{"one hundred twenty-three thousand four hundred fifty six", "123456"},
+		{"fifteen hundred", "1500"},
+		{"eleven cats", "11 cats"},
+		{"one of us and three more", "one of us and three more"},
+		{"one two three", "one two three"},
+		{"a hundred times", "a hundred times"},
+		{"someone often", "someone often"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, entities.FormatNumbers(tt.text), tt.text)
+	}
+}
+
+func TestMaskProfanity(t *testing.T) {
+	assert.Equal(t, "Oh s***, the d*** thing", entities.MaskProfanity("Oh shit, the damn thing"))
+	assert.Equal(t, "Scrap the class", entities.MaskProfanity("Scrap the class"))
+}
+
+func TestTextProcessingSettings_Update(t *testing.T) {
+	settings := entities.NewTextProcessingSettings(uuid.New(), nil)
+
+	err := settings.Update(
+		[]entities.TextStage{entities.TextStageNormalize, entities.TextStageReplacements},
+		[]entities.TextReplacement{{Find: " voice line ", Replace: "Voiceline"}, {Find: "c++", Replace: "C++"}},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "voice line", settings.Replacements[0].Find)
+	assert.Equal(t, "Voiceline rocks, not voice lines. C++!", settings.Replace("Voice Line rocks, not voice lines. c++!"))
+
+	for _, update := range []struct {
+		stages       []entities.TextStage
+		replacements []entities.TextReplacement
+		err          error
+	}{
+		{[]entities.TextStage{"spelling"}, nil, entities.ErrInvalidTextStage},
+		{[]entities.TextStage{entities.TextStageFillers, entities.TextStageFillers}, nil, entities.ErrDuplicateTextStage},
+		{nil, []entities.TextReplacement{{Find: " ", Replace: "x"}}, entities.ErrInvalidTextReplacement},
+		{nil, []entities.TextReplacement{{Find: "x", Replace: strings.Repeat("y", 101)}}, entities.ErrInvalidTextReplacement},
+		{nil, make([]entities.TextReplacement, 101), entities.ErrTooManyTextReplacements},
+	} {
+		assert.Equal(t, update.err, settings.Update(update.stages, update.replacements))
+	}
+	assert.Len(t, settings.Stages, 2)
+	assert.Len(t, settings.Replacements, 2)
+}
+
+func TestParseTextStages(t *testing.T) {
+	stages, err := entities.ParseTextStages(" Fillers, normalize ,")
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.TextStage{entities.TextStageFillers, entities.TextStageNormalize}, stages)
+
+	stages, err = entities.ParseTextStages("")
+	assert.NoError(t, err)
+	assert.Empty(t, stages)
+
+	_, err = entities.ParseTextStages("normalize,translate")
+	assert.Equal(t, entities.ErrInvalidTextStage, err)
+}
+
+func TestTranscription_ProcessText(t *testing.T) {
+	trans := diarizedTranscription()
+	trans.ProcessText(strings.ToUpper)
+
+	assert.Equal(t, "HELLO THERE. HI. BYE NOW.", trans.Text)
+	assert.Equal(t, "Hello there. Hi. Bye now.", trans.RawText)
+	assert.Equal(t, "HELLO THERE.", trans.Segments[0].Text)
+
+	// The completion event carries the processed text
+	for _, event := range trans.PullEvents() {
+		if completed, ok := event.(entities.TranscriptionCompleted); ok {
+			assert.Equal(t, trans.Text, completed.Text)
+		}
+	}
+
+	// Processing always starts from the raw text and never empties it
+	trans.ProcessText(func(text string) string { return " " })
+	assert.Equal(t, "HELLO THERE. HI. BYE NOW.", trans.Text)
+	trans.ProcessText(strings.ToLower)
+	assert.Equal(t, "hello there. hi. bye now.", trans.Text)
+	assert.Equal(t, "hello there.", trans.Segments[0].Text)
+	assert.Equal(t, "Hello there.", trans.RawSegments[0].Text)
+
+	// A reused duplicate is processed once, not on top of the source's result
+	reused := NewTranscription(uuid.New())
+	_ = reused.CompleteFromDuplicate(trans)
+	reused.ProcessText(func(text string) string { return text + "!" })
+	assert.Equal(t, "Hello there. Hi. Bye now.!", reused.Text)
+	assert.Equal(t, "Hello there.!", reused.Segments[0].Text)
+	reused.ProcessText(func(text string) string { return text + "!" })
+	assert.Equal(t, "Hello there.!", reused.Segments[0].Text)
+
+	pending := NewTranscription(uuid.New())
+	pending.ProcessText(strings.ToUpper)
+	assert.Empty(t, pending.Text)
+}
@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+// rfc6238Secret is the SHA-1 test key of RFC 6238 appendix B in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTP(t *testing.T) {
+	// The six low digits of the RFC 6238 SHA-1 test vectors
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range vectors {
+		code, err := entities.GenerateTOTP(rfc6238Secret, time.Unix(unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "at %d", unix)
+	}
+}
+
+func TestTOTPFactor_VerifyCode(t *testing.T) {
+	factor := &entities.TOTPFactor{UserID: uuid.New(), Secret: rfc6238Secret}
+	now := time.Unix(1111111109, 0)
+
+	assert.False(t, factor.VerifyCode("000000", now))
+
+	// Codes of the neighbouring steps are accepted for clock drift
+	previous, _ := entities.GenerateTOTP(rfc6238Secret, now.Add(-30*time.Second))
+	assert.True(t, factor.VerifyCode(previous, now))
+
+	// but each step only once, and never an older one
+	assert.False(t, factor.VerifyCode(previous, now))
+
+	current, _ := entities.GenerateTOTP(rfc6238Secret, now)
+	assert.True(t, factor.VerifyCode(current, now))
+	assert.False(t, factor.VerifyCode(previous, now))
+
+	tooOld, _ := entities.GenerateTOTP(rfc6238Secret, now.Add(-2*time.Minute))
+	assert.False(t, factor.VerifyCode(tooOld, now.Add(time.Minute)))
+}
+
+func TestTOTPFactor_Confirm(t *testing.T) {
+	factor, err := entities.NewTOTPFactor(uuid.New())
+	assert.NoError(t, err)
+	assert.Len(t, factor.Secret, 32)
+	assert.False(t, factor.IsConfirmed())
+
+	now := time.Now()
+	assert.Equal(t, entities.ErrInvalidMFACode, factor.Confirm("000000", now))
+	assert.False(t, factor.IsConfirmed())
+
+	code, _ := entities.GenerateTOTP(factor.Secret, now)
+	assert.NoError(t, factor.Confirm(code, now))
+	assert.True(t, factor.IsConfirmed())
+}
+
+func TestTOTPFactor_ProvisioningURI(t *testing.T) {
+	factor := &entities.TOTPFactor{Secret: rfc6238Secret}
+
+	uri := factor.ProvisioningURI("Voiceline", "user@example.com")
+
+	assert.Equal(t, "otpauth://totp/Voiceline:user@example.com?algorithm=SHA1&digits=6&issuer=Voiceline&period=30&secret="+rfc6238Secret, uri)
+}
+
+func TestTOTPFactor_RecoveryCodes(t *testing.T) {
+	factor, _ := entities.NewTOTPFactor(uuid.New())
+
+	codes, err := factor.GenerateRecoveryCodes()
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+	assert.Len(t, factor.RecoveryCodeHashes, 10)
+	assert.Regexp(t, `^[0-9a-f]{5}-[0-9a-f]{5}$`, codes[0])
+	assert.NotContains(t, factor.RecoveryCodeHashes, codes[0])
+
+	assert.False(t, factor.UseRecoveryCode("00000-00000"))
+	assert.True(t, factor.UseRecoveryCode(" "+codes[3]+" "))
+	assert.False(t, factor.UseRecoveryCode(codes[3]))
+	assert.Len(t, factor.RecoveryCodeHashes, 9)
+
+	// Regenerating invalidates the previous codes
+	factor.GenerateRecoveryCodes()
+	assert.False(t, factor.UseRecoveryCode(codes[0]))
+}
@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestTranscription_SetTags(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+
+	assert.NoError(t, trans.SetTags([]string{" Team ", "weekly", "team", "Apollo"}))
+	assert.Equal(t, []string{"apollo", "team", "weekly"}, trans.Tags)
+	assert.True(t, trans.HasTag("team"))
+
+	assert.Equal(t, entities.ErrInvalidTag, trans.SetTags([]string{"ok", " "}))
+	assert.Equal(t, entities.ErrInvalidTag, trans.SetTags([]string{"a,b"}))
+	assert.Equal(t, entities.ErrInvalidTag, trans.SetTags([]string{strings.Repeat("t", 51)}))
+
+	many := make([]string, 21)
+	for i := range many {
+		many[i] = strings.Repeat("t", i+1)
+	}
+	assert.Equal(t, entities.ErrTooManyTags, trans.SetTags(many))
+	assert.Equal(t, []string{"apollo", "team", "weekly"}, trans.Tags)
+
+	assert.NoError(t, trans.SetTags(nil))
+	assert.Empty(t, trans.Tags)
+}
+
+func TestTranscription_ReplaceTags(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+	_ = trans.SetTags([]string{"meeting", "meetings", "todo"})
+
+	changed, err := trans.ReplaceTags([]string{"draft"}, "final")
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = trans.ReplaceTags([]string{"todo"}, "tasks")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []string{"meeting", "meetings", "tasks"}, trans.Tags)
+
+	// Merging into an existing tag leaves a single copy
+	changed, err = trans.ReplaceTags([]string{"meetings"}, "meeting")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []string{"meeting", "tasks"}, trans.Tags)
+}
+
+func TestTranscription_SetFolder(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+
+	assert.NoError(t, trans.SetFolder(" /meetings/2024/ "))
+	assert.Equal(t, "meetings/2024", trans.Folder)
+	assert.True(t, trans.InFolder("meetings"))
+	assert.True(t, trans.InFolder("meetings/2024"))
+	assert.False(t, trans.InFolder("meet"))
+
+	assert.Equal(t, entities.ErrInvalidFolder, trans.SetFolder("meetings//2024"))
+	assert.Equal(t, entities.ErrInvalidFolder, trans.SetFolder(strings.Repeat("f", 201)))
+
+	assert.NoError(t, trans.SetFolder(""))
+	assert.Empty(t, trans.Folder)
+}
+
+func TestTranscription_SetTitleAndMetadata(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+
+	assert.NoError(t, trans.SetTitle("  Weekly sync "))
+	assert.Equal(t, "Weekly sync", trans.Title)
+	assert.Equal(t, entities.ErrInvalidTitle, trans.SetTitle(strings.Repeat("t", 201)))
+
+	assert.NoError(t, trans.SetMetadata(map[string]string{" project ": "apollo"}))
+	assert.Equal(t, map[string]string{"project": "apollo"}, trans.Metadata)
+
+	assert.Equal(t, entities.ErrInvalidMetadata, trans.SetMetadata(map[string]string{"": "empty key"}))
+	assert.Equal(t, entities.ErrInvalidMetadata, trans.SetMetadata(map[string]string{"k": strings.Repeat("v", 1025)}))
+	assert.Equal(t, map[string]string{"project": "apollo"}, trans.Metadata)
+}
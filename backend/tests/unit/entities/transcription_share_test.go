@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestNewTranscriptionShare(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+	recipient := uuid.New()
+
+	_, err := entities.NewTranscriptionShare(trans, "recipient@example.com", "admin")
+	assert.Equal(t, entities.ErrInvalidSharePermission, err)
+
+	_, err = entities.NewTranscriptionShare(trans, "not-an-email", entities.SharePermissionRead)
+	assert.Equal(t, entities.ErrInvalidEmail, err)
+
+	share, err := entities.NewTranscriptionShare(trans, " Recipient@Example.com ", entities.SharePermissionRead)
+	assert.NoError(t, err)
+	assert.Equal(t, trans.ID, share.TranscriptionID)
+	assert.Equal(t, trans.UserID, share.OwnerID)
+	assert.Equal(t, "recipient@example.com", share.Email)
+	assert.True(t, share.IsFor("RECIPIENT@example.com"))
+	assert.True(t, share.IsPending())
+	assert.False(t, share.CanEdit())
+
+	assert.Equal(t, entities.ErrShareWithOwner, share.Claim(trans.UserID))
+	assert.True(t, share.IsPending())
+	assert.NoError(t, share.Claim(recipient))
+	assert.False(t, share.IsPending())
+	assert.Equal(t, recipient, share.UserID)
+
+	assert.NoError(t, share.ChangePermission(entities.SharePermissionEdit))
+	assert.True(t, share.CanEdit())
+	assert.Equal(t, entities.ErrInvalidSharePermission, share.ChangePermission("owner"))
+	assert.True(t, share.CanEdit())
+}
+
+func TestShareLink(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+
+	t.Run("Token is hashed", func(t *testing.T) {
+		link, secret, err := entities.NewShareLink(trans, nil)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, secret)
+		assert.NotEqual(t, secret, link.TokenHash)
+		assert.Equal(t, entities.HashUserToken(secret), link.TokenHash)
+		assert.Equal(t, trans.UserID, link.OwnerID)
+
+		_, other, err := entities.NewShareLink(trans, nil)
+		assert.NoError(t, err)
+		assert.NotEqual(t, secret, other)
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		_, _, err := entities.NewShareLink(trans, &past)
+		assert.Equal(t, entities.ErrInvalidShareLinkExpiry, err)
+
+		expiresAt := time.Now().Add(time.Hour)
+		link, _, err := entities.NewShareLink(trans, &expiresAt)
+		assert.NoError(t, err)
+		assert.True(t, link.IsActive(time.Now()))
+		assert.False(t, link.IsActive(expiresAt))
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		link, _, err := entities.NewShareLink(trans, nil)
+		assert.NoError(t, err)
+		assert.True(t, link.IsActive(time.Now().Add(365*24*time.Hour)))
+
+		link.Revoke()
+		revokedAt := link.RevokedAt
+		assert.NotNil(t, revokedAt)
+		assert.False(t, link.IsActive(time.Now()))
+
+		link.Revoke()
+		assert.Equal(t, revokedAt, link.RevokedAt)
+	})
+}
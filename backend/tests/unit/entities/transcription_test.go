@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -101,6 +102,22 @@ func TestTranscription_StatusChecks(t *testing.T) {
 	})
 }
 
+func TestTranscription_EditText(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+	assert.Equal(t, entities.ErrTranscriptionNotCompleted, trans.EditText("Early", trans.UserID))
+
+	_ = trans.Complete("Helo world", 1)
+	assert.Equal(t, ErrEmptyText, trans.EditText("", trans.UserID))
+	assert.Equal(t, "Helo world", trans.Text)
+
+	assert.NoError(t, trans.EditText("Hello world", trans.UserID))
+	assert.Equal(t, "Hello world", trans.Text)
+	if assert.Len(t, trans.Revisions, 1) {
+		assert.Equal(t, "Helo world", trans.Revisions[0].Text)
+		assert.Equal(t, trans.UserID, trans.Revisions[0].EditedBy)
+	}
+}
+
 func TestTranscription_BelongsToUser(t *testing.T) {
 	userID := uuid.New()
 	otherUserID := uuid.New()
@@ -110,3 +127,47 @@ func TestTranscription_BelongsToUser(t *testing.T) {
 	assert.True(t, transcription.BelongsToUser(userID))
 	assert.False(t, transcription.BelongsToUser(otherUserID))
 }
+
+func TestTranscription_CompleteFromDuplicate(t *testing.T) {
+	userID := uuid.New()
+
+	source := NewTranscription(userID)
+	_ = source.Complete("Original text", 12.5)
+
+	duplicate := NewTranscription(userID)
+	err := duplicate.CompleteFromDuplicate(source)
+
+	assert.NoError(t, err)
+	assert.True(t, duplicate.IsCompleted())
+	assert.True(t, duplicate.IsDuplicate())
+	assert.Equal(t, source.ID, *duplicate.DuplicateOf)
+	assert.Equal(t, "Original text", duplicate.Text)
+	assert.Equal(t, 12.5, duplicate.Duration)
+
+	t.Run("Reuses only the provider's result", func(t *testing.T) {
+		edited := NewTranscription(uuid.New())
+		_ = edited.Complete("Call me at home", 3)
+		_ = edited.SetSegments([]entities.Segment{{Start: 0, End: 3, Speaker: "SPEAKER_00", Text: "Call me at home"}})
+		edited.ProcessText(strings.ToUpper)
+		_ = edited.EditText("Call me at the office", uuid.New())
+
+		reused := NewTranscription(userID)
+		assert.NoError(t, reused.CompleteFromDuplicate(edited))
+		assert.Equal(t, "Call me at home", reused.Text)
+		assert.Equal(t, "Call me at home", reused.RawText)
+		assert.Equal(t, "Call me at home", reused.Segments[0].Text)
+	})
+
+	t.Run("Links to the original of a duplicate", func(t *testing.T) {
+		again := NewTranscription(userID)
+		assert.NoError(t, again.CompleteFromDuplicate(duplicate))
+		assert.Equal(t, source.ID, *again.DuplicateOf)
+	})
+
+	t.Run("Source must be completed", func(t *testing.T) {
+		processing := NewTranscription(userID)
+		trans := NewTranscription(userID)
+		assert.Equal(t, entities.ErrSourceNotCompleted, trans.CompleteFromDuplicate(processing))
+		assert.True(t, trans.IsProcessing())
+	})
+}
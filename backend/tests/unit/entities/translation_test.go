@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestNormalizeLanguage(t *testing.T) {
+	for code, expected := range map[string]string{
+		"de":         "de",
+		" DE ":       "de",
+		"pt-br":      "pt-BR",
+		"pt_BR":      "pt-BR",
+		"zh-hant-tw": "zh-Hant-TW",
+		"es-419":     "es-419",
+	} {
+		normalized, err := entities.NormalizeLanguage(code)
+		assert.NoError(t, err, code)
+		assert.Equal(t, expected, normalized, code)
+	}
+
+	for _, code := range []string{"", "d", "german language", "de-", "1a"} {
+		_, err := entities.NormalizeLanguage(code)
+		assert.Equal(t, entities.ErrInvalidLanguage, err, code)
+	}
+}
+
+func TestNewTranslation(t *testing.T) {
+	trans := NewTranscription(uuid.New())
+	userID := uuid.New()
+
+	_, err := entities.NewTranslation(trans, userID, "de", 1, "Hallo Welt", nil)
+	assert.Equal(t, entities.ErrTranscriptionNotCompleted, err)
+
+	_ = trans.Complete("Hello world", 3)
+	_, err = entities.NewTranslation(trans, userID, "german", 1, "Hallo Welt", nil)
+	assert.Equal(t, entities.ErrInvalidLanguage, err)
+	_, err = entities.NewTranslation(trans, userID, "de", 1, "  ", nil)
+	assert.Equal(t, entities.ErrEmptyTranslation, err)
+
+	translation, err := entities.NewTranslation(trans, userID, "DE", 2, " Hallo Welt ", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, trans.ID, translation.TranscriptionID)
+	assert.Equal(t, trans.UserID, translation.OwnerID)
+	assert.Equal(t, userID, translation.UserID)
+	assert.Equal(t, "de", translation.Language)
+	assert.Equal(t, 2, translation.Version)
+	assert.Equal(t, "Hallo Welt", translation.Text)
+	assert.True(t, translation.IsCurrent("Hello world"))
+
+	_ = trans.EditText("Hello again", uuid.New())
+	assert.False(t, translation.IsCurrent(trans.Text))
+}
+
+func TestNewTranslation_Segments(t *testing.T) {
+	trans := diarizedTranscription()
+
+	_, err := entities.NewTranslation(trans, uuid.New(), "de", 1, "Hallo", []string{"Hallo."})
+	assert.Equal(t, entities.ErrInvalidSegments, err)
+
+	translation, err := entities.NewTranslation(trans, uuid.New(), "de", 1, "Hallo. Hi. Tschüss.", []string{"Hallo.", "Hi.", "Tschüss", "jetzt."})
+	assert.NoError(t, err)
+	if assert.Len(t, translation.Segments, 4) {
+		assert.Equal(t, trans.Segments[1].Speaker, translation.Segments[1].Speaker)
+		assert.Equal(t, trans.Segments[3].End, translation.Segments[3].End)
+		assert.Equal(t, "jetzt.", translation.Segments[3].Text)
+	}
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestNewUsageRecord(t *testing.T) {
+	record := entities.NewUsageRecord(uuid.New(), uuid.New(), 90, "openai/whisper-1", 0.006)
+
+	assert.Equal(t, 90.0, record.AudioSeconds)
+	assert.InDelta(t, 0.009, record.EstimatedCost, 1e-9)
+	assert.Equal(t, "openai/whisper-1", record.Provider)
+	assert.Equal(t, entities.UsageOperationTranscription, record.Operation)
+
+	record = entities.NewOperationUsageRecord(uuid.New(), uuid.New(), entities.UsageOperationSummary, 120, "openai/gpt-4o-mini", 0.001)
+	assert.Equal(t, entities.UsageOperationSummary, record.Operation)
+	assert.Zero(t, record.AudioSeconds)
+	assert.InDelta(t, 0.002, record.EstimatedCost, 1e-9)
+}
+
+func TestBillingPeriod(t *testing.T) {
+	start, end := entities.BillingPeriod(time.Date(2024, time.February, 17, 13, 5, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), end)
+}
+
+func TestUsageQuota(t *testing.T) {
+	quota := entities.NewUsageQuota(uuid.New())
+	periodStart, _ := entities.BillingPeriod(time.Now())
+
+	assert.Nil(t, quota.MonthlyMinutes)
+	assert.Equal(t, periodStart, quota.CountsFrom(periodStart))
+
+	assert.Equal(t, entities.ErrInvalidQuota, quota.SetMonthlyMinutes(-1))
+	assert.NoError(t, quota.SetMonthlyMinutes(30))
+	assert.Equal(t, 30.0, *quota.MonthlyMinutes)
+
+	quota.Reset()
+	assert.Equal(t, *quota.ResetAt, quota.CountsFrom(periodStart))
+
+	// A reset before the period started has no effect
+	nextPeriod := periodStart.AddDate(0, 1, 0)
+	assert.Equal(t, nextPeriod, quota.CountsFrom(nextPeriod))
+}
@@ -120,3 +120,85 @@ func TestUser_UpdatePassword(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, ErrInvalidPassword, err)
 }
+
+func TestUser_ChangeEmail(t *testing.T) {
+	user, _ := NewUser("old@example.com", "password123", "Test User")
+	user.VerifyEmail()
+
+	assert.Equal(t, entities.ErrInvalidEmail, user.ChangeEmail("invalid"))
+	assert.Equal(t, "old@example.com", user.Email)
+
+	assert.NoError(t, user.ChangeEmail("old@example.com"))
+	assert.True(t, user.EmailVerified)
+
+	assert.NoError(t, user.ChangeEmail("new@example.com"))
+	assert.Equal(t, "new@example.com", user.Email)
+	assert.False(t, user.EmailVerified)
+	assert.Nil(t, user.EmailVerifiedAt)
+}
+
+func TestUser_UpdateName(t *testing.T) {
+	user, _ := NewUser("test@example.com", "password123", "Test User")
+
+	assert.Equal(t, entities.ErrInvalidName, user.UpdateName(""))
+	assert.NoError(t, user.UpdateName("Renamed"))
+	assert.Equal(t, "Renamed", user.Name)
+}
+
+func TestUser_Delete(t *testing.T) {
+	user, _ := NewUser("test@example.com", "password123", "Test User")
+	user.PullEvents()
+
+	user.Delete()
+
+	events := user.PullEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, entities.EventUserDeleted, events[0].EventName())
+}
+
+func TestUser_Roles(t *testing.T) {
+	user, _ := NewUser("roles@example.com", "password123", "Roles User")
+
+	assert.Equal(t, entities.RoleUser, user.Role)
+	assert.True(t, user.HasRole(entities.RoleUser))
+	assert.False(t, user.HasRole(entities.RoleAdmin, entities.RoleSupport))
+
+	assert.NoError(t, user.SetRole(entities.RoleSupport))
+	assert.True(t, user.HasRole(entities.RoleAdmin, entities.RoleSupport))
+
+	assert.Equal(t, entities.ErrInvalidRole, user.SetRole("superuser"))
+	assert.Equal(t, entities.RoleSupport, user.Role)
+}
+
+func TestUser_DisableAndEnable(t *testing.T) {
+	user, _ := NewUser("disable@example.com", "password123", "Disabled User")
+
+	user.Disable()
+	assert.True(t, user.Disabled)
+	assert.NotNil(t, user.DisabledAt)
+
+	disabledAt := *user.DisabledAt
+	user.Disable()
+	assert.Equal(t, disabledAt, *user.DisabledAt)
+
+	user.Enable()
+	assert.False(t, user.Disabled)
+	assert.Nil(t, user.DisabledAt)
+}
+
+func TestNewExternalUser(t *testing.T) {
+	user, err := entities.NewExternalUser("sso@example.com", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sso", user.Name)
+	assert.Empty(t, user.PasswordHash)
+	assert.False(t, user.VerifyPassword(""))
+	assert.Equal(t, entities.RoleUser, user.Role)
+
+	events := user.PullEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, entities.EventUserRegistered, events[0].EventName())
+
+	_, err = entities.NewExternalUser("not-an-email", "SSO User")
+	assert.Equal(t, ErrInvalidEmail, err)
+}
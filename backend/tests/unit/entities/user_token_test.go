@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestNewUserToken(t *testing.T) {
+	user, _ := entities.NewUser("token@example.com", "password123", "Token User")
+
+	token, secret, err := entities.NewUserToken(user, entities.TokenPurposePasswordReset, time.Hour)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, secret)
+	assert.Equal(t, user.ID, token.UserID)
+	assert.Equal(t, user.Email, token.Email)
+	assert.Equal(t, entities.HashUserToken(secret), token.TokenHash)
+	assert.NotContains(t, token.TokenHash, secret)
+	assert.False(t, token.IsUsed())
+}
+
+func TestUserToken_Use(t *testing.T) {
+	user, _ := entities.NewUser("token@example.com", "password123", "Token User")
+	token, _, _ := entities.NewUserToken(user, entities.TokenPurposeEmailVerification, time.Hour)
+	now := time.Now()
+
+	assert.NoError(t, token.Use(now))
+	assert.True(t, token.IsUsed())
+	assert.Equal(t, entities.ErrUserTokenUsed, token.Use(now))
+}
+
+func TestUserToken_UseExpired(t *testing.T) {
+	user, _ := entities.NewUser("token@example.com", "password123", "Token User")
+	token, _, _ := entities.NewUserToken(user, entities.TokenPurposeEmailVerification, time.Hour)
+
+	assert.Equal(t, entities.ErrUserTokenExpired, token.Use(time.Now().Add(2*time.Hour)))
+	assert.False(t, token.IsUsed())
+}
+
+func TestUser_VerifyEmail(t *testing.T) {
+	user, _ := entities.NewUser("token@example.com", "password123", "Token User")
+	assert.False(t, user.EmailVerified)
+
+	user.VerifyEmail()
+
+	assert.True(t, user.EmailVerified)
+	assert.NotNil(t, user.EmailVerifiedAt)
+}
@@ -0,0 +1,140 @@
+package entities
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+)
+
+func TestNewWebhook(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		events    []string
+		errorType error
+	}{
+		{
+			name:   "Valid webhook",
+			url:    "https://example.com/hooks",
+			events: []string{entities.WebhookEventTranscriptionCompleted},
+		},
+		{
+			name:      "Relative URL",
+			url:       "/hooks",
+			events:    []string{entities.WebhookEventTranscriptionCompleted},
+			errorType: entities.ErrInvalidWebhookURL,
+		},
+		{
+			name:      "Unsupported scheme",
+			url:       "ftp://example.com/hooks",
+			events:    []string{entities.WebhookEventTranscriptionCompleted},
+			errorType: entities.ErrInvalidWebhookURL,
+		},
+		{
+			name:      "No events",
+			url:       "https://example.com/hooks",
+			events:    nil,
+			errorType: entities.ErrNoWebhookEvents,
+		},
+		{
+			name:      "Unknown event",
+			url:       "https://example.com/hooks",
+			events:    []string{"user.deleted"},
+			errorType: entities.ErrInvalidWebhookEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.New()
+			webhook, err := entities.NewWebhook(userID, tt.url, tt.events)
+
+			if tt.errorType != nil {
+				assert.Equal(t, tt.errorType, err)
+				assert.Nil(t, webhook)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userID, webhook.UserID)
+				assert.True(t, webhook.Active)
+				assert.Contains(t, webhook.Secret, "whsec_")
+			}
+		})
+	}
+}
+
+func TestWebhook_IsSubscribedTo(t *testing.T) {
+	webhook, err := entities.NewWebhook(uuid.New(), "https://example.com/hooks", []string{
+		entities.WebhookEventTranscriptionCompleted,
+		entities.WebhookEventTranscriptionCompleted,
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, webhook.Events, 1)
+	assert.True(t, webhook.IsSubscribedTo(entities.WebhookEventTranscriptionCompleted))
+	assert.False(t, webhook.IsSubscribedTo(entities.WebhookEventTranscriptionFailed))
+
+	webhook.SetActive(false)
+	assert.False(t, webhook.IsSubscribedTo(entities.WebhookEventTranscriptionCompleted))
+}
+
+func TestWebhook_Sign(t *testing.T) {
+	webhook, err := entities.NewWebhook(uuid.New(), "https://example.com/hooks", []string{entities.WebhookEventTranscriptionCompleted})
+	assert.NoError(t, err)
+
+	payload := []byte(`{"type":"transcription.completed"}`)
+	signature := webhook.Sign(1700000000, payload)
+
+	assert.Len(t, signature, 64)
+	assert.Equal(t, signature, webhook.Sign(1700000000, payload))
+	assert.NotEqual(t, signature, webhook.Sign(1700000001, payload))
+	assert.NotEqual(t, signature, webhook.Sign(1700000000, []byte(`{}`)))
+}
+
+func TestWebhookDelivery_Retries(t *testing.T) {
+	webhook, _ := entities.NewWebhook(uuid.New(), "https://example.com/hooks", []string{entities.WebhookEventTranscriptionCompleted})
+	delivery := entities.NewWebhookDelivery(webhook, uuid.New(), entities.WebhookEventTranscriptionCompleted, []byte(`{}`), 2)
+
+	assert.True(t, delivery.IsDue(time.Now()))
+
+	delivery.RecordFailure(500, "unexpected response status 500", time.Minute)
+	assert.True(t, delivery.IsPending())
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.False(t, delivery.IsDue(time.Now()))
+	assert.True(t, delivery.IsDue(time.Now().Add(2*time.Minute)))
+
+	delivery.RecordFailure(0, "connection refused", time.Minute)
+	assert.True(t, delivery.IsFailed())
+	assert.False(t, delivery.IsDue(time.Now().Add(time.Hour)))
+}
+
+func TestWebhookDelivery_Redeliver(t *testing.T) {
+	webhook, _ := entities.NewWebhook(uuid.New(), "https://example.com/hooks", []string{entities.WebhookEventTranscriptionCompleted})
+	delivery := entities.NewWebhookDelivery(webhook, uuid.New(), entities.WebhookEventTranscriptionCompleted, []byte(`{"a":1}`), 3)
+	delivery.RecordSuccess(200)
+
+	redelivery := delivery.Redeliver(webhook)
+
+	assert.NotEqual(t, delivery.ID, redelivery.ID)
+	assert.Equal(t, delivery.EventID, redelivery.EventID)
+	assert.Equal(t, delivery.Payload, redelivery.Payload)
+	assert.Equal(t, delivery.ID, *redelivery.RedeliveryOf)
+	assert.True(t, redelivery.IsPending())
+	assert.Equal(t, 0, redelivery.Attempts)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for _, address := range []string{"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"} {
+		assert.True(t, entities.IsPublicIP(net.ParseIP(address)), address)
+	}
+
+	for _, address := range []string{
+		"127.0.0.1", "::1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+		"0.0.0.0", "100.64.0.1", "fd00::1", "fe80::1", "::ffff:127.0.0.1", "224.0.0.1",
+	} {
+		assert.False(t, entities.IsPublicIP(net.ParseIP(address)), address)
+	}
+}
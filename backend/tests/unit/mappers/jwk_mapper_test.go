@@ -0,0 +1,63 @@
+package mappers
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voiceline/backend/internal/domain/entities"
+	"github.com/voiceline/backend/internal/interface/mappers"
+)
+
+func TestJWKMapper_ToDTO(t *testing.T) {
+	mapper := mappers.NewJWKMapper()
+
+	t.Run("Convert Ed25519 key", func(t *testing.T) {
+		key, _ := entities.NewSigningKey(entities.SigningAlgorithmEdDSA)
+
+		jwk := mapper.ToDTO(key)
+
+		assert.Equal(t, "OKP", jwk.KeyType)
+		assert.Equal(t, "Ed25519", jwk.Curve)
+		assert.Equal(t, "EdDSA", jwk.Algorithm)
+		assert.Equal(t, key.ID, jwk.KeyID)
+		assert.Equal(t, "sig", jwk.Use)
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		assert.NoError(t, err)
+		assert.Equal(t, key.PublicKey(), ed25519.PublicKey(x))
+	})
+
+	t.Run("Convert RSA key", func(t *testing.T) {
+		key, _ := entities.NewSigningKey(entities.SigningAlgorithmRS256)
+		publicKey := key.PublicKey().(*rsa.PublicKey)
+
+		jwk := mapper.ToDTO(key)
+
+		assert.Equal(t, "RSA", jwk.KeyType)
+		assert.Equal(t, "RS256", jwk.Algorithm)
+		assert.Equal(t, "AQAB", jwk.E)
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, publicKey.N.Cmp(new(big.Int).SetBytes(n)))
+	})
+
+	t.Run("Convert nil key", func(t *testing.T) {
+		assert.Nil(t, mapper.ToDTO(nil))
+	})
+}
+
+func TestJWKMapper_ToSetDTO(t *testing.T) {
+	mapper := mappers.NewJWKMapper()
+	first, _ := entities.NewSigningKey(entities.SigningAlgorithmEdDSA)
+	second, _ := entities.NewSigningKey(entities.SigningAlgorithmEdDSA)
+
+	set := mapper.ToSetDTO([]*entities.SigningKey{first, second})
+
+	assert.Len(t, set.Keys, 2)
+	assert.Equal(t, first.ID, set.Keys[0].KeyID)
+}
@@ -35,6 +35,95 @@ func TestTranscriptionMapper_ToDTO(t *testing.T) {
 		assert.Equal(t, transcription.CreatedAt, dto.CreatedAt)
 	})
 
+	t.Run("Convert duplicate transcription", func(t *testing.T) {
+		originalID := uuid.New()
+		transcription := &entities.Transcription{
+			ID:          uuid.New(),
+			UserID:      uuid.New(),
+			Status:      entities.StatusCompleted,
+			DuplicateOf: &originalID,
+		}
+
+		dto := mapper.ToDTO(transcription)
+
+		assert.Equal(t, originalID.String(), dto.DuplicateOf)
+	})
+
+	t.Run("Convert labelled transcription", func(t *testing.T) {
+		transcription := &entities.Transcription{
+			ID:       uuid.New(),
+			UserID:   uuid.New(),
+			Status:   entities.StatusCompleted,
+			Title:    "Weekly sync",
+			Folder:   "meetings/2024",
+			Tags:     []string{"team", "weekly"},
+			Metadata: map[string]string{"project": "apollo"},
+		}
+
+		dto := mapper.ToDTO(transcription)
+
+		assert.Equal(t, "Weekly sync", dto.Title)
+		assert.Equal(t, "meetings/2024", dto.Folder)
+		assert.Equal(t, []string{"team", "weekly"}, dto.Tags)
+		assert.Equal(t, "apollo", dto.Metadata["project"])
+	})
+
+	t.Run("Convert diarized transcription", func(t *testing.T) {
+		transcription := &entities.Transcription{
+			ID:     uuid.New(),
+			UserID: uuid.New(),
+			Status: entities.StatusCompleted,
+			Segments: []entities.Segment{
+				{Start: 0, End: 1.5, Speaker: "SPEAKER_00", Text: "Hello"},
+				{Start: 1.5, End: 3, Speaker: "SPEAKER_01", Text: "world"},
+			},
+			SpeakerNames: map[string]string{"SPEAKER_00": "Alice"},
+		}
+
+		dto := mapper.ToDTO(transcription)
+
+		if assert.Len(t, dto.Segments, 2) {
+			assert.Equal(t, "SPEAKER_00", dto.Segments[0].Speaker)
+			assert.Equal(t, "Alice", dto.Segments[0].SpeakerName)
+			assert.Equal(t, "SPEAKER_01", dto.Segments[1].SpeakerName)
+			assert.Equal(t, 1.5, dto.Segments[1].Start)
+		}
+
+		speakers := mapper.ToSpeakerDTOs(transcription)
+		assert.Equal(t, "Alice", speakers[0].Name)
+		assert.Equal(t, "SPEAKER_01", speakers[1].Label)
+	})
+
+	t.Run("Convert redacted transcription", func(t *testing.T) {
+		transcription := &entities.Transcription{
+			ID:     uuid.New(),
+			UserID: uuid.New(),
+			Text:   "Mail jane@example.com",
+			Status: entities.StatusCompleted,
+			Redaction: &entities.Redaction{
+				Text:     "Mail [EMAIL]",
+				Findings: []entities.PIIFinding{{Kind: entities.PIIEmail, Start: 5, End: 21}},
+			},
+		}
+
+		dto := mapper.ToDTO(transcription)
+		assert.False(t, dto.Redacted)
+		assert.Equal(t, "Mail [EMAIL]", dto.RedactedText)
+		assert.Equal(t, map[string]int{"email": 1}, dto.PII)
+
+		transcription.RawText = "mail jane@example.com"
+		assert.Equal(t, "mail jane@example.com", mapper.ToDTO(transcription).RawText)
+
+		transcription.RedactionRequired = true
+		transcription.Text = "Mail [EMAIL]"
+
+		dto = mapper.ToDTO(transcription)
+		assert.True(t, dto.Redacted)
+		assert.Equal(t, "Mail [EMAIL]", dto.Text)
+		assert.Empty(t, dto.RedactedText)
+		assert.Empty(t, dto.RawText)
+	})
+
 	t.Run("Convert nil transcription", func(t *testing.T) {
 		dto := mapper.ToDTO(nil)
 		assert.Nil(t, dto)
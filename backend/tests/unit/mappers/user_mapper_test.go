@@ -20,6 +20,7 @@ func TestUserMapper_ToDTO(t *testing.T) {
 			ID:        uuid.New(),
 			Email:     "test@example.com",
 			Name:      "Test User",
+			Role:      entities.RoleSupport,
 			CreatedAt: time.Now(),
 		}
 
@@ -29,6 +30,7 @@ func TestUserMapper_ToDTO(t *testing.T) {
 		assert.Equal(t, user.ID.String(), dto.ID)
 		assert.Equal(t, user.Email, dto.Email)
 		assert.Equal(t, user.Name, dto.Name)
+		assert.Equal(t, entities.RoleSupport, dto.Role)
 		assert.Equal(t, user.CreatedAt, dto.CreatedAt)
 	})
 